@@ -0,0 +1,121 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: matcher.proto
+
+// The ExternalMatcher service lets PodCleanupPolicies consult an organization's own
+// logic, e.g. an ownership database or the status of a ticket, when selecting
+// candidate pods. See spec.externalMatcher.
+//
+// Regenerate the Go code from the repository root with:
+//
+//	buf generate --template api/matcher/v1/buf.gen.yaml api/matcher/v1
+
+package matcherv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	ExternalMatcher_Match_FullMethodName = "/cleanup.matcher.v1.ExternalMatcher/Match"
+)
+
+// ExternalMatcherClient is the client API for ExternalMatcher service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ExternalMatcherClient interface {
+	// Match is called for every pod that meets all other criteria of a policy, before
+	// protections and retention apply. A pod that does not match is not a candidate.
+	Match(ctx context.Context, in *MatchRequest, opts ...grpc.CallOption) (*MatchResponse, error)
+}
+
+type externalMatcherClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewExternalMatcherClient(cc grpc.ClientConnInterface) ExternalMatcherClient {
+	return &externalMatcherClient{cc}
+}
+
+func (c *externalMatcherClient) Match(ctx context.Context, in *MatchRequest, opts ...grpc.CallOption) (*MatchResponse, error) {
+	out := new(MatchResponse)
+	err := c.cc.Invoke(ctx, ExternalMatcher_Match_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ExternalMatcherServer is the server API for ExternalMatcher service.
+// All implementations must embed UnimplementedExternalMatcherServer
+// for forward compatibility
+type ExternalMatcherServer interface {
+	// Match is called for every pod that meets all other criteria of a policy, before
+	// protections and retention apply. A pod that does not match is not a candidate.
+	Match(context.Context, *MatchRequest) (*MatchResponse, error)
+	mustEmbedUnimplementedExternalMatcherServer()
+}
+
+// UnimplementedExternalMatcherServer must be embedded to have forward compatible implementations.
+type UnimplementedExternalMatcherServer struct {
+}
+
+func (UnimplementedExternalMatcherServer) Match(context.Context, *MatchRequest) (*MatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Match not implemented")
+}
+func (UnimplementedExternalMatcherServer) mustEmbedUnimplementedExternalMatcherServer() {}
+
+// UnsafeExternalMatcherServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ExternalMatcherServer will
+// result in compilation errors.
+type UnsafeExternalMatcherServer interface {
+	mustEmbedUnimplementedExternalMatcherServer()
+}
+
+func RegisterExternalMatcherServer(s grpc.ServiceRegistrar, srv ExternalMatcherServer) {
+	s.RegisterService(&ExternalMatcher_ServiceDesc, srv)
+}
+
+func _ExternalMatcher_Match_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExternalMatcherServer).Match(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExternalMatcher_Match_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExternalMatcherServer).Match(ctx, req.(*MatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ExternalMatcher_ServiceDesc is the grpc.ServiceDesc for ExternalMatcher service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ExternalMatcher_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "cleanup.matcher.v1.ExternalMatcher",
+	HandlerType: (*ExternalMatcherServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Match",
+			Handler:    _ExternalMatcher_Match_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "matcher.proto",
+}
@@ -0,0 +1,44 @@
+package v1
+
+const (
+	// AnnotationOptOut on a namespace, when set to "true", excludes the namespace
+	// from cleanup by every policy.
+	AnnotationOptOut = "cleanup.k8s.io/opt-out"
+
+	// AnnotationTTLOverride on a namespace lengthens the effective maxAge of every
+	// policy for pods in that namespace (e.g. "72h"). It never shortens maxAge.
+	AnnotationTTLOverride = "cleanup.k8s.io/ttl-override"
+
+	// AnnotationNamespaceTTL on a namespace overrides the namespaceTTL.ttl of every
+	// policy for that namespace (e.g. "4h"), lengthening or shortening its lifetime.
+	AnnotationNamespaceTTL = "cleanup.k8s.io/namespace-ttl"
+
+	// AnnotationDrain on a cordoned node, when set to "true", lets NodeDrainCleanups
+	// drain it.
+	AnnotationDrain = "cleanup.k8s.io/drain"
+
+	// AnnotationDrainedAt is set on a node drained by a NodeDrainCleanup, with the
+	// RFC 3339 time at which its last pod left.
+	AnnotationDrainedAt = "cleanup.k8s.io/drained-at"
+
+	// AnnotationRunNow on a policy, when set to "true", triggers an immediate run
+	// regardless of schedule. The controller removes the annotation once the run starts.
+	AnnotationRunNow = "cleanup.k8s.io/run-now"
+
+	// AnnotationAcknowledgeAlert on a policy, when set to "true", lets its next run
+	// delete pods even if their count exceeds spec.alertThreshold. The controller
+	// removes the annotation once that run starts.
+	AnnotationAcknowledgeAlert = "cleanup.k8s.io/acknowledge-alert"
+
+	// AnnotationCandidateOf is set on pods a dry-run policy would delete, naming the policy.
+	AnnotationCandidateOf = "cleanup.k8s.io/candidate-of"
+
+	// AnnotationCandidateDeletionTime is set alongside AnnotationCandidateOf with the
+	// RFC 3339 time at which the policy would delete the pod if it were not a dry run.
+	AnnotationCandidateDeletionTime = "cleanup.k8s.io/candidate-deletion-time"
+
+	// AnnotationDeletionAt is set alongside AnnotationCandidateOf on pods a policy with
+	// spec.warnBefore will delete, with the RFC 3339 time of the first run allowed to
+	// delete the pod.
+	AnnotationDeletionAt = "cleanup.k8s.io/deletion-at"
+)
@@ -0,0 +1,86 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CleanupReportName is the name of the CleanupReport the controller maintains in
+// each namespace it cleans up.
+const CleanupReportName = "pod-cleanup"
+
+// CleanupCount is the number of pods removed for one key, e.g. a policy or phase.
+type CleanupCount struct {
+	Name  string `json:"name"`
+	Count int32  `json:"count"`
+}
+
+// OwnerCleanupCount is the number of removed pods controlled by one owner.
+type OwnerCleanupCount struct {
+	// Kind is the owner's kind, e.g. Job or ReplicaSet.
+	Kind  string `json:"kind"`
+	Name  string `json:"name"`
+	Count int32  `json:"count"`
+}
+
+// CleanupReportStatus summarizes the pods removed in the namespace since PeriodStart.
+type CleanupReportStatus struct {
+	// PeriodStart is when the period the report covers started. A new period starts
+	// with the first removal a day after it.
+	// +optional
+	PeriodStart *metav1.Time `json:"periodStart,omitempty"`
+
+	// LastUpdateTime is when the report last changed.
+	// +optional
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
+
+	// PodsRemoved is the number of pods deleted or evicted in the period.
+	// +optional
+	PodsRemoved int32 `json:"podsRemoved,omitempty"`
+
+	// ByPolicy counts the removed pods by the PodCleanupPolicy that removed them.
+	// +optional
+	ByPolicy []CleanupCount `json:"byPolicy,omitempty"`
+
+	// ByPhase counts the removed pods by their phase.
+	// +optional
+	ByPhase []CleanupCount `json:"byPhase,omitempty"`
+
+	// TopOwners lists the owners with the most removed pods, up to MaxReportedOwners.
+	// Pods without a controlling owner are not counted.
+	// +optional
+	TopOwners []OwnerCleanupCount `json:"topOwners,omitempty"`
+}
+
+// MaxReportedOwners caps the owners listed in a CleanupReport.
+const MaxReportedOwners = 10
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:shortName=pcrep
+//+kubebuilder:printcolumn:name="PodsRemoved",type=integer,JSONPath=`.status.podsRemoved`
+//+kubebuilder:printcolumn:name="Since",type=date,JSONPath=`.status.periodStart`
+//+kubebuilder:printcolumn:name="Updated",type=date,JSONPath=`.status.lastUpdateTime`
+
+// CleanupReport is the Schema for the cleanupreports API.
+// It is maintained by the controller in each namespace it removes pods from, and
+// summarizes the cleanup activity there, so namespace owners can follow it with
+// namespaced read access.
+type CleanupReport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Status CleanupReportStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// CleanupReportList contains a list of CleanupReport
+type CleanupReportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CleanupReport `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CleanupReport{}, &CleanupReportList{})
+}
@@ -0,0 +1,152 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MaxRequestCandidates caps the candidates recorded in the status of a preview
+// CleanupRequest.
+const MaxRequestCandidates = 1000
+
+// CleanupRequestSpec defines a single on-demand run of a policy.
+// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="spec is immutable"
+// +kubebuilder:validation:XValidation:rule="!has(self.explain) || !self.explain || (has(self.preview) && self.preview)",message="explain requires preview"
+type CleanupRequestSpec struct {
+	// PolicyName is the PodCleanupPolicy to run.
+	// +kubebuilder:validation:MinLength=1
+	PolicyName string `json:"policyName"`
+
+	// NamespaceSelector narrows this run to the namespaces matched by both the
+	// policy's namespaceSelector and this selector. A request can never widen a run.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// PodSelector narrows this run to the pods matched by both the policy's
+	// podSelector and this selector. A request can never widen a run.
+	// +optional
+	PodSelector *metav1.LabelSelector `json:"podSelector,omitempty"`
+
+	// DryRun if true, forces the run into dry-run mode. It cannot turn off dry-run
+	// mode requested by the policy or the OperatorConfig.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// AcknowledgeAlert if true, lets the run delete pods even if their count exceeds
+	// the policy's alertThreshold.
+	// +optional
+	AcknowledgeAlert bool `json:"acknowledgeAlert,omitempty"`
+
+	// Preview if true, evaluates the policy like a preview run: nothing is deleted,
+	// annotated or recorded in a CleanupRun, and the pods a run would delete are
+	// listed in status.candidates.
+	// +optional
+	Preview bool `json:"preview,omitempty"`
+
+	// Explain if true, lists why the preview selected or skipped each evaluated pod
+	// in status.decisions. Requires preview.
+	// +optional
+	Explain bool `json:"explain,omitempty"`
+}
+
+// CleanupRequestStatus records the outcome of the requested run.
+type CleanupRequestStatus struct {
+	// Phase is the lifecycle phase of the run. Requests without a phase have not
+	// started yet.
+	// +optional
+	Phase CleanupRunPhase `json:"phase,omitempty"`
+
+	// RunName is the CleanupRun recording the run.
+	// +optional
+	RunName string `json:"runName,omitempty"`
+
+	// StartTime is when the run started.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is when the run finished.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// DryRun is true when the run only reported what it would delete.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// PodsDeleted is the number of pods deleted (or would-be deleted) by the run.
+	// +optional
+	PodsDeleted int32 `json:"podsDeleted,omitempty"`
+
+	// PodsSkippedByPriority is the number of candidate pods left to a higher-priority policy.
+	// +optional
+	PodsSkippedByPriority int32 `json:"podsSkippedByPriority,omitempty"`
+
+	// PodsProtected is the number of candidate pods shielded by a Protect policy.
+	// +optional
+	PodsProtected int32 `json:"podsProtected,omitempty"`
+
+	// PodsRetained is the number of candidate pods kept by a PodRetentionPolicy.
+	// +optional
+	PodsRetained int32 `json:"podsRetained,omitempty"`
+
+	// PodsDeferredByQuota is the number of pods not deleted because their tenant
+	// exhausted its daily deletion quota.
+	// +optional
+	PodsDeferredByQuota int32 `json:"podsDeferredByQuota,omitempty"`
+
+	// PodsDeferredByBudget is the number of pods not deleted because a
+	// ClusterCleanupBudget was exhausted.
+	// +optional
+	PodsDeferredByBudget int32 `json:"podsDeferredByBudget,omitempty"`
+
+	// Message is a human-readable summary of the outcome.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Candidates lists the pods a preview would delete, capped at 1000 entries;
+	// podsDeleted counts all of them.
+	// +optional
+	Candidates []PreviewCandidate `json:"candidates,omitempty"`
+
+	// Decisions explains why a preview with explain selected or skipped each
+	// evaluated pod, capped at MaxRecordedDecisions entries.
+	// +optional
+	Decisions []PodDecision `json:"decisions,omitempty"`
+
+	// DecisionsOmitted is the number of decisions left out of Decisions by the cap.
+	// +optional
+	DecisionsOmitted int32 `json:"decisionsOmitted,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Cluster,shortName=pcq
+//+kubebuilder:printcolumn:name="Policy",type=string,JSONPath=`.spec.policyName`
+//+kubebuilder:printcolumn:name="DryRun",type=boolean,JSONPath=`.status.dryRun`
+//+kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+//+kubebuilder:printcolumn:name="PodsDeleted",type=integer,JSONPath=`.status.podsDeleted`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// CleanupRequest is the Schema for the cleanuprequests API.
+// Creating one executes exactly one run of the referenced PodCleanupPolicy, optionally
+// with narrower selectors, forced into dry-run mode or as a preview, and records the
+// outcome in its status. Unlike the run-now annotation, who may request runs is controlled by RBAC on
+// this resource, and each request remains as an audit record.
+type CleanupRequest struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CleanupRequestSpec   `json:"spec,omitempty"`
+	Status CleanupRequestStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// CleanupRequestList contains a list of CleanupRequest
+type CleanupRequestList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CleanupRequest `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CleanupRequest{}, &CleanupRequestList{})
+}
@@ -0,0 +1,183 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// LabelPolicy is set on objects created for a policy, naming the policy.
+	LabelPolicy = "cleanup.k8s.io/policy"
+	// LabelRunReport is set to "true" on the ConfigMaps holding run reports.
+	LabelRunReport = "cleanup.k8s.io/run-report"
+	// LabelArchiveRun is set on the ConfigMaps archiving pod manifests, naming the
+	// CleanupRun that removed the pods.
+	LabelArchiveRun = "cleanup.k8s.io/archive-run"
+	// LabelRunID carries the unique ID of a run. It labels the CleanupRun, report
+	// and archive ConfigMaps of the run, and annotates its Events and archived pods.
+	LabelRunID = "cleanup.k8s.io/run-id"
+	// LabelHook is set on hook Jobs to preRun or postRun.
+	LabelHook = "cleanup.k8s.io/hook"
+)
+
+// RecordSchemaVersion is the version of the JSON documents describing runs: run
+// reports, notifications and digests. Fields may be added within a version; a
+// field is only removed or changed with a new version.
+const RecordSchemaVersion = "v1"
+
+// RunTrigger describes what started a cleanup run.
+// +kubebuilder:validation:Enum=Schedule;Manual;Request;Retry;Maintenance;ScaleDown
+type RunTrigger string
+
+const (
+	// TriggerSchedule marks runs started by the policy schedule (or on every reconcile
+	// for unscheduled policies).
+	TriggerSchedule RunTrigger = "Schedule"
+	// TriggerManual marks runs requested explicitly, e.g. through the run-now annotation.
+	TriggerManual RunTrigger = "Manual"
+	// TriggerRequest marks runs executed for a CleanupRequest.
+	TriggerRequest RunTrigger = "Request"
+	// TriggerRetry marks runs retrying a previous run that hit transient errors.
+	TriggerRetry RunTrigger = "Retry"
+	// TriggerMaintenance marks runs cleaning up nodes that were labeled for maintenance.
+	TriggerMaintenance RunTrigger = "Maintenance"
+	// TriggerScaleDown marks runs cleaning up nodes the cluster-autoscaler picked for
+	// scale down.
+	TriggerScaleDown RunTrigger = "ScaleDown"
+)
+
+// CleanupRunPhase is the lifecycle phase of a cleanup run.
+// +kubebuilder:validation:Enum=Running;Succeeded;Failed
+type CleanupRunPhase string
+
+const (
+	// RunPhaseRunning means the run is still in progress.
+	RunPhaseRunning CleanupRunPhase = "Running"
+	// RunPhaseSucceeded means the run completed.
+	RunPhaseSucceeded CleanupRunPhase = "Succeeded"
+	// RunPhaseFailed means the run stopped with an error.
+	RunPhaseFailed CleanupRunPhase = "Failed"
+)
+
+// CleanupRunSpec identifies the policy run a CleanupRun records.
+type CleanupRunSpec struct {
+	// PolicyName is the PodCleanupPolicy that executed the run.
+	PolicyName string `json:"policyName"`
+
+	// RunID is the unique ID of the run, matching the policy's status.lastRunID once
+	// the run is accounted.
+	// +optional
+	RunID types.UID `json:"runID,omitempty"`
+
+	// Trigger is what started the run.
+	Trigger RunTrigger `json:"trigger"`
+
+	// DryRun is true when the run only reported what it would delete.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// CleanupRunStatus records the progress and outcome of a cleanup run.
+type CleanupRunStatus struct {
+	// Phase is the lifecycle phase of the run.
+	// +optional
+	Phase CleanupRunPhase `json:"phase,omitempty"`
+
+	// StartTime is when the run started.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is when the run finished.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// NamespacesTotal is the number of namespaces the run targets.
+	// +optional
+	NamespacesTotal int32 `json:"namespacesTotal,omitempty"`
+
+	// NamespacesProcessed is the number of namespaces the run has finished.
+	// +optional
+	NamespacesProcessed int32 `json:"namespacesProcessed,omitempty"`
+
+	// PodsDeleted is the number of pods deleted (or would-be deleted) so far.
+	// +optional
+	PodsDeleted int32 `json:"podsDeleted,omitempty"`
+
+	// Message is a human-readable summary of the outcome.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Decisions explains why each evaluated pod was selected or skipped. Only recorded
+	// for dry-run and preview runs of policies with explain enabled, and capped at
+	// MaxRecordedDecisions entries.
+	// +optional
+	Decisions []PodDecision `json:"decisions,omitempty"`
+
+	// DecisionsOmitted is the number of decisions left out of Decisions by the cap.
+	// +optional
+	DecisionsOmitted int32 `json:"decisionsOmitted,omitempty"`
+
+	// PodsArchived is the number of pod manifests archived before their removal.
+	// +optional
+	PodsArchived int32 `json:"podsArchived,omitempty"`
+
+	// ArchiveLocations lists, in order, where the archived manifests were stored:
+	// ConfigMap names in the operator namespace, or gs:// and https:// object URLs.
+	// +optional
+	ArchiveLocations []string `json:"archiveLocations,omitempty"`
+}
+
+// MaxRecordedDecisions caps the decisions recorded in a CleanupRun.
+const MaxRecordedDecisions = 500
+
+// PodDecision explains why a run selected or skipped a pod, or a whole namespace.
+type PodDecision struct {
+	Namespace string `json:"namespace"`
+
+	// Pod is empty for decisions about a whole namespace.
+	// +optional
+	Pod string `json:"pod,omitempty"`
+
+	// Selected is true if the run would delete the pod.
+	Selected bool `json:"selected"`
+
+	// Reason is a CamelCase code for the criterion that decided, e.g. PhaseNotSelected.
+	Reason string `json:"reason"`
+
+	// Message describes the decision, including the values compared.
+	Message string `json:"message"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Cluster,shortName=pcr
+//+kubebuilder:printcolumn:name="Policy",type=string,JSONPath=`.spec.policyName`
+//+kubebuilder:printcolumn:name="Trigger",type=string,JSONPath=`.spec.trigger`
+//+kubebuilder:printcolumn:name="DryRun",type=boolean,JSONPath=`.spec.dryRun`
+//+kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+//+kubebuilder:printcolumn:name="PodsDeleted",type=integer,JSONPath=`.status.podsDeleted`
+//+kubebuilder:printcolumn:name="Started",type=date,JSONPath=`.status.startTime`
+
+// CleanupRun is the Schema for the cleanupruns API.
+// It is created by the controller for every run of a PodCleanupPolicy and records
+// the run's progress and outcome. Old runs are pruned per the policy's runHistoryLimit.
+type CleanupRun struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CleanupRunSpec   `json:"spec,omitempty"`
+	Status CleanupRunStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// CleanupRunList contains a list of CleanupRun
+type CleanupRunList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CleanupRun `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CleanupRun{}, &CleanupRunList{})
+}
@@ -0,0 +1,65 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CleanupScheduleSpec defines when the policies referencing the schedule run.
+type CleanupScheduleSpec struct {
+	// Schedule is a cron expression for when to run cleanup (e.g., "0 2 * * *").
+	// +kubebuilder:validation:Pattern=`^((CRON_TZ|TZ)=\S+\s+)?[0-9A-Za-z*?/,-]+(\s+[0-9A-Za-z*?/,-]+){4}$`
+	Schedule string `json:"schedule"`
+
+	// TimeZone is the IANA time zone the schedule and blackout windows are
+	// interpreted in, e.g. "Europe/Berlin". If not set, the operator's local time
+	// zone is used, as for policy schedules.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// BlackoutWindows lists recurring windows in which scheduled runs do not start.
+	// A run scheduled within a window is skipped, and the next run is the first one
+	// scheduled outside every window. Runs in progress are not stopped, and manual
+	// runs and CleanupRequests are not held back.
+	// +optional
+	BlackoutWindows []BlackoutWindow `json:"blackoutWindows,omitempty"`
+}
+
+// BlackoutWindow is a recurring window in which scheduled runs do not start.
+type BlackoutWindow struct {
+	// Start is a cron expression for when the window opens, e.g. "0 0 24 12 *".
+	// +kubebuilder:validation:Pattern=`^((CRON_TZ|TZ)=\S+\s+)?[0-9A-Za-z*?/,-]+(\s+[0-9A-Za-z*?/,-]+){4}$`
+	Start string `json:"start"`
+
+	// Duration is how long the window stays open, e.g. "8h" or "2d".
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h|d|w))+$`
+	Duration string `json:"duration"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Cluster,shortName=csch
+//+kubebuilder:printcolumn:name="Schedule",type=string,JSONPath=`.spec.schedule`
+//+kubebuilder:printcolumn:name="TimeZone",type=string,JSONPath=`.spec.timeZone`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// CleanupSchedule is the Schema for the cleanupschedules API.
+// It is a maintenance-window definition shared by the policies that reference it
+// through spec.scheduleRef, so their schedule is updated in one place.
+type CleanupSchedule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CleanupScheduleSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// CleanupScheduleList contains a list of CleanupSchedule
+type CleanupScheduleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CleanupSchedule `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CleanupSchedule{}, &CleanupScheduleList{})
+}
@@ -0,0 +1,137 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// MaxSimulatedNamespaces caps the namespaces listed in status.byNamespace.
+	MaxSimulatedNamespaces = 50
+	// MaxSimulatedSamplePods caps the pods listed in status.samplePods.
+	MaxSimulatedSamplePods = 20
+)
+
+// CleanupSimulationSpec defines the policy to simulate.
+// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="spec is immutable"
+type CleanupSimulationSpec struct {
+	// Policy is the spec of the PodCleanupPolicy to evaluate, as if it were created
+	// with the simulation's name. Its dryRun, preview and suspend fields are ignored.
+	Policy PodCleanupPolicySpec `json:"policy"`
+}
+
+// BudgetImpact is the effect a simulated run would have on one ClusterCleanupBudget.
+type BudgetImpact struct {
+	// Name is the name of the ClusterCleanupBudget.
+	Name string `json:"name"`
+
+	// Deletions is the number of pods the budget had counted within its window when
+	// the simulation ran.
+	// +optional
+	Deletions int32 `json:"deletions,omitempty"`
+
+	// WouldDelete is the number of candidates the budget would let the run remove.
+	// +optional
+	WouldDelete int32 `json:"wouldDelete,omitempty"`
+
+	// WouldDefer is the number of candidates the run would defer because this
+	// budget is exhausted.
+	// +optional
+	WouldDefer int32 `json:"wouldDefer,omitempty"`
+}
+
+// CleanupSimulationStatus reports what a run of the simulated policy would do.
+type CleanupSimulationStatus struct {
+	// Phase is the lifecycle phase of the simulation. Simulations without a phase
+	// have not started yet.
+	// +optional
+	Phase CleanupRunPhase `json:"phase,omitempty"`
+
+	// StartTime is when the simulation started.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is when the simulation finished.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// CandidateCount is the number of pods a run would delete or evict.
+	// +optional
+	CandidateCount int32 `json:"candidateCount,omitempty"`
+
+	// ByNamespace counts the candidates per namespace, largest first, capped at 50
+	// namespaces.
+	// +optional
+	ByNamespace []CleanupCount `json:"byNamespace,omitempty"`
+
+	// SamplePods lists some of the candidates, capped at 20 pods.
+	// +optional
+	SamplePods []PreviewCandidate `json:"samplePods,omitempty"`
+
+	// PodsSkippedByPriority is the number of candidate pods a higher-priority policy
+	// would decide for.
+	// +optional
+	PodsSkippedByPriority int32 `json:"podsSkippedByPriority,omitempty"`
+
+	// PodsProtected is the number of candidate pods shielded by a Protect policy.
+	// +optional
+	PodsProtected int32 `json:"podsProtected,omitempty"`
+
+	// PodsRetained is the number of candidate pods kept by a PodRetentionPolicy.
+	// +optional
+	PodsRetained int32 `json:"podsRetained,omitempty"`
+
+	// PodsDeniedByDecision is the number of candidate pods the OperatorConfig's
+	// decision point would not allow to be removed.
+	// +optional
+	PodsDeniedByDecision int32 `json:"podsDeniedByDecision,omitempty"`
+
+	// PodsDeferredByBudget is the number of candidates a run would defer because a
+	// ClusterCleanupBudget is exhausted, given the budgets' usage when the
+	// simulation ran.
+	// +optional
+	PodsDeferredByBudget int32 `json:"podsDeferredByBudget,omitempty"`
+
+	// Budgets reports the impact of a run on each ClusterCleanupBudget.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Budgets []BudgetImpact `json:"budgets,omitempty"`
+
+	// Message is a human-readable summary of the outcome.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Cluster,shortName=pcsim
+//+kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+//+kubebuilder:printcolumn:name="Candidates",type=integer,JSONPath=`.status.candidateCount`
+//+kubebuilder:printcolumn:name="Deferred",type=integer,JSONPath=`.status.podsDeferredByBudget`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// CleanupSimulation is the Schema for the cleanupsimulations API.
+// Creating one evaluates an inline policy spec once, read-only, and reports in its
+// status which pods a run would delete, where they are and how the run would draw
+// on the ClusterCleanupBudgets. Nothing is deleted, annotated or recorded, so policy
+// authors and reviewers can try a policy before creating it.
+type CleanupSimulation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CleanupSimulationSpec   `json:"spec,omitempty"`
+	Status CleanupSimulationStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// CleanupSimulationList contains a list of CleanupSimulation
+type CleanupSimulationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CleanupSimulation `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CleanupSimulation{}, &CleanupSimulationList{})
+}
@@ -0,0 +1,82 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterCleanupBudgetSpec limits the pods all policies together may delete or evict
+// within a rolling time window.
+// +kubebuilder:validation:XValidation:rule="has(self.maxDeletions) || has(self.maxDeletionsPerNamespace)",message="maxDeletions or maxDeletionsPerNamespace is required"
+type ClusterCleanupBudgetSpec struct {
+	// Window is the rolling time window the limits apply to, e.g. "1h" or "1d".
+	// +kubebuilder:default="1h"
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h|d|w))+$`
+	// +optional
+	Window string `json:"window,omitempty"`
+
+	// MaxDeletions caps the pods deleted or evicted by all policies within the window.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxDeletions *int32 `json:"maxDeletions,omitempty"`
+
+	// MaxDeletionsPerNamespace caps the pods deleted or evicted by all policies in any
+	// single namespace within the window.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxDeletionsPerNamespace *int32 `json:"maxDeletionsPerNamespace,omitempty"`
+}
+
+// ClusterCleanupBudgetStatus reports how much of the budget is used.
+type ClusterCleanupBudgetStatus struct {
+	// Deletions is the number of pods deleted or evicted within the current window.
+	// +optional
+	Deletions int32 `json:"deletions,omitempty"`
+
+	// ExhaustedNamespaces lists the namespaces that used up maxDeletionsPerNamespace,
+	// capped at 10 names.
+	// +optional
+	ExhaustedNamespaces []string `json:"exhaustedNamespaces,omitempty"`
+
+	// ObservedGeneration is the generation of the spec the status reflects.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions represents the latest available observations of the budget's state.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Cluster,shortName=ccb
+//+kubebuilder:printcolumn:name="Window",type=string,JSONPath=`.spec.window`
+//+kubebuilder:printcolumn:name="Max",type=integer,JSONPath=`.spec.maxDeletions`
+//+kubebuilder:printcolumn:name="Deletions",type=integer,JSONPath=`.status.deletions`
+//+kubebuilder:printcolumn:name="Exhausted",type=string,JSONPath=`.status.conditions[?(@.type=="Exhausted")].status`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// ClusterCleanupBudget is the Schema for the clustercleanupbudgets API.
+// It is a global brake on cleanup: every policy reserves budget before deleting or
+// evicting a pod, and defers the pod while any budget is exhausted.
+type ClusterCleanupBudget struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterCleanupBudgetSpec   `json:"spec,omitempty"`
+	Status ClusterCleanupBudgetStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ClusterCleanupBudgetList contains a list of ClusterCleanupBudget
+type ClusterCleanupBudgetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterCleanupBudget `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterCleanupBudget{}, &ClusterCleanupBudgetList{})
+}
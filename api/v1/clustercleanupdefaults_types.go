@@ -0,0 +1,53 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterCleanupDefaultsSpec holds settings inherited by every PodCleanupPolicy that
+// references it through spec.defaultsFrom. Fields set on the policy take precedence.
+type ClusterCleanupDefaultsSpec struct {
+	// GracePeriodSeconds is the termination grace period sent with every pod deletion.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	GracePeriodSeconds *int64 `json:"gracePeriodSeconds,omitempty"`
+
+	// RateLimit bounds how fast each referencing policy deletes pods.
+	// +optional
+	RateLimit *RateLimit `json:"rateLimit,omitempty"`
+
+	// Notifications lists endpoints that receive a summary of every run of a referencing policy.
+	// +optional
+	Notifications []NotificationEndpoint `json:"notifications,omitempty"`
+
+	// Archive stores the manifests of the pods each referencing policy removes.
+	// Requires the Archive feature gate.
+	// +optional
+	Archive *ArchiveSpec `json:"archive,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Cluster,shortName=ccd
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// ClusterCleanupDefaults is the Schema for the clustercleanupdefaults API.
+// It is a reusable block of settings merged into the policies that reference it.
+type ClusterCleanupDefaults struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ClusterCleanupDefaultsSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ClusterCleanupDefaultsList contains a list of ClusterCleanupDefaults
+type ClusterCleanupDefaultsList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterCleanupDefaults `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterCleanupDefaults{}, &ClusterCleanupDefaultsList{})
+}
@@ -0,0 +1,42 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterTargetSpec defines how the operator reaches a workload cluster.
+type ClusterTargetSpec struct {
+	// KubeconfigSecretRef selects the kubeconfig of the workload cluster. The
+	// operator acts in the cluster with the kubeconfig's credentials, which need the
+	// permissions on pods and namespaces the referencing policies use.
+	KubeconfigSecretRef SecretKeyRef `json:"kubeconfigSecretRef"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Cluster,shortName=pct
+//+kubebuilder:printcolumn:name="Secret",type=string,JSONPath=`.spec.kubeconfigSecretRef.name`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// ClusterTarget is the Schema for the clustertargets API.
+// It names a workload cluster and points at a Secret holding its kubeconfig, so a
+// single operator in a management cluster can run policies in many clusters.
+// Policies list the ClusterTargets they clean up in spec.clusterRefs.
+type ClusterTarget struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ClusterTargetSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ClusterTargetList contains a list of ClusterTarget
+type ClusterTargetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterTarget `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterTarget{}, &ClusterTargetList{})
+}
@@ -0,0 +1,123 @@
+package v1
+
+// Condition types of a PodCleanupPolicy. Tools should key off these types and the
+// reasons below rather than condition messages, which may change.
+const (
+	// ConditionReady is True while the policy works as configured: its last run
+	// succeeded, or it is a Protect policy.
+	ConditionReady = "Ready"
+	// ConditionProgressing is True while a run of the policy is in progress.
+	ConditionProgressing = "Progressing"
+	// ConditionSuspended is True while spec.suspend holds the policy's runs.
+	ConditionSuspended = "Suspended"
+	// ConditionDegraded is True when the last run could not reach all of the pods it
+	// targets.
+	ConditionDegraded = "Degraded"
+	// ConditionScheduleValid is False when the policy's schedule cannot be used.
+	ConditionScheduleValid = "ScheduleValid"
+	// ConditionScheduleHealthy is False when a scheduled run is overdue.
+	ConditionScheduleHealthy = "ScheduleHealthy"
+	// ConditionBudgetExceeded is True when the last run deferred pods because a
+	// ClusterCleanupBudget or the tenant quota was exhausted.
+	ConditionBudgetExceeded = "BudgetExceeded"
+	// ConditionDryRunForced is True while the operator forces every policy into dry
+	// runs.
+	ConditionDryRunForced = "DryRunForced"
+	// ConditionNoMatches is True when the recent runs of the policy matched no
+	// namespaces or no pods, usually because of a mistyped selector.
+	ConditionNoMatches = "NoMatches"
+)
+
+// Reasons of the Ready condition.
+const (
+	ReasonCleanupSucceeded       = "CleanupSucceeded"
+	ReasonProtecting             = "Protecting"
+	ReasonInvalidSpec            = "InvalidSpec"
+	ReasonFeatureDisabled        = "FeatureDisabled"
+	ReasonSuspended              = "Suspended"
+	ReasonDefaultsNotFound       = "DefaultsNotFound"
+	ReasonCleanupFailed          = "CleanupFailed"
+	ReasonRunCanceled            = "RunCanceled"
+	ReasonAlertThresholdExceeded = "AlertThresholdExceeded"
+	// ReasonInvalidSchedule and ReasonScheduleNotFound are also reasons of the
+	// ScheduleValid condition.
+	ReasonInvalidSchedule  = "InvalidSchedule"
+	ReasonScheduleNotFound = "ScheduleNotFound"
+)
+
+// Reasons of the Progressing condition.
+const (
+	ReasonRunInProgress = "RunInProgress"
+	ReasonRunFinished   = "RunFinished"
+)
+
+// Reasons of the Suspended condition.
+const (
+	ReasonSuspendedBySpec = "SuspendedBySpec"
+	ReasonNotSuspended    = "NotSuspended"
+)
+
+// Reasons of the Degraded condition.
+const (
+	ReasonForbidden            = "Forbidden"
+	ReasonClustersUnavailable  = "ClustersUnavailable"
+	ReasonNamespacesAccessible = "NamespacesAccessible"
+)
+
+// Reasons of the ScheduleValid condition, besides ReasonInvalidSchedule and
+// ReasonScheduleNotFound.
+const (
+	ReasonScheduleParsed = "ScheduleParsed"
+	ReasonUnscheduled    = "Unscheduled"
+)
+
+// Reasons of the ScheduleHealthy condition.
+const (
+	ReasonOnSchedule = "OnSchedule"
+	ReasonRunOverdue = "RunOverdue"
+)
+
+// Reasons of the BudgetExceeded condition.
+const (
+	ReasonBudgetExhausted      = "BudgetExhausted"
+	ReasonTenantQuotaExhausted = "TenantQuotaExhausted"
+	ReasonWithinBudget         = "WithinBudget"
+)
+
+// Reasons of the DryRunForced condition.
+const (
+	ReasonForceDryRunFlag = "ForceDryRunFlag"
+	ReasonOperatorConfig  = "OperatorConfig"
+)
+
+// Reasons of the NoMatches condition.
+const (
+	ReasonMatched      = "Matched"
+	ReasonNoNamespaces = "NoNamespaces"
+	ReasonNoPods       = "NoPods"
+)
+
+// Condition types of a ClusterCleanupBudget, besides ConditionReady, which is True
+// while the budget is enforced.
+const (
+	// ConditionExhausted is True while the budget defers deletions.
+	ConditionExhausted = "Exhausted"
+)
+
+// Reasons of the Ready condition of a ClusterCleanupBudget, besides
+// ReasonInvalidSpec.
+const (
+	ReasonEnforced = "Enforced"
+)
+
+// Reasons of the Ready condition of a NodeDrainCleanup, besides ReasonInvalidSpec and
+// ReasonFeatureDisabled.
+const (
+	ReasonWatching = "Watching"
+)
+
+// Reasons of the Exhausted condition, besides ReasonWithinBudget.
+const (
+	ReasonMaxDeletionsReached          = "MaxDeletionsReached"
+	ReasonNamespaceMaxDeletionsReached = "NamespaceMaxDeletionsReached"
+)
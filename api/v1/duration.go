@@ -0,0 +1,114 @@
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// extendedUnits are the units ParseDuration accepts in addition to those of
+// time.ParseDuration.
+var extendedUnits = map[byte]time.Duration{
+	'd': 24 * time.Hour,
+	'w': 7 * 24 * time.Hour,
+}
+
+// ParseDuration parses a duration such as "90m", "7d" or "2w3d12h". It accepts the
+// units of time.ParseDuration plus "d" (24 hours) and "w" (7 days), but no sign: pod
+// ages are never negative.
+func ParseDuration(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	var total time.Duration
+	for rest := s; rest != ""; {
+		// Split off the next number and unit.
+		i := 0
+		for i < len(rest) && (rest[i] == '.' || ('0' <= rest[i] && rest[i] <= '9')) {
+			i++
+		}
+		j := i
+		for j < len(rest) && !(rest[j] == '.' || ('0' <= rest[j] && rest[j] <= '9')) {
+			j++
+		}
+		if i == 0 || j == i {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+		number, unit := rest[:i], rest[i:j]
+		rest = rest[j:]
+
+		var d time.Duration
+		if scale, ok := extendedUnits[unit[0]]; ok && len(unit) == 1 {
+			value, err := strconv.ParseFloat(number, 64)
+			if err != nil {
+				return 0, fmt.Errorf("invalid duration %q", s)
+			}
+			d = time.Duration(value * float64(scale))
+		} else {
+			var err error
+			if d, err = time.ParseDuration(number + unit); err != nil {
+				return 0, fmt.Errorf("invalid duration %q", s)
+			}
+		}
+		total += d
+	}
+	if total < 0 {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	return total, nil
+}
+
+// Duration is a duration in the format of ParseDuration, e.g. "36h", "7d" or "2w".
+// It is a string in the API, and serializes canonically in the largest units that
+// represent it exactly: "36h" is written back as "1d12h" and "14d" as "2w". Duration
+// fields of the API take a Duration rather than a string.
+// +kubebuilder:validation:Type=string
+// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h|d|w))+$`
+type Duration struct {
+	time.Duration `json:"-"`
+}
+
+// String returns the canonical form of the duration, e.g. "1w3d12h" or "1m30.5s".
+func (d Duration) String() string {
+	if d.Duration <= 0 {
+		return "0s"
+	}
+	var b strings.Builder
+	rest := d.Duration
+	for _, unit := range []struct {
+		suffix string
+		size   time.Duration
+	}{{"w", extendedUnits['w']}, {"d", extendedUnits['d']}, {"h", time.Hour}, {"m", time.Minute}} {
+		if n := rest / unit.size; n > 0 {
+			fmt.Fprintf(&b, "%d%s", n, unit.suffix)
+			rest -= n * unit.size
+		}
+	}
+	if rest > 0 {
+		// Seconds and below, e.g. "30s", "1.5s" or "250ms".
+		b.WriteString(rest.String())
+	}
+	return b.String()
+}
+
+// MarshalJSON writes the duration in its canonical form.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON reads a duration in any format ParseDuration accepts, so values
+// written as plain strings before the field became a Duration keep working.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("duration must be a string: %w", err)
+	}
+	parsed, err := ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	d.Duration = parsed
+	return nil
+}
@@ -0,0 +1,144 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DrainAction is how pods are removed from a drained node.
+// +kubebuilder:validation:Enum=Evict;Delete
+type DrainAction string
+
+const (
+	// DrainActionEvict evicts pods through the Eviction API, respecting
+	// PodDisruptionBudgets.
+	DrainActionEvict DrainAction = "Evict"
+	// DrainActionDelete deletes pods, ignoring PodDisruptionBudgets.
+	DrainActionDelete DrainAction = "Delete"
+)
+
+// NodeDrainCleanupSpec selects the nodes to drain and the pods removed from them.
+type NodeDrainCleanupSpec struct {
+	// NodeSelector restricts the drained nodes by their labels. If not set, every
+	// node that is cordoned and annotated cleanup.k8s.io/drain=true is drained.
+	// +optional
+	NodeSelector *metav1.LabelSelector `json:"nodeSelector,omitempty"`
+
+	// PodSelector restricts the removed pods by their labels. If not set, every pod
+	// on the node is removed, except DaemonSet and static pods.
+	// +optional
+	PodSelector *metav1.LabelSelector `json:"podSelector,omitempty"`
+
+	// Action is how pods are removed: Evict (default), which respects
+	// PodDisruptionBudgets, or Delete.
+	// +kubebuilder:default=Evict
+	// +optional
+	Action DrainAction `json:"action,omitempty"`
+
+	// GracePeriodSeconds is the termination grace period sent with every removal.
+	// Defaults to the grace period of each pod.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	GracePeriodSeconds *int64 `json:"gracePeriodSeconds,omitempty"`
+}
+
+// NodeDrainPhase is the progress of the drain of a node.
+// +kubebuilder:validation:Enum=Draining;Completed
+type NodeDrainPhase string
+
+const (
+	// NodeDrainDraining means pods are still being removed from the node.
+	NodeDrainDraining NodeDrainPhase = "Draining"
+	// NodeDrainCompleted means every selected pod left the node.
+	NodeDrainCompleted NodeDrainPhase = "Completed"
+)
+
+// NodeDrainStatus reports the progress of the drain of one node.
+type NodeDrainStatus struct {
+	// Name is the name of the node.
+	Name string `json:"name"`
+
+	// Phase is Draining or Completed.
+	Phase NodeDrainPhase `json:"phase"`
+
+	// StartTime is when the drain of the node started.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is when the last selected pod left the node.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// PodsRemoved is the number of pods evicted or deleted from the node.
+	// +optional
+	PodsRemoved int32 `json:"podsRemoved,omitempty"`
+
+	// PodsRemaining is the number of selected pods still on the node.
+	// +optional
+	PodsRemaining int32 `json:"podsRemaining,omitempty"`
+
+	// PodsBlocked is the number of pods whose eviction a PodDisruptionBudget refused
+	// in the latest attempt.
+	// +optional
+	PodsBlocked int32 `json:"podsBlocked,omitempty"`
+
+	// CurrentPriority is the priority of the pods being removed. Pods are removed in
+	// ascending order of priority, one priority at a time.
+	// +optional
+	CurrentPriority *int32 `json:"currentPriority,omitempty"`
+
+	// Message describes the latest attempt, e.g. a failed removal.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// NodeDrainCleanupStatus reports the drains in progress and completed.
+type NodeDrainCleanupStatus struct {
+	// Nodes lists the nodes annotated for drain that the NodeDrainCleanup selects.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Nodes []NodeDrainStatus `json:"nodes,omitempty"`
+
+	// ObservedGeneration is the generation of the spec the status reflects.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions represents the latest available observations of the
+	// NodeDrainCleanup's state.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Cluster,shortName=ndc
+//+kubebuilder:printcolumn:name="Action",type=string,JSONPath=`.spec.action`
+//+kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// NodeDrainCleanup is the Schema for the nodedraincleanups API.
+// It drains nodes that are cordoned and annotated cleanup.k8s.io/drain=true: it
+// removes their pods in ascending order of priority, reports the progress of each
+// node and annotates the node with cleanup.k8s.io/drained-at once it is empty.
+type NodeDrainCleanup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NodeDrainCleanupSpec   `json:"spec,omitempty"`
+	Status NodeDrainCleanupStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// NodeDrainCleanupList contains a list of NodeDrainCleanup
+type NodeDrainCleanupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NodeDrainCleanup `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&NodeDrainCleanup{}, &NodeDrainCleanupList{})
+}
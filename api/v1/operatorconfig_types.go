@@ -0,0 +1,494 @@
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OperatorConfigName is the name of the singleton OperatorConfig read by the controller.
+const OperatorConfigName = "cluster"
+
+// OperatorConfigSpec defines controller-wide defaults applied to every PodCleanupPolicy.
+type OperatorConfigSpec struct {
+	// GracePeriodSeconds is the termination grace period sent with every pod deletion.
+	// If not set, each pod's own terminationGracePeriodSeconds applies.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	GracePeriodSeconds *int64 `json:"gracePeriodSeconds,omitempty"`
+
+	// RateLimit bounds how fast the operator deletes pods across all policies.
+	// If not set, deletions are not rate limited.
+	// +optional
+	RateLimit *RateLimit `json:"rateLimit,omitempty"`
+
+	// MaxConcurrentAPICalls bounds the pod list, delete and evict calls in flight
+	// across all runs, so the load on the API server stays bounded however many
+	// policies run at once. Lists served from the operator's cache do not count.
+	// If not set, calls are not bounded.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxConcurrentAPICalls *int32 `json:"maxConcurrentAPICalls,omitempty"`
+
+	// TenantQuota caps how many pods the operator may delete per tenant in any rolling
+	// 24 hours. Deletions over the quota are deferred to later runs.
+	// +optional
+	TenantQuota *TenantQuota `json:"tenantQuota,omitempty"`
+
+	// ProtectedNamespaces lists namespaces that are never cleaned up, regardless of policy.
+	// +optional
+	ProtectedNamespaces []string `json:"protectedNamespaces,omitempty"`
+
+	// DryRun if true, forces every policy into dry-run mode regardless of its spec.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// Notifications lists endpoints that receive a summary of every cleanup run.
+	// +optional
+	Notifications []NotificationEndpoint `json:"notifications,omitempty"`
+
+	// RunReports if set, writes a JSON report of every run into a ConfigMap in the
+	// operator's namespace.
+	// +optional
+	RunReports *RunReports `json:"runReports,omitempty"`
+
+	// Audit configures outputs that receive an audit record of every pod the operator
+	// removes and of every run.
+	// +optional
+	Audit *AuditConfig `json:"audit,omitempty"`
+
+	// DecisionPoint, if set, asks an Open Policy Agent whether each pod may be
+	// removed before a run deletes or evicts it, so org-wide guardrails written in
+	// Rego apply to every policy.
+	// +optional
+	DecisionPoint *DecisionPoint `json:"decisionPoint,omitempty"`
+
+	// DisruptionAnnotations configures the pod annotations, shared with the rest of
+	// the disruption ecosystem, that keep pods from being deleted or evicted. If not
+	// set, karpenter.sh/do-not-disrupt: "true" and
+	// cluster-autoscaler.kubernetes.io/safe-to-evict: "false" protect pods.
+	// +optional
+	DisruptionAnnotations *DisruptionAnnotations `json:"disruptionAnnotations,omitempty"`
+
+	// ServiceMesh configures how running pods injected into a service mesh such as
+	// Istio or Linkerd are removed, so cleanup does not set off connection storms in
+	// the mesh. If not set, mesh pods are removed like any other.
+	// +optional
+	ServiceMesh *ServiceMesh `json:"serviceMesh,omitempty"`
+
+	// CostModel, if set, estimates the monthly savings of the pods every run removes
+	// from their resource requests, for run reports and metrics.
+	// +optional
+	CostModel *CostModel `json:"costModel,omitempty"`
+
+	// ChangeRecord, if set, files a change record in an ITSM system such as
+	// ServiceNow for every run that deletes more pods than its threshold.
+	// +optional
+	ChangeRecord *ChangeRecordWebhook `json:"changeRecord,omitempty"`
+}
+
+// ChangeRecordWebhook files change records through a REST API. The body of each
+// request is rendered from Template with the fields of the run's record: RunID, Run
+// (the CleanupRun), Report and ReportNamespace (the run report ConfigMap), Policy,
+// Trigger, StartTime, CompletionTime, PodsDeleted, Namespaces and Error.
+type ChangeRecordWebhook struct {
+	// URL receives a POST for every record, e.g.
+	// https://example.service-now.com/api/now/table/change_request.
+	URL string `json:"url"`
+
+	// Threshold is the number of pods a run must delete more than to be recorded.
+	// Defaults to 0: every run that deletes pods. Dry runs are never recorded.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Threshold int32 `json:"threshold,omitempty"`
+
+	// Template is a Go text/template rendering the request body. The json function
+	// encodes a value as JSON, e.g. {{ json .Policy }}. If not set, the ChangeRecord
+	// data is posted as JSON.
+	// +optional
+	Template string `json:"template,omitempty"`
+
+	// ContentType of the request body. Defaults to application/json.
+	// +optional
+	ContentType string `json:"contentType,omitempty"`
+
+	// Headers are added to every request, e.g. an Accept header.
+	// +optional
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// SecretRef selects a bearer token sent in the Authorization header of every
+	// request.
+	// +optional
+	SecretRef *SecretKeyRef `json:"secretRef,omitempty"`
+}
+
+// CostModel prices the resources pods request. A pod's cost is the sum of its
+// requests times their hourly prices.
+type CostModel struct {
+	// Prices maps resource names to their hourly price, as a decimal string: per core
+	// for cpu, per GiB for memory, ephemeral-storage and hugepages, and per unit for
+	// other resources such as nvidia.com/gpu. Resources without a price cost nothing.
+	// +kubebuilder:validation:MinProperties=1
+	Prices map[corev1.ResourceName]string `json:"prices"`
+
+	// Currency labels the estimates, e.g. USD.
+	// +optional
+	Currency string `json:"currency,omitempty"`
+}
+
+// DisruptionAnnotations configures which pod annotations protect pods from removal.
+type DisruptionAnnotations struct {
+	// Ignore, if true, removes pods regardless of their disruption annotations.
+	// +optional
+	Ignore bool `json:"ignore,omitempty"`
+
+	// Annotations replaces the default protecting annotations. A pod carrying one of
+	// them with the given value is not removed.
+	// +optional
+	Annotations []AnnotationMatch `json:"annotations,omitempty"`
+}
+
+// ServiceMesh configures the mesh-safe removal of pods injected into a service mesh.
+// It applies to pods that have not finished; finished pods hold no connections.
+type ServiceMesh struct {
+	// Annotations are the keys of the annotations marking mesh-injected pods. Defaults
+	// to sidecar.istio.io/status and linkerd.io/proxy-version.
+	// +optional
+	Annotations []string `json:"annotations,omitempty"`
+
+	// Exclude, if true, keeps mesh pods from being deleted or evicted by any policy.
+	// +optional
+	Exclude bool `json:"exclude,omitempty"`
+
+	// Evict, if true, evicts mesh pods through the Eviction API, honoring
+	// PodDisruptionBudgets, instead of deleting them. Requires the Eviction feature
+	// gate; without it, policies do not run.
+	// +optional
+	Evict bool `json:"evict,omitempty"`
+
+	// GracePeriodSeconds is the least termination grace period mesh pods are removed
+	// with, giving their proxies time to drain connections. Longer grace periods of
+	// the policy or the OperatorConfig are kept.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	GracePeriodSeconds *int64 `json:"gracePeriodSeconds,omitempty"`
+}
+
+// AnnotationMatch matches an annotation by key and value.
+type AnnotationMatch struct {
+	// Key is the annotation key.
+	// +kubebuilder:validation:MinLength=1
+	Key string `json:"key"`
+
+	// Value is the value the annotation must have.
+	Value string `json:"value"`
+}
+
+// DecisionFailurePolicy is what a run does with a pod when the decision point
+// cannot decide on it.
+// +kubebuilder:validation:Enum=Deny;Allow
+type DecisionFailurePolicy string
+
+const (
+	// DecisionFailureDeny keeps the pod; it is retried with the run.
+	DecisionFailureDeny DecisionFailurePolicy = "Deny"
+	// DecisionFailureAllow removes the pod as if the decision point allowed it.
+	DecisionFailureAllow DecisionFailurePolicy = "Allow"
+)
+
+// DecisionPoint is an Open Policy Agent queried through its Data API. Each query
+// posts the pod, its namespace, the policy and the action as input to
+// /v1/data/<path>.
+type DecisionPoint struct {
+	// URL is the base URL of the OPA server, e.g. http://opa.opa-system:8181.
+	URL string `json:"url"`
+
+	// Path is the decision under /v1/data, e.g. podcleanup/allow. The decision is a
+	// boolean, or an object with a boolean allow and an optional string reason.
+	// +kubebuilder:validation:MinLength=1
+	Path string `json:"path"`
+
+	// SecretRef selects a bearer token sent in the Authorization header of every query.
+	// +optional
+	SecretRef *SecretKeyRef `json:"secretRef,omitempty"`
+
+	// Timeout bounds each query, e.g. "2s". Defaults to 5s.
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h|d|w))+$`
+	// +optional
+	Timeout string `json:"timeout,omitempty"`
+
+	// FailurePolicy is Deny (the default) to keep pods the decision point cannot
+	// decide on, because it is unreachable or the decision is undefined, or Allow to
+	// remove them.
+	// +optional
+	FailurePolicy DecisionFailurePolicy `json:"failurePolicy,omitempty"`
+}
+
+// AuditConfig configures the audit outputs.
+type AuditConfig struct {
+	// Syslog sends audit records to a syslog server as RFC 5424 messages.
+	// +optional
+	Syslog *SyslogOutput `json:"syslog,omitempty"`
+
+	// Splunk sends audit records to a Splunk HTTP Event Collector, in batches.
+	// +optional
+	Splunk *SplunkOutput `json:"splunk,omitempty"`
+
+	// File appends audit records as JSON lines to a file on a volume of the operator
+	// pod, rotated by size and age.
+	// +optional
+	File *FileOutput `json:"file,omitempty"`
+}
+
+// FileOutput is a file receiving audit records, one JSON document per line. The
+// file is rotated when it reaches maxSizeMB or maxAge: it is renamed with the time
+// of the rotation, optionally compressed, and the oldest rotated files beyond
+// maxBackups are deleted.
+type FileOutput struct {
+	// Path is the absolute path of the file, e.g. /var/log/pod-cleanup/audit.log. Its
+	// directory must exist and be writable by the operator, and should only hold
+	// audit files.
+	// +kubebuilder:validation:Pattern=`^/`
+	Path string `json:"path"`
+
+	// MaxSizeMB is the size in megabytes at which the file is rotated. Defaults to
+	// 100.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxSizeMB *int32 `json:"maxSizeMB,omitempty"`
+
+	// MaxAge is how long records are appended to one file before it is rotated (e.g.
+	// "24h"). If not set, the file is only rotated by size.
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h|d|w))+$`
+	// +optional
+	MaxAge string `json:"maxAge,omitempty"`
+
+	// Compress gzips rotated files.
+	// +optional
+	Compress bool `json:"compress,omitempty"`
+
+	// MaxBackups is the number of rotated files kept. Defaults to 10.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxBackups *int32 `json:"maxBackups,omitempty"`
+}
+
+// SyslogProtocol is the transport of a syslog output.
+// +kubebuilder:validation:Enum=UDP;TCP;TLS
+type SyslogProtocol string
+
+const (
+	// SyslogUDP sends one message per datagram (RFC 5426).
+	SyslogUDP SyslogProtocol = "UDP"
+	// SyslogTCP sends octet-counted messages over TCP (RFC 6587).
+	SyslogTCP SyslogProtocol = "TCP"
+	// SyslogTLS sends octet-counted messages over TLS (RFC 5425).
+	SyslogTLS SyslogProtocol = "TLS"
+)
+
+// SyslogOutput is a syslog server receiving audit records.
+// +kubebuilder:validation:XValidation:rule="!has(self.caSecretRef) || self.protocol == 'TLS'",message="caSecretRef requires protocol TLS"
+type SyslogOutput struct {
+	// Address is the host:port of the syslog server.
+	// +kubebuilder:validation:MinLength=1
+	Address string `json:"address"`
+
+	// Protocol is the transport to the server. Defaults to UDP.
+	// +kubebuilder:default=UDP
+	// +optional
+	Protocol SyslogProtocol `json:"protocol,omitempty"`
+
+	// Facility is the syslog facility code of the messages. Defaults to 16 (local0).
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=23
+	// +optional
+	Facility *int32 `json:"facility,omitempty"`
+
+	// CASecretRef selects a PEM bundle of CAs trusted to verify a TLS server. If not
+	// set, the system roots are trusted.
+	// +optional
+	CASecretRef *SecretKeyRef `json:"caSecretRef,omitempty"`
+}
+
+// SplunkOutput is a Splunk HTTP Event Collector (HEC) receiving audit records.
+type SplunkOutput struct {
+	// URL is the event endpoint of the collector, e.g.
+	// https://splunk.example.com:8088/services/collector/event.
+	// +kubebuilder:validation:MinLength=1
+	URL string `json:"url"`
+
+	// TokenSecretRef selects the HEC token.
+	TokenSecretRef SecretKeyRef `json:"tokenSecretRef"`
+
+	// Index, Source and SourceType are set on every event. Where not set, the
+	// token's defaults apply; SourceType defaults to _json.
+	// +optional
+	Index string `json:"index,omitempty"`
+	// +optional
+	Source string `json:"source,omitempty"`
+	// +optional
+	SourceType string `json:"sourceType,omitempty"`
+
+	// BatchSize is the number of records sent in one request. Records are also sent
+	// when a run finishes. Defaults to 50.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=1000
+	// +optional
+	BatchSize *int32 `json:"batchSize,omitempty"`
+
+	// CASecretRef selects a PEM bundle of CAs trusted to verify an https collector.
+	// If not set, the system roots are trusted.
+	// +optional
+	CASecretRef *SecretKeyRef `json:"caSecretRef,omitempty"`
+}
+
+// RunReports configures the per-run report ConfigMaps.
+type RunReports struct {
+	// HistoryLimit is the number of reports kept per policy. Defaults to 10, and is
+	// at most 100.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	HistoryLimit *int32 `json:"historyLimit,omitempty"`
+}
+
+// RateLimit is a token-bucket limit on pod deletions.
+type RateLimit struct {
+	// DeletionsPerSecond is the sustained number of pod deletions allowed per second.
+	// +kubebuilder:validation:Minimum=1
+	DeletionsPerSecond int32 `json:"deletionsPerSecond"`
+
+	// Burst is the maximum number of deletions allowed at once. Defaults to DeletionsPerSecond.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Burst int32 `json:"burst,omitempty"`
+}
+
+// TenantQuota is a daily deletion quota applied to every tenant.
+type TenantQuota struct {
+	// MaxDeletionsPerDay is the number of pods that may be deleted per tenant in any
+	// rolling 24 hours, across all policies.
+	// +kubebuilder:validation:Minimum=0
+	MaxDeletionsPerDay int32 `json:"maxDeletionsPerDay"`
+
+	// TenantLabel is a namespace label whose value identifies the tenant; namespaces
+	// sharing a value share a quota. If not set, or if a namespace lacks the label,
+	// the namespace is its own tenant.
+	// +optional
+	TenantLabel string `json:"tenantLabel,omitempty"`
+}
+
+// NotificationEndpoint is an HTTP endpoint that receives run summaries as JSON.
+// +kubebuilder:validation:XValidation:rule="!has(self.format) || self.format != 'Datadog' || has(self.secretRef)",message="format Datadog requires secretRef"
+// +kubebuilder:validation:XValidation:rule="!has(self.tags) || (has(self.format) && self.format == 'Datadog')",message="tags require format Datadog"
+type NotificationEndpoint struct {
+	// Name identifies the endpoint in logs.
+	Name string `json:"name"`
+
+	// URL receives an HTTP POST with a JSON summary of each run.
+	URL string `json:"url"`
+
+	// Format is the message format of the posts: JSON (the default) for the summary
+	// itself, Teams or GoogleChat for a card with the summary posted to an incoming
+	// webhook of that chat, or Datadog for an event posted to the Datadog Events API.
+	// +optional
+	Format NotificationFormat `json:"format,omitempty"`
+
+	// Tags are added to the events of a Datadog endpoint, e.g. env:prod. Events are
+	// also tagged with the policy and, for runs in a workload cluster, the cluster,
+	// which replaces a cluster tag set here.
+	// +optional
+	Tags []string `json:"tags,omitempty"`
+
+	// Digest if set, aggregates the summaries of all runs, across policies, into a
+	// periodic digest instead of posting every run.
+	// +optional
+	Digest *NotificationDigest `json:"digest,omitempty"`
+
+	// SecretRef selects a bearer token sent in the Authorization header of every post,
+	// or the API key sent in the DD-API-KEY header of a Datadog endpoint.
+	// +optional
+	SecretRef *SecretKeyRef `json:"secretRef,omitempty"`
+
+	// Signing, if set, signs every post with an HMAC, so the receiver can verify
+	// that it comes from the operator and is not a replay.
+	// +optional
+	Signing *WebhookSigning `json:"signing,omitempty"`
+}
+
+// NotificationFormat is the message format of a notification endpoint.
+// +kubebuilder:validation:Enum=JSON;Teams;GoogleChat;Datadog
+type NotificationFormat string
+
+const (
+	// NotificationFormatJSON posts run summaries and digests as JSON documents.
+	NotificationFormatJSON NotificationFormat = "JSON"
+	// NotificationFormatTeams posts Adaptive Cards to a Microsoft Teams incoming
+	// webhook or workflow.
+	NotificationFormatTeams NotificationFormat = "Teams"
+	// NotificationFormatGoogleChat posts cards to a Google Chat incoming webhook.
+	NotificationFormatGoogleChat NotificationFormat = "GoogleChat"
+	// NotificationFormatDatadog posts events to the Datadog Events API.
+	NotificationFormatDatadog NotificationFormat = "Datadog"
+)
+
+// WebhookSigning configures the HMAC signature of notification posts. Each post
+// carries its Unix time in the X-Cleanup-Timestamp header and, in the
+// X-Cleanup-Signature header, "sha256=" followed by the hex HMAC-SHA256 of the
+// timestamp, a ".", and the body.
+type WebhookSigning struct {
+	// SecretRef selects the shared HMAC key.
+	SecretRef SecretKeyRef `json:"secretRef"`
+}
+
+// SecretKeyRef selects a key of a Secret holding a credential. The operator reads
+// the Secret when it needs the credential and rereads it after the Secret changes.
+type SecretKeyRef struct {
+	// Name is the name of the Secret.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the Secret. Defaults to the operator namespace.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Key is the key in the Secret's data holding the credential.
+	// +kubebuilder:validation:MinLength=1
+	Key string `json:"key"`
+}
+
+// NotificationDigest configures the digest mode of a notification endpoint.
+type NotificationDigest struct {
+	// Interval is how often the digest is posted, e.g. "1h". Intervals in which no
+	// run deleted, labeled or reported pods or failed are not posted.
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h|d|w))+$`
+	Interval string `json:"interval"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Cluster
+//+kubebuilder:validation:XValidation:rule="self.metadata.name == 'cluster'",message="OperatorConfig is a singleton and must be named 'cluster'"
+//+kubebuilder:printcolumn:name="DryRun",type=boolean,JSONPath=`.spec.dryRun`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// OperatorConfig is the Schema for the operatorconfigs API.
+// It is a cluster-scoped singleton named "cluster" holding controller-wide defaults,
+// so operator behavior can be changed without redeploying with new flags.
+type OperatorConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec OperatorConfigSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// OperatorConfigList contains a list of OperatorConfig
+type OperatorConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OperatorConfig `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OperatorConfig{}, &OperatorConfigList{})
+}
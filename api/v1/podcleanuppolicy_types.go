@@ -1,17 +1,75 @@
 package v1
 
 import (
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// PolicyFinalizer holds the deletion of a PodCleanupPolicy until its runs in progress
+// have stopped and its final summary has been reported.
+const PolicyFinalizer = "cleanup.k8s.io/finalizer"
+
+// AgeReference is the point in a pod's life its age is measured from.
+// +kubebuilder:validation:Enum=Creation;Start
+type AgeReference string
+
+const (
+	// AgeFromCreation measures age from the pod's creation timestamp.
+	AgeFromCreation AgeReference = "Creation"
+	// AgeFromStart measures age from the pod's status.startTime, when the kubelet
+	// accepted it. Pods that have not started have no age.
+	AgeFromStart AgeReference = "Start"
+)
+
+// PolicyAction is the action a policy takes on the pods it matches.
+// +kubebuilder:validation:Enum=Delete;Protect
+type PolicyAction string
+
+const (
+	// ActionDelete deletes matching pods.
+	ActionDelete PolicyAction = "Delete"
+	// ActionProtect shields matching pods from every other policy.
+	ActionProtect PolicyAction = "Protect"
 )
 
 // PodCleanupPolicySpec defines the desired state of PodCleanupPolicy
+// +kubebuilder:validation:XValidation:rule="!has(self.serviceAccountName) || has(self.serviceAccountNamespace)",message="serviceAccountNamespace is required when serviceAccountName is set"
+// +kubebuilder:validation:XValidation:rule="!has(self.schedule) || !has(self.scheduleRef)",message="schedule and scheduleRef are mutually exclusive"
+// +kubebuilder:validation:XValidation:rule="!has(self.namespaceTTL) || has(self.namespaceSelector)",message="namespaceSelector is required when namespaceTTL is set"
+// +kubebuilder:validation:XValidation:rule="!has(self.hooks) || has(self.serviceAccountName)",message="serviceAccountName is required when hooks is set"
+// +kubebuilder:validation:XValidation:rule="!has(self.stuckOnSidecar) || !has(self.stuckOnSidecar.action) || self.stuckOnSidecar.action != 'QuitSidecar' || has(self.serviceAccountName)",message="serviceAccountName is required when stuckOnSidecar.action is QuitSidecar"
+// +kubebuilder:validation:XValidation:rule="!has(self.clusterRefs) || !has(self.serviceAccountName)",message="clusterRefs and serviceAccountName are mutually exclusive"
+// +kubebuilder:validation:XValidation:rule="!has(self.clusterRefs) || !has(self.maintenanceNodeSelector)",message="clusterRefs and maintenanceNodeSelector are mutually exclusive"
+// +kubebuilder:validation:XValidation:rule="!has(self.clusterRefs) || !has(self.preferScaleDownNodes) || !self.preferScaleDownNodes",message="clusterRefs and preferScaleDownNodes are mutually exclusive"
 type PodCleanupPolicySpec struct {
+	// Action is what the policy does with matching pods. Delete policies clean them up;
+	// Protect policies remove them from the candidates of every other policy, regardless
+	// of priority. Schedule, maxAge and dryRun are ignored for Protect policies.
+	// +kubebuilder:default=Delete
+	// +optional
+	Action PolicyAction `json:"action,omitempty"`
+
 	// Schedule is a cron expression for when to run cleanup (e.g., "*/5 * * * *").
 	// If not set, cleanup runs on every reconcile.
+	// +kubebuilder:validation:Pattern=`^((CRON_TZ|TZ)=\S+\s+)?[0-9A-Za-z*?/,-]+(\s+[0-9A-Za-z*?/,-]+){4}$`
 	// +optional
 	Schedule string `json:"schedule,omitempty"`
 
+	// ScheduleRef is the name of a CleanupSchedule to run on instead of schedule, so
+	// policies can share one schedule with its time zone and blackout windows.
+	// +optional
+	ScheduleRef string `json:"scheduleRef,omitempty"`
+
+	// StartingDeadlineSeconds is how late a scheduled run may start. When the
+	// controller finds a missed schedule slot older than this, e.g. after a restart,
+	// the run is skipped until the next slot. If not set, a missed slot is always
+	// caught up with a single run.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	StartingDeadlineSeconds *int64 `json:"startingDeadlineSeconds,omitempty"`
+
 	// NamespaceSelector selects namespaces to scan for pods.
 	// If not set, all namespaces are scanned.
 	// +optional
@@ -24,45 +82,952 @@ type PodCleanupPolicySpec struct {
 
 	// PodStatuses is a list of pod phases to clean up (e.g., Failed, Succeeded).
 	// If not set, all phases are eligible.
+	// +kubebuilder:validation:MaxItems=5
+	// +kubebuilder:validation:XValidation:rule="self.all(p, p in ['Pending', 'Running', 'Succeeded', 'Failed', 'Unknown'])",message="podStatuses must be pod phases: Pending, Running, Succeeded, Failed or Unknown"
 	// +optional
 	PodStatuses []corev1.PodPhase `json:"podStatuses,omitempty"`
 
-	// MaxAge is the maximum age of pods to retain (e.g., "24h", "1h30m").
-	// Pods older than this will be candidates for deletion.
+	// PodConditions restricts cleanup to pods whose conditions all match, e.g.
+	// PodScheduled=False with reason Unschedulable for at least 30m.
+	// If not set, pod conditions are not checked.
+	// +optional
+	PodConditions []PodConditionMatch `json:"podConditions,omitempty"`
+
+	// Match combines criteria with AND and OR, e.g. (phase Failed AND older than 1h)
+	// OR (waiting on ImagePullBackOff AND older than 15m). It applies in addition to
+	// the other criteria of the policy.
+	// +optional
+	Match *MatchCriteria `json:"match,omitempty"`
+
+	// StuckOnVolumeClaim restricts cleanup to pods stuck on a PersistentVolumeClaim
+	// that is Pending, Lost or deleted. If not set, claims are not checked.
+	// +optional
+	StuckOnVolumeClaim *VolumeClaimCriteria `json:"stuckOnVolumeClaim,omitempty"`
+
+	// StuckOnSidecar restricts cleanup to Job pods whose main containers have all
+	// terminated but that keep Running because a sidecar, e.g. istio-proxy, never
+	// exits. If not set, sidecars are not checked.
+	// +optional
+	StuckOnSidecar *SidecarCriteria `json:"stuckOnSidecar,omitempty"`
+
+	// Orphaned restricts cleanup to pods whose owners no longer exist, e.g. pods a
+	// crashed or uninstalled controller left behind with a stale ownerReference.
+	// Requires the GenericResourceCleanup feature gate.
+	// +optional
+	Orphaned *OrphanedCriteria `json:"orphaned,omitempty"`
+
+	// ExternalMatcher restricts cleanup to pods an external gRPC service matches, so
+	// organizations can hook their own logic, e.g. an ownership database or the
+	// status of a ticket, into candidate selection. It is asked about every pod that
+	// meets all other criteria.
+	// +optional
+	ExternalMatcher *ExternalMatcher `json:"externalMatcher,omitempty"`
+
+	// NodeLabelSelector restricts cleanup to pods on nodes with matching labels, e.g.
+	// node-type=spot or a topology zone. If not set, node labels are not checked.
+	// +optional
+	NodeLabelSelector *metav1.LabelSelector `json:"nodeLabelSelector,omitempty"`
+
+	// MaintenanceNodeSelector selects nodes under maintenance. When a node starts
+	// matching it, the policy runs immediately for the Succeeded and Failed pods on
+	// that node, regardless of schedule and maxAge. The policy's other criteria
+	// still apply.
+	// +optional
+	MaintenanceNodeSelector *metav1.LabelSelector `json:"maintenanceNodeSelector,omitempty"`
+
+	// NodeConditions restricts cleanup to pods on nodes whose conditions all match,
+	// e.g. Ready=False. If not set, node conditions are not checked.
+	// +optional
+	NodeConditions []NodeConditionMatch `json:"nodeConditions,omitempty"`
+
+	// NodeTaints restricts cleanup to pods on nodes carrying at least one of these
+	// taints. Cordoned nodes carry node.kubernetes.io/unschedulable:NoSchedule.
+	// If not set, node taints are not checked.
+	// +optional
+	NodeTaints []TaintMatch `json:"nodeTaints,omitempty"`
+
+	// PreferScaleDownNodes cleans up the nodes the cluster-autoscaler wants to remove
+	// ahead of the schedule. When a node gets the DeletionCandidateOfClusterAutoscaler
+	// or ToBeDeletedByClusterAutoscaler taint, the policy runs immediately for the pods
+	// on that node, so they stop holding it up. All of the policy's criteria, maxAge
+	// included, still apply.
+	// +optional
+	PreferScaleDownNodes bool `json:"preferScaleDownNodes,omitempty"`
+
+	// MaxAge is the maximum age of pods to retain (e.g., "24h", "1h30m", "7d", "2w").
+	// Pods older than this will be candidates for deletion. Besides the units of Go
+	// durations, "d" (days) and "w" (weeks) are accepted.
+	// +optional
+	MaxAge *Duration `json:"maxAge,omitempty"`
+
+	// MaxAgeByPhase overrides maxAge for pods in the listed phases, e.g. Succeeded pods
+	// after 1h and Failed pods after 24h. Pods in other phases use maxAge.
+	// +listType=map
+	// +listMapKey=phase
+	// +optional
+	MaxAgeByPhase []PhaseMaxAge `json:"maxAgeByPhase,omitempty"`
+
+	// MaxAgeFrom is what pod ages are measured from: Creation (the default) or Start.
+	// Start ignores the time a pod spent waiting to be scheduled.
+	// +optional
+	MaxAgeFrom AgeReference `json:"maxAgeFrom,omitempty"`
+
+	// KeepNewest keeps the newest pods from cleanup whatever their age, e.g. the last
+	// attempts of every Argo workflow or Tekton PipelineRun.
 	// +optional
-	MaxAge string `json:"maxAge,omitempty"`
+	KeepNewest *KeepNewest `json:"keepNewest,omitempty"`
 
 	// DryRun if true, the operator logs what it would delete without actually deleting.
 	// +optional
 	DryRun bool `json:"dryRun,omitempty"`
+
+	// AlertThreshold if set, stops a run that would delete or evict more pods than
+	// this before it deletes any, and reports the candidate count through a Warning
+	// event, the Ready condition and notifications. The run proceeds once
+	// acknowledged with the acknowledge-alert annotation or a CleanupRequest.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	AlertThreshold *int32 `json:"alertThreshold,omitempty"`
+
+	// Suspend stops all runs of the policy, including one in progress, until it is
+	// set back to false.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+
+	// AllowReadyPods if true, Running pods whose Ready condition is True may be
+	// deleted. By default they never are, whatever the other criteria.
+	// +optional
+	AllowReadyPods bool `json:"allowReadyPods,omitempty"`
+
+	// SkipPodsWithEndpoints if true, pods that are a ready endpoint of any Service are
+	// never deleted, whatever the other criteria.
+	// +optional
+	SkipPodsWithEndpoints bool `json:"skipPodsWithEndpoints,omitempty"`
+
+	// AnnotateCandidates if true, pods a dry run would delete are annotated with the
+	// policy name and the time they would be deleted, so their owners can see it coming.
+	// +optional
+	AnnotateCandidates bool `json:"annotateCandidates,omitempty"`
+
+	// WarnBefore, if set, gives pods advance notice of their deletion (e.g., "15m").
+	// A run that would delete a pod first annotates it with cleanup.k8s.io/deletion-at,
+	// set to the first scheduled run at least this long after, and leaves it; only
+	// runs from that time on delete it. Pods that stop matching lose the annotation,
+	// and a warning not acted on within this long after its deletion time expires.
+	// Dry runs and maintenance runs ignore it.
+	// +optional
+	WarnBefore *Duration `json:"warnBefore,omitempty"`
+
+	// Preview if true, each run evaluates the policy without deleting or annotating
+	// anything, and records the pods it would delete in status.lastPreview instead of
+	// logging them.
+	// +optional
+	Preview bool `json:"preview,omitempty"`
+
+	// Explain if true, dry-run and preview runs record why each evaluated pod was
+	// selected or skipped, in their CleanupRun and the controller log. It has no effect
+	// on runs that delete pods.
+	// +optional
+	Explain bool `json:"explain,omitempty"`
+
+	// Logging tunes the controller's log lines for this policy, instead of leaving
+	// them to the global -v flag alone.
+	// +optional
+	Logging *PolicyLogging `json:"logging,omitempty"`
+
+	// RunHistoryLimit is the number of finished CleanupRun records kept for this policy.
+	// Defaults to 10, and is at most 100.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	RunHistoryLimit *int32 `json:"runHistoryLimit,omitempty"`
+
+	// ServiceAccountName is the ServiceAccount the operator impersonates when listing
+	// and deleting pods for this policy, so the policy is bounded by that account's RBAC.
+	// If not set, the operator's own permissions are used.
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+
+	// ServiceAccountNamespace is the namespace of ServiceAccountName.
+	// +optional
+	ServiceAccountNamespace string `json:"serviceAccountNamespace,omitempty"`
+
+	// Priority decides which policy acts on a pod matched by several policies.
+	// Only the highest-priority matching policy acts on the pod; ties are broken by
+	// policy name in ascending order. Suspended, dryRun and preview policies do not
+	// take precedence. Defaults to 0; ranges from -1000 to 1000.
+	// +kubebuilder:validation:Minimum=-1000
+	// +kubebuilder:validation:Maximum=1000
+	// +optional
+	Priority int32 `json:"priority,omitempty"`
+
+	// Archive stores the manifests of the pods this policy deletes or evicts before
+	// they are removed. Requires the Archive feature gate.
+	// +optional
+	Archive *ArchiveSpec `json:"archive,omitempty"`
+
+	// NamespaceTTL treats the selected namespaces as ephemeral, e.g. preview
+	// environments. Once a namespace outlives its TTL, maxAge no longer holds back
+	// its pods or, with deleteNamespace, the namespace itself is deleted. Requires
+	// the NamespaceTTL feature gate and a namespaceSelector.
+	// +optional
+	NamespaceTTL *NamespaceTTL `json:"namespaceTTL,omitempty"`
+
+	// Hooks are Jobs the operator creates and waits for around every run that may
+	// remove pods, e.g. to flush logs to storage before pods are deleted, or to
+	// start a downstream re-index afterwards. The Jobs are created in
+	// serviceAccountNamespace as serviceAccountName, which is required.
+	// +optional
+	Hooks *RunHooks `json:"hooks,omitempty"`
+
+	// ClusterRefs names the ClusterTargets whose workload clusters the policy cleans
+	// up, one after the other in the order listed, instead of the operator's own
+	// cluster. The kubeconfig of each target bounds what the policy can touch there.
+	// Requires the MultiCluster feature gate.
+	// +listType=set
+	// +optional
+	ClusterRefs []string `json:"clusterRefs,omitempty"`
+
+	// DefaultsFrom names a ClusterCleanupDefaults whose settings are inherited for
+	// every field below that is left unset on this policy.
+	// +optional
+	DefaultsFrom string `json:"defaultsFrom,omitempty"`
+
+	// GracePeriodSeconds is the termination grace period sent with every pod deletion.
+	// Overrides the OperatorConfig grace period.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	GracePeriodSeconds *int64 `json:"gracePeriodSeconds,omitempty"`
+
+	// RateLimit bounds how fast this policy deletes pods. The OperatorConfig rate limit
+	// across all policies still applies.
+	// +optional
+	RateLimit *RateLimit `json:"rateLimit,omitempty"`
+
+	// Notifications lists endpoints that receive a summary of every run of this policy,
+	// in addition to those in the OperatorConfig.
+	// +optional
+	Notifications []NotificationEndpoint `json:"notifications,omitempty"`
+}
+
+// RunHooks are the Jobs run before and after a run.
+type RunHooks struct {
+	// PreRun runs before the run; pods are only removed once its Job succeeds.
+	// +optional
+	PreRun *HookJob `json:"preRun,omitempty"`
+
+	// PostRun runs after the run, whether or not it succeeded.
+	// +optional
+	PostRun *HookJob `json:"postRun,omitempty"`
+}
+
+// HookFailurePolicy is what a run does when its hook Job fails or times out.
+// +kubebuilder:validation:Enum=Fail;Ignore
+type HookFailurePolicy string
+
+const (
+	// HookFailureFail fails the run. A failed preRun hook keeps the run from
+	// removing any pods.
+	HookFailureFail HookFailurePolicy = "Fail"
+	// HookFailureIgnore records a Warning Event and carries on.
+	HookFailureIgnore HookFailurePolicy = "Ignore"
+)
+
+// HookJob is a Job created from a template for every run.
+type HookJob struct {
+	// JobTemplate is the Job to create, as in a CronJob. Its containers get the
+	// CLEANUP_POLICY, CLEANUP_RUN_ID and CLEANUP_HOOK environment variables, and in
+	// postRun hooks CLEANUP_PODS_DELETED and CLEANUP_RUN_ERROR.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	JobTemplate batchv1.JobTemplateSpec `json:"jobTemplate"`
+
+	// Timeout is how long the run waits for the Job to finish before deleting it
+	// and treating it as failed. Defaults to 10m.
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h|d|w))+$`
+	// +optional
+	Timeout string `json:"timeout,omitempty"`
+
+	// FailurePolicy is Fail (the default) or Ignore.
+	// +optional
+	FailurePolicy HookFailurePolicy `json:"failurePolicy,omitempty"`
+}
+
+// NamespaceTTL expires the namespaces a policy selects.
+type NamespaceTTL struct {
+	// TTL is how long after its creation a namespace expires (e.g., "72h"). The
+	// namespace annotation cleanup.k8s.io/namespace-ttl overrides it.
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h|d|w))+$`
+	TTL string `json:"ttl"`
+
+	// DeleteNamespace deletes expired namespaces, and with them all their objects,
+	// instead of cleaning up their pods.
+	// +optional
+	DeleteNamespace bool `json:"deleteNamespace,omitempty"`
+}
+
+// LogLevel is how much a policy logs.
+// +kubebuilder:validation:Enum=Info;Quiet;Debug
+type LogLevel string
+
+const (
+	// LogLevelInfo follows the controller's -v flag.
+	LogLevelInfo LogLevel = "Info"
+	// LogLevelQuiet drops the informational lines logged for each pod, such as its
+	// deletion, for high-volume policies.
+	LogLevelQuiet LogLevel = "Quiet"
+	// LogLevelDebug logs the policy's debug lines, such as why pods are skipped,
+	// whatever the -v flag.
+	LogLevelDebug LogLevel = "Debug"
+)
+
+// PolicyLogging tunes the controller's log lines for a policy. Errors are always
+// logged.
+type PolicyLogging struct {
+	// Level is Info (the default), Quiet or Debug.
+	// +optional
+	Level LogLevel `json:"level,omitempty"`
+
+	// Fields are added to every log line of the policy (e.g., team: payments).
+	// +kubebuilder:validation:MaxProperties=20
+	// +optional
+	Fields map[string]string `json:"fields,omitempty"`
+
+	// LabelFields names labels of the policy whose values are added to every log
+	// line of the policy, keyed by the label key.
+	// +kubebuilder:validation:MaxItems=20
+	// +optional
+	LabelFields []string `json:"labelFields,omitempty"`
+
+	// PodSampleRate, if greater than 1, keeps the lines logged for only one in this
+	// many pods a run evaluates: the first, then every PodSampleRate-th.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	PodSampleRate int32 `json:"podSampleRate,omitempty"`
+}
+
+// ArchiveType selects the backend that stores archived pod manifests.
+// +kubebuilder:validation:Enum=ConfigMap;GCS;AzureBlob
+type ArchiveType string
+
+const (
+	// ArchiveConfigMap stores the manifests, gzip-compressed, in ConfigMaps in the
+	// operator namespace that are owned by the run's CleanupRun.
+	ArchiveConfigMap ArchiveType = "ConfigMap"
+	// ArchiveGCS stores the manifests in a Google Cloud Storage bucket.
+	ArchiveGCS ArchiveType = "GCS"
+	// ArchiveAzureBlob stores the manifests in an Azure Blob Storage container.
+	ArchiveAzureBlob ArchiveType = "AzureBlob"
+)
+
+// ArchiveSpec configures where the manifests of removed pods are archived.
+// +kubebuilder:validation:XValidation:rule="self.type != 'GCS' || has(self.gcs)",message="gcs is required when type is GCS"
+// +kubebuilder:validation:XValidation:rule="self.type != 'AzureBlob' || has(self.azureBlob)",message="azureBlob is required when type is AzureBlob"
+type ArchiveSpec struct {
+	// Type is the archive backend. Defaults to ConfigMap.
+	// +kubebuilder:default=ConfigMap
+	// +optional
+	Type ArchiveType `json:"type,omitempty"`
+
+	// GCS configures the GCS backend.
+	// +optional
+	GCS *GCSArchive `json:"gcs,omitempty"`
+
+	// AzureBlob configures the AzureBlob backend.
+	// +optional
+	AzureBlob *AzureBlobArchive `json:"azureBlob,omitempty"`
+
+	// IncludeEvents archives the Events of each pod after its manifest, since they
+	// can no longer be looked up once the pod is gone.
+	// +optional
+	IncludeEvents bool `json:"includeEvents,omitempty"`
+}
+
+// GCSArchive is a Google Cloud Storage location for archived manifests.
+type GCSArchive struct {
+	// Bucket is the name of the bucket.
+	// +kubebuilder:validation:MinLength=1
+	Bucket string `json:"bucket"`
+
+	// Prefix is prepended to the object names, e.g. "pod-archive/".
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+
+	// SecretRef selects a Google service account key in JSON format to authenticate
+	// with. Defaults to the operator's own service account, from the metadata server.
+	// +optional
+	SecretRef *SecretKeyRef `json:"secretRef,omitempty"`
+}
+
+// AzureBlobArchive is an Azure Blob Storage location for archived manifests.
+type AzureBlobArchive struct {
+	// Account is the storage account name.
+	// +kubebuilder:validation:MinLength=1
+	Account string `json:"account"`
+
+	// Container is the blob container in the account.
+	// +kubebuilder:validation:MinLength=1
+	Container string `json:"container"`
+
+	// Prefix is prepended to the blob names, e.g. "pod-archive/".
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+
+	// SecretRef selects a shared access signature with create and write permission
+	// on the container. Defaults to the operator's AZURE_STORAGE_SAS_TOKEN
+	// environment variable.
+	// +optional
+	SecretRef *SecretKeyRef `json:"secretRef,omitempty"`
+}
+
+// PhaseMaxAge is the maximum age of pods in one phase.
+type PhaseMaxAge struct {
+	// Phase is the pod phase the maximum age applies to.
+	// +kubebuilder:validation:Enum=Pending;Running;Succeeded;Failed;Unknown
+	Phase corev1.PodPhase `json:"phase"`
+
+	// MaxAge is the maximum age of pods in the phase, in the format of maxAge.
+	MaxAge Duration `json:"maxAge"`
+}
+
+// MatchCriteria is a boolean combination of criteria: a pod matches when it
+// satisfies every criterion of AllOf and, if AnyOf is set, at least one of its groups.
+type MatchCriteria struct {
+	// AllOf lists criteria that must all hold.
+	// +optional
+	AllOf []Criterion `json:"allOf,omitempty"`
+
+	// AnyOf lists groups of which at least one must hold. They are evaluated in order.
+	// +optional
+	AnyOf []CriteriaGroup `json:"anyOf,omitempty"`
+}
+
+// RuleAction is what a policy does with the pods matched by one of its rules.
+// +kubebuilder:validation:Enum=Delete;Evict;Label;Notify
+type RuleAction string
+
+const (
+	// RuleActionDelete deletes matching pods.
+	RuleActionDelete RuleAction = "Delete"
+	// RuleActionEvict evicts matching pods through the Eviction API, which honors
+	// PodDisruptionBudgets.
+	RuleActionEvict RuleAction = "Evict"
+	// RuleActionLabel adds the rule's labels to matching pods.
+	RuleActionLabel RuleAction = "Label"
+	// RuleActionNotify only reports matching pods in the run's notifications.
+	RuleActionNotify RuleAction = "Notify"
+)
+
+// CriteriaGroup holds when all of its criteria hold. Each group is a rule of the
+// policy with its own action.
+// +kubebuilder:validation:XValidation:rule="self.action != 'Label' || (has(self.labels) && size(self.labels) > 0)",message="labels is required when action is Label"
+type CriteriaGroup struct {
+	// Name identifies the group in explanations and run reports.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// AllOf lists the criteria of the group.
+	// +kubebuilder:validation:MinItems=1
+	AllOf []Criterion `json:"allOf"`
+
+	// Action is what the policy does with the pods this rule matches.
+	// +kubebuilder:default=Delete
+	// +optional
+	Action RuleAction `json:"action,omitempty"`
+
+	// Labels are added to matching pods by the Label action.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// Criterion is a single test of a pod. Exactly one field must be set.
+// +kubebuilder:validation:XValidation:rule="[has(self.phases), has(self.olderThan), has(self.waitingReasons), has(self.terminatedReasons), has(self.condition)].filter(x, x).size() == 1",message="exactly one criterion field must be set"
+type Criterion struct {
+	// Phases holds when the pod is in one of these phases.
+	// +kubebuilder:validation:MaxItems=5
+	// +kubebuilder:validation:XValidation:rule="self.all(p, p in ['Pending', 'Running', 'Succeeded', 'Failed', 'Unknown'])",message="phases must be pod phases: Pending, Running, Succeeded, Failed or Unknown"
+	// +optional
+	Phases []corev1.PodPhase `json:"phases,omitempty"`
+
+	// OlderThan holds when the pod is older than this duration (e.g. "1h", "2d"),
+	// measured as configured by maxAgeFrom.
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h|d|w))+$`
+	// +optional
+	OlderThan string `json:"olderThan,omitempty"`
+
+	// WaitingReasons holds when a container of the pod is waiting with one of these
+	// reasons, e.g. ImagePullBackOff or CrashLoopBackOff.
+	// +optional
+	WaitingReasons []string `json:"waitingReasons,omitempty"`
+
+	// TerminatedReasons holds when a container of the pod terminated with one of
+	// these reasons, e.g. OOMKilled or Error.
+	// +optional
+	TerminatedReasons []string `json:"terminatedReasons,omitempty"`
+
+	// Condition holds when the pod has this condition.
+	// +optional
+	Condition *PodConditionMatch `json:"condition,omitempty"`
+}
+
+// PodConditionMatch matches a pod condition.
+type PodConditionMatch struct {
+	// Type is the condition type, e.g. PodScheduled or Ready.
+	Type corev1.PodConditionType `json:"type"`
+
+	// Status is the status the condition must have.
+	// +kubebuilder:validation:Enum=True;False;Unknown
+	Status corev1.ConditionStatus `json:"status"`
+
+	// Reason, if set, is the reason the condition must have, e.g. Unschedulable.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// For is how long the condition must have had its status (e.g. "30m", "2d"),
+	// measured from its last transition. If not set, any duration matches.
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h|d|w))+$`
+	// +optional
+	For string `json:"for,omitempty"`
+}
+
+// VolumeClaimCriteria matches pods stuck on a PersistentVolumeClaim.
+type VolumeClaimCriteria struct {
+	// For is how long the pod must exist before it is considered stuck (e.g. "30m"),
+	// giving claims time to bind. If not set, pods are matched immediately.
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h|d|w))+$`
+	// +optional
+	For string `json:"for,omitempty"`
+}
+
+// SidecarAction is what a policy does with pods stuck on a sidecar.
+// +kubebuilder:validation:Enum=Delete;QuitSidecar
+type SidecarAction string
+
+const (
+	// SidecarActionDelete removes stuck pods like any other candidate.
+	SidecarActionDelete SidecarAction = "Delete"
+	// SidecarActionQuit runs the quit command in the sidecars of stuck pods, so they
+	// exit and the pods complete on their own.
+	SidecarActionQuit SidecarAction = "QuitSidecar"
+)
+
+// SidecarCriteria matches Job pods kept Running by a sidecar after their main
+// containers terminated.
+// +kubebuilder:validation:XValidation:rule="!has(self.quitCommand) || self.action == 'QuitSidecar'",message="quitCommand requires action QuitSidecar"
+type SidecarCriteria struct {
+	// Sidecars names the sidecar containers. Defaults to istio-proxy and
+	// linkerd-proxy.
+	// +optional
+	Sidecars []string `json:"sidecars,omitempty"`
+
+	// For is how long the last main container must have terminated (e.g. "10m"),
+	// giving sidecars time to exit by themselves. Defaults to 5m.
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h|d|w))+$`
+	// +optional
+	For string `json:"for,omitempty"`
+
+	// Action is Delete (the default) or QuitSidecar.
+	// +kubebuilder:default=Delete
+	// +optional
+	Action SidecarAction `json:"action,omitempty"`
+
+	// QuitCommand is run in every sidecar still running by the QuitSidecar action,
+	// as the policy's ServiceAccount, which QuitSidecar requires. Defaults to
+	// istio-proxy's "pilot-agent request POST quitquitquit".
+	// +optional
+	QuitCommand []string `json:"quitCommand,omitempty"`
+}
+
+// KeepNewest keeps the newest pods of every group.
+type KeepNewest struct {
+	// Count is the number of pods kept per group. Pods are ranked by creation time
+	// among the pods of the namespace that the podSelector and podStatuses select.
+	// +kubebuilder:validation:Minimum=1
+	Count int32 `json:"count"`
+
+	// GroupBy is the key of a label grouping the pods, e.g.
+	// workflows.argoproj.io/workflow for Argo Workflows or tekton.dev/pipelineRun for
+	// Tekton, so the newest pods of every workflow are kept. Pods without the label
+	// form one group. If not set, the newest pods of every namespace are kept.
+	// +optional
+	GroupBy string `json:"groupBy,omitempty"`
+}
+
+// OrphanedCriteria matches pods all of whose owners no longer exist.
+type OrphanedCriteria struct {
+	// Companions also removes the ConfigMaps and Secrets in the pod's namespace
+	// owned only by the removed pod or its missing owners.
+	// +optional
+	Companions bool `json:"companions,omitempty"`
+}
+
+// ExternalMatcher is a gRPC service implementing cleanup.matcher.v1.ExternalMatcher,
+// defined in api/matcher/v1/matcher.proto.
+// +kubebuilder:validation:XValidation:rule="!has(self.caSecretRef) || !self.insecure",message="caSecretRef requires TLS"
+type ExternalMatcher struct {
+	// Endpoint is the host:port of the service.
+	// +kubebuilder:validation:MinLength=1
+	Endpoint string `json:"endpoint"`
+
+	// Insecure connects without TLS, e.g. to a service in the cluster network.
+	// +optional
+	Insecure bool `json:"insecure,omitempty"`
+
+	// CASecretRef selects a PEM bundle of CAs trusted to verify the service. If not
+	// set, the system roots are trusted.
+	// +optional
+	CASecretRef *SecretKeyRef `json:"caSecretRef,omitempty"`
+
+	// Timeout bounds each call. Defaults to 5s.
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h|d|w))+$`
+	// +optional
+	Timeout string `json:"timeout,omitempty"`
+
+	// FailurePolicy is Deny (the default) to keep pods the service fails to match,
+	// or Allow to treat them as matching.
+	// +optional
+	FailurePolicy DecisionFailurePolicy `json:"failurePolicy,omitempty"`
+}
+
+// NodeConditionMatch matches a node condition.
+type NodeConditionMatch struct {
+	// Type is the condition type, e.g. Ready or DiskPressure.
+	Type corev1.NodeConditionType `json:"type"`
+
+	// Status is the status the condition must have.
+	// +kubebuilder:validation:Enum=True;False;Unknown
+	Status corev1.ConditionStatus `json:"status"`
+}
+
+// TaintMatch matches a node taint.
+type TaintMatch struct {
+	// Key is the taint key.
+	Key string `json:"key"`
+
+	// Value, if set, is the value the taint must have.
+	// +optional
+	Value string `json:"value,omitempty"`
+
+	// Effect, if set, is the effect the taint must have.
+	// +kubebuilder:validation:Enum=NoSchedule;PreferNoSchedule;NoExecute
+	// +optional
+	Effect corev1.TaintEffect `json:"effect,omitempty"`
 }
 
 // PodCleanupPolicyStatus defines the observed state of PodCleanupPolicy
 type PodCleanupPolicyStatus struct {
-	// LastRunTime is the timestamp of the last cleanup run.
+	// LastRunTime is when the last cleanup run completed.
 	// +optional
 	LastRunTime *metav1.Time `json:"lastRunTime,omitempty"`
 
+	// LastScheduleTime is the cron time of the last scheduled run, or the time the
+	// schedule was last changed if that is later. It is recorded before the run
+	// starts, so the next run is computed from it even if the run is interrupted.
+	// +optional
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+
+	// NextRunTime is when the next scheduled run is due.
+	// +optional
+	NextRunTime *metav1.Time `json:"nextRunTime,omitempty"`
+
+	// ObservedSchedule is the schedule NextRunTime was computed from, including the
+	// time zone and blackout windows of a referenced CleanupSchedule. When the
+	// schedule changes, the new schedule applies from the time of the change.
+	// +optional
+	ObservedSchedule string `json:"observedSchedule,omitempty"`
+
+	// LastRunID is the unique ID of the last run whose results are accounted in this
+	// status. It prevents a run from being counted twice.
+	// +optional
+	LastRunID types.UID `json:"lastRunID,omitempty"`
+
+	// LastRunTrigger is what started the last cleanup run.
+	// +optional
+	LastRunTrigger RunTrigger `json:"lastRunTrigger,omitempty"`
+
+	// RetryAttempts is the number of consecutive retries of runs that hit transient
+	// errors, such as API server throttling. It is reset by a run without them.
+	// +optional
+	RetryAttempts int32 `json:"retryAttempts,omitempty"`
+
+	// NextRetryTime is when the last run, which hit transient errors, is retried.
+	// +optional
+	NextRetryTime *metav1.Time `json:"nextRetryTime,omitempty"`
+
+	// LastManualRunTime is the timestamp of the last run triggered through the
+	// run-now annotation.
+	// +optional
+	LastManualRunTime *metav1.Time `json:"lastManualRunTime,omitempty"`
+
 	// PodsDeleted is the cumulative number of pods deleted by this policy.
 	// +optional
 	PodsDeleted int64 `json:"podsDeleted,omitempty"`
 
-	// LastRunPodsDeleted is the number of pods deleted (or would-be deleted) in the last run.
+	// LastRunPodsDeleted is the number of pods deleted or evicted (or would-be deleted)
+	// in the last run.
 	// +optional
 	LastRunPodsDeleted int32 `json:"lastRunPodsDeleted,omitempty"`
 
+	// LastRunNamespacesCleaned is the number of namespaces the last run deleted or
+	// evicted (or would have) pods in.
+	// +optional
+	LastRunNamespacesCleaned int32 `json:"lastRunNamespacesCleaned,omitempty"`
+
+	// LastRunDryRun is true if the last run only reported the pods it would delete.
+	// +optional
+	LastRunDryRun bool `json:"lastRunDryRun,omitempty"`
+
+	// RunsWithoutMatches is the number of consecutive runs whose selectors matched no
+	// namespaces or no pods. From 3 on, the NoMatches condition is set.
+	// +optional
+	RunsWithoutMatches int32 `json:"runsWithoutMatches,omitempty"`
+
+	// LastRunPodsSkippedByPriority is the number of candidate pods left alone in the last
+	// run because a higher-priority policy also matches them.
+	// +optional
+	LastRunPodsSkippedByPriority int32 `json:"lastRunPodsSkippedByPriority,omitempty"`
+
+	// LastRunPodsProtected is the number of candidate pods left alone in the last run
+	// because a Protect policy matches them.
+	// +optional
+	LastRunPodsProtected int32 `json:"lastRunPodsProtected,omitempty"`
+
+	// LastRunPodsRetained is the number of candidate pods kept in the last run
+	// because a PodRetentionPolicy retains them.
+	// +optional
+	LastRunPodsRetained int32 `json:"lastRunPodsRetained,omitempty"`
+
+	// LastRunNamespacesDeleted is the number of expired namespaces deleted (or
+	// would-be deleted) in the last run, per spec.namespaceTTL.
+	// +optional
+	LastRunNamespacesDeleted int32 `json:"lastRunNamespacesDeleted,omitempty"`
+
+	// LastRunCompanionsDeleted is the number of ConfigMaps and Secrets of orphaned
+	// pods deleted (or would-be deleted) in the last run, per spec.orphaned.
+	// +optional
+	LastRunCompanionsDeleted int32 `json:"lastRunCompanionsDeleted,omitempty"`
+
+	// Clusters breaks down the last run by workload cluster, for policies with
+	// clusterRefs.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Clusters []ClusterRunStatus `json:"clusters,omitempty"`
+
+	// LastRunPodsLabeled is the number of pods labeled by Label rules in the last run.
+	// +optional
+	LastRunPodsLabeled int32 `json:"lastRunPodsLabeled,omitempty"`
+
+	// LastRunPodsNotified is the number of pods reported by Notify rules in the last run.
+	// +optional
+	LastRunPodsNotified int32 `json:"lastRunPodsNotified,omitempty"`
+
+	// LastRunPodsDeferredByQuota is the number of pods not deleted in the last run
+	// because their tenant exhausted its daily deletion quota.
+	// +optional
+	LastRunPodsDeferredByQuota int32 `json:"lastRunPodsDeferredByQuota,omitempty"`
+
+	// LastRunPodsDeferredByBudget is the number of pods not deleted in the last run
+	// because a ClusterCleanupBudget was exhausted.
+	// +optional
+	LastRunPodsDeferredByBudget int32 `json:"lastRunPodsDeferredByBudget,omitempty"`
+
+	// LastRunPodsWarned is the number of pods the last run annotated with, or left
+	// within, their spec.warnBefore notice instead of deleting them.
+	// +optional
+	LastRunPodsWarned int32 `json:"lastRunPodsWarned,omitempty"`
+
+	// LastRunPodsDeniedByDecision is the number of pods the last run did not remove
+	// because the OperatorConfig's decision point did not allow it.
+	// +optional
+	LastRunPodsDeniedByDecision int32 `json:"lastRunPodsDeniedByDecision,omitempty"`
+
+	// LastRunPodsDisruptionProtected is the number of pods the last run did not remove
+	// because of a disruption annotation such as karpenter.sh/do-not-disrupt.
+	// +optional
+	LastRunPodsDisruptionProtected int32 `json:"lastRunPodsDisruptionProtected,omitempty"`
+
+	// LastPreview lists the pods the last preview run would have deleted.
+	// +optional
+	LastPreview *PolicyPreview `json:"lastPreview,omitempty"`
+
+	// LastDryRunDiff is the change in candidates between the last two dry runs.
+	// +optional
+	LastDryRunDiff *CandidateDiff `json:"lastDryRunDiff,omitempty"`
+
+	// CurrentRun checkpoints the run in progress, so a run interrupted by a restart of
+	// the operator resumes where it left off. It is cleared when the run finishes.
+	// +optional
+	CurrentRun *RunCheckpoint `json:"currentRun,omitempty"`
+
+	// Message is a one-line summary of the status for people, e.g. "Last run at
+	// 2024-05-01T10:00:00Z deleted 37 pod(s) across 5 namespace(s); next run at
+	// 2024-05-01T11:00:00Z". It is rewritten with every status update.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// LastError is the message of the last error that left the policy not ready, such
+	// as a failed run or an invalid spec, truncated to MaxLastErrorLength characters.
+	// It is cleared when the policy becomes ready again.
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+
 	// Conditions represents the latest available observations of the policy's current state.
 	// +optional
+	// +listType=map
+	// +listMapKey=type
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+// RunCheckpoint records how far a run in progress has come. Runs process namespaces
+// in name order, so every namespace before Namespace is done.
+type RunCheckpoint struct {
+	// RunID is the unique ID of the run.
+	RunID types.UID `json:"runID"`
+
+	// RunName is the name of the run's CleanupRun, if it could be created.
+	// +optional
+	RunName string `json:"runName,omitempty"`
+
+	// Trigger is what started the run.
+	Trigger RunTrigger `json:"trigger"`
+
+	// StartTime is when the run started.
+	StartTime metav1.Time `json:"startTime"`
+
+	// ObservedGeneration is the policy generation the run evaluates. A run is not
+	// resumed once the policy spec changed.
+	ObservedGeneration int64 `json:"observedGeneration"`
+
+	// DryRun is true if the run only reports the pods it would delete.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// Acknowledged is true if the run may exceed the policy's alertThreshold.
+	// +optional
+	Acknowledged bool `json:"acknowledged,omitempty"`
+
+	// Cluster is the ClusterTarget being processed, for policies with clusterRefs.
+	// Clusters are processed in the order of spec.clusterRefs, so every cluster
+	// before Cluster is done.
+	// +optional
+	Cluster string `json:"cluster,omitempty"`
+
+	// Namespace is the namespace being processed.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Phase and Continue locate the next page of pods in Namespace when pods are
+	// listed from the API server in pages: the pod phase being listed, if the run
+	// lists phases separately, and the continue token of the page.
+	// +optional
+	Phase corev1.PodPhase `json:"phase,omitempty"`
+	// +optional
+	Continue string `json:"continue,omitempty"`
+
+	// NamespacesProcessed is the number of namespaces done.
+	// +optional
+	NamespacesProcessed int32 `json:"namespacesProcessed,omitempty"`
+
+	// PodsDeleted is the number of pods deleted or evicted (or would-be deleted) so far.
+	// +optional
+	PodsDeleted int32 `json:"podsDeleted,omitempty"`
+}
+
+// ClusterRunStatus is the outcome of the last run of a policy in one workload cluster.
+type ClusterRunStatus struct {
+	// Name is the name of the ClusterTarget.
+	Name string `json:"name"`
+
+	// PodsDeleted is the number of pods deleted or evicted (or would-be deleted) in
+	// the cluster.
+	// +optional
+	PodsDeleted int32 `json:"podsDeleted,omitempty"`
+
+	// NamespacesProcessed is the number of target namespaces processed in the cluster.
+	// +optional
+	NamespacesProcessed int32 `json:"namespacesProcessed,omitempty"`
+
+	// PodsDeferred is the number of candidate pods in the cluster left for a later run
+	// because a deletion quota or ClusterCleanupBudget was exhausted.
+	// +optional
+	PodsDeferred int32 `json:"podsDeferred,omitempty"`
+
+	// Error describes why the run could not clean up the cluster, e.g. an unreadable
+	// kubeconfig or an unreachable API server.
+	// +optional
+	Error string `json:"error,omitempty"`
+}
+
+// MaxLastErrorLength caps the length of status.lastError.
+const MaxLastErrorLength = 128
+
+// MaxPreviewCandidates caps the candidates recorded in status.lastPreview.
+const MaxPreviewCandidates = 100
+
+// PolicyPreview is the outcome of a preview run.
+type PolicyPreview struct {
+	// Time is when the preview ran.
+	Time metav1.Time `json:"time"`
+
+	// CandidateCount is the number of pods the run would have deleted.
+	CandidateCount int32 `json:"candidateCount"`
+
+	// Candidates lists the pods the run would have deleted, capped at 100 entries.
+	// +optional
+	Candidates []PreviewCandidate `json:"candidates,omitempty"`
+}
+
+// PreviewCandidate is a pod a preview run would have deleted.
+type PreviewCandidate struct {
+	Namespace string          `json:"namespace"`
+	Name      string          `json:"name"`
+	Phase     corev1.PodPhase `json:"phase,omitempty"`
+	// Age is the age of the pod when the preview ran.
+	Age metav1.Duration `json:"age"`
+	// Rule is the anyOf group of spec.match the pod matched, if any.
+	// +optional
+	Rule string `json:"rule,omitempty"`
+}
+
+// MaxDiffPods caps the pods listed in each side of a CandidateDiff.
+const MaxDiffPods = 20
+
+// CandidateDiff is the change in candidates between two consecutive dry runs.
+type CandidateDiff struct {
+	// Time is when the later dry run ran.
+	Time metav1.Time `json:"time"`
+
+	// Added is the number of pods that became candidates since the previous dry run.
+	Added int32 `json:"added"`
+
+	// Resolved is the number of previous candidates that no longer are, e.g. because
+	// they were deleted or the policy no longer matches them.
+	Resolved int32 `json:"resolved"`
+
+	// AddedPods lists up to 20 added pods as namespace/name.
+	// +optional
+	AddedPods []string `json:"addedPods,omitempty"`
+
+	// ResolvedPods lists up to 20 resolved pods as namespace/name.
+	// +optional
+	ResolvedPods []string `json:"resolvedPods,omitempty"`
+}
+
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 //+kubebuilder:resource:scope=Cluster,shortName=pcp
+//+kubebuilder:printcolumn:name="Action",type=string,JSONPath=`.spec.action`
 //+kubebuilder:printcolumn:name="Schedule",type=string,JSONPath=`.spec.schedule`
+//+kubebuilder:printcolumn:name="Suspended",type=boolean,JSONPath=`.spec.suspend`
 //+kubebuilder:printcolumn:name="DryRun",type=boolean,JSONPath=`.spec.dryRun`
+//+kubebuilder:printcolumn:name="DryRunForced",type=string,JSONPath=`.status.conditions[?(@.type=="DryRunForced")].status`
 //+kubebuilder:printcolumn:name="LastRun",type=string,JSONPath=`.status.lastRunTime`
+//+kubebuilder:printcolumn:name="LastRunDeleted",type=integer,JSONPath=`.status.lastRunPodsDeleted`
 //+kubebuilder:printcolumn:name="PodsDeleted",type=integer,JSONPath=`.status.podsDeleted`
+//+kubebuilder:printcolumn:name="LastError",type=string,JSONPath=`.status.lastError`
+//+kubebuilder:printcolumn:name="Message",type=string,JSONPath=`.status.message`,priority=1
 
 // PodCleanupPolicy is the Schema for the podcleanuppolicies API.
 // It defines rules for automatically cleaning up pods based on their state and age.
@@ -0,0 +1,67 @@
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PodRetentionPolicySpec declares pods every cleanup policy must keep until they
+// reach a minimum age.
+type PodRetentionPolicySpec struct {
+	// NamespaceSelector selects the namespaces whose pods are retained.
+	// If not set, pods in all namespaces are retained.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// PodSelector selects the pods to retain by their labels.
+	// If not set, all pods in the selected namespaces are retained.
+	// +optional
+	PodSelector *metav1.LabelSelector `json:"podSelector,omitempty"`
+
+	// PodStatuses restricts retention to pods in these phases, e.g. [Failed].
+	// If not set, pods in any phase are retained.
+	// +kubebuilder:validation:MaxItems=5
+	// +kubebuilder:validation:XValidation:rule="self.all(p, p in ['Pending', 'Running', 'Succeeded', 'Failed', 'Unknown'])",message="podStatuses must be pod phases: Pending, Running, Succeeded, Failed or Unknown"
+	// +optional
+	PodStatuses []corev1.PodPhase `json:"podStatuses,omitempty"`
+
+	// RetainFor is the minimum age, measured from pod creation, before a cleanup
+	// policy may remove a retained pod (e.g., "7d").
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h|d|w))+$`
+	RetainFor string `json:"retainFor"`
+
+	// Reason documents why the pods are retained, e.g. "forensics". It is included
+	// in explained decisions.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Cluster,shortName=prp
+//+kubebuilder:printcolumn:name="RetainFor",type=string,JSONPath=`.spec.retainFor`
+//+kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.spec.reason`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// PodRetentionPolicy is the Schema for the podretentionpolicies API.
+// It declares pods that every cleanup policy must retain for a minimum time, e.g.
+// Failed pods of a team kept for 7 days for forensics. Unlike a Protect policy, the
+// pods are cleaned up as usual once they are old enough.
+type PodRetentionPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec PodRetentionPolicySpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// PodRetentionPolicyList contains a list of PodRetentionPolicy
+type PodRetentionPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PodRetentionPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PodRetentionPolicy{}, &PodRetentionPolicyList{})
+}
@@ -0,0 +1,670 @@
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// scheduleParser parses the five-field cron expressions accepted in spec.schedule.
+var scheduleParser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
+
+// ParseSchedule parses a policy schedule.
+func ParseSchedule(schedule string) (cron.Schedule, error) {
+	return scheduleParser.Parse(schedule)
+}
+
+// changeRecordFuncs are the functions available to change record templates.
+var changeRecordFuncs = template.FuncMap{
+	"json": func(v any) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+}
+
+// ParseChangeRecordTemplate parses the template of a ChangeRecordWebhook.
+func ParseChangeRecordTemplate(text string) (*template.Template, error) {
+	return template.New("changeRecord").Funcs(changeRecordFuncs).Parse(text)
+}
+
+// Validate checks the policy the way the controller interprets it: the schedule,
+// durations and selectors must parse, and the rules the CRD enforces through CEL
+// must hold. It does not check rules enforced by the OpenAPI schema alone.
+func (p *PodCleanupPolicy) Validate() field.ErrorList {
+	var errs field.ErrorList
+	spec := &p.Spec
+	specPath := field.NewPath("spec")
+
+	if spec.Schedule != "" {
+		if _, err := ParseSchedule(spec.Schedule); err != nil {
+			errs = append(errs, field.Invalid(specPath.Child("schedule"), spec.Schedule, err.Error()))
+		}
+		if spec.ScheduleRef != "" {
+			errs = append(errs, field.Forbidden(specPath.Child("scheduleRef"), "schedule and scheduleRef are mutually exclusive"))
+		}
+	}
+	phases := make(map[corev1.PodPhase]bool, len(spec.MaxAgeByPhase))
+	for i, phaseAge := range spec.MaxAgeByPhase {
+		phasePath := specPath.Child("maxAgeByPhase").Index(i)
+		errs = append(errs, validatePhases([]corev1.PodPhase{phaseAge.Phase}, phasePath.Child("phase"))...)
+		if phases[phaseAge.Phase] {
+			errs = append(errs, field.Duplicate(phasePath.Child("phase"), phaseAge.Phase))
+		}
+		phases[phaseAge.Phase] = true
+	}
+	errs = append(errs, validateSelector(spec.NamespaceSelector, specPath.Child("namespaceSelector"))...)
+	errs = append(errs, validateSelector(spec.PodSelector, specPath.Child("podSelector"))...)
+	errs = append(errs, validateSelector(spec.NodeLabelSelector, specPath.Child("nodeLabelSelector"))...)
+	errs = append(errs, validateSelector(spec.MaintenanceNodeSelector, specPath.Child("maintenanceNodeSelector"))...)
+	errs = append(errs, validatePhases(spec.PodStatuses, specPath.Child("podStatuses"))...)
+	for i, cond := range spec.PodConditions {
+		errs = append(errs, validatePodConditionMatch(cond, specPath.Child("podConditions").Index(i))...)
+	}
+	if spec.Match != nil {
+		matchPath := specPath.Child("match")
+		errs = append(errs, validateCriteria(spec.Match.AllOf, matchPath.Child("allOf"))...)
+		for i, group := range spec.Match.AnyOf {
+			groupPath := matchPath.Child("anyOf").Index(i)
+			if len(group.AllOf) == 0 {
+				errs = append(errs, field.Required(groupPath.Child("allOf"), ""))
+			}
+			switch group.Action {
+			case "", RuleActionDelete, RuleActionEvict, RuleActionNotify:
+			case RuleActionLabel:
+				if len(group.Labels) == 0 {
+					errs = append(errs, field.Required(groupPath.Child("labels"), "labels is required when action is Label"))
+				}
+			default:
+				errs = append(errs, field.NotSupported(groupPath.Child("action"), group.Action,
+					[]string{string(RuleActionDelete), string(RuleActionEvict), string(RuleActionLabel), string(RuleActionNotify)}))
+			}
+			errs = append(errs, metav1validation.ValidateLabels(group.Labels, groupPath.Child("labels"))...)
+			errs = append(errs, validateCriteria(group.AllOf, groupPath.Child("allOf"))...)
+		}
+	}
+	if keep := spec.KeepNewest; keep != nil {
+		keepPath := specPath.Child("keepNewest")
+		if keep.Count < 1 {
+			errs = append(errs, field.Invalid(keepPath.Child("count"), keep.Count, "must be at least 1"))
+		}
+		if keep.GroupBy != "" {
+			for _, msg := range validation.IsQualifiedName(keep.GroupBy) {
+				errs = append(errs, field.Invalid(keepPath.Child("groupBy"), keep.GroupBy, msg))
+			}
+		}
+	}
+	if c := spec.StuckOnVolumeClaim; c != nil && c.For != "" {
+		if _, err := ParseDuration(c.For); err != nil {
+			errs = append(errs, field.Invalid(specPath.Child("stuckOnVolumeClaim", "for"), c.For, err.Error()))
+		}
+	}
+	if c := spec.StuckOnSidecar; c != nil {
+		sidecarPath := specPath.Child("stuckOnSidecar")
+		if c.For != "" {
+			if _, err := ParseDuration(c.For); err != nil {
+				errs = append(errs, field.Invalid(sidecarPath.Child("for"), c.For, err.Error()))
+			}
+		}
+		switch c.Action {
+		case "", SidecarActionDelete, SidecarActionQuit:
+		default:
+			errs = append(errs, field.NotSupported(sidecarPath.Child("action"), c.Action,
+				[]string{string(SidecarActionDelete), string(SidecarActionQuit)}))
+		}
+		if len(c.QuitCommand) > 0 && c.Action != SidecarActionQuit {
+			errs = append(errs, field.Invalid(sidecarPath.Child("quitCommand"), c.QuitCommand, "quitCommand requires action QuitSidecar"))
+		}
+		if c.Action == SidecarActionQuit && spec.ServiceAccountName == "" {
+			errs = append(errs, field.Required(specPath.Child("serviceAccountName"),
+				"serviceAccountName is required when stuckOnSidecar.action is QuitSidecar"))
+		}
+	}
+	errs = append(errs, validateExternalMatcher(spec.ExternalMatcher, specPath.Child("externalMatcher"))...)
+	if hooks := spec.Hooks; hooks != nil {
+		if spec.ServiceAccountName == "" {
+			errs = append(errs, field.Required(specPath.Child("serviceAccountName"),
+				"serviceAccountName is required when hooks is set"))
+		}
+		errs = append(errs, validateHookJob(hooks.PreRun, specPath.Child("hooks", "preRun"))...)
+		errs = append(errs, validateHookJob(hooks.PostRun, specPath.Child("hooks", "postRun"))...)
+	}
+	for i, cond := range spec.NodeConditions {
+		condPath := specPath.Child("nodeConditions").Index(i)
+		if cond.Type == "" {
+			errs = append(errs, field.Required(condPath.Child("type"), ""))
+		}
+		errs = append(errs, validateConditionStatus(cond.Status, condPath.Child("status"))...)
+	}
+	for i, taint := range spec.NodeTaints {
+		taintPath := specPath.Child("nodeTaints").Index(i)
+		if taint.Key == "" {
+			errs = append(errs, field.Required(taintPath.Child("key"), ""))
+		}
+		switch taint.Effect {
+		case "", corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule, corev1.TaintEffectNoExecute:
+		default:
+			errs = append(errs, field.NotSupported(taintPath.Child("effect"), taint.Effect,
+				[]string{string(corev1.TaintEffectNoSchedule), string(corev1.TaintEffectPreferNoSchedule),
+					string(corev1.TaintEffectNoExecute)}))
+		}
+	}
+	if spec.ServiceAccountName != "" && spec.ServiceAccountNamespace == "" {
+		errs = append(errs, field.Required(specPath.Child("serviceAccountNamespace"),
+			"serviceAccountNamespace is required when serviceAccountName is set"))
+	}
+	errs = append(errs, validateNotifications(spec.Notifications, specPath.Child("notifications"))...)
+	errs = append(errs, validateArchive(spec.Archive, specPath.Child("archive"))...)
+	if ttl := spec.NamespaceTTL; ttl != nil {
+		if _, err := ParseDuration(ttl.TTL); err != nil {
+			errs = append(errs, field.Invalid(specPath.Child("namespaceTTL", "ttl"), ttl.TTL, err.Error()))
+		}
+		if spec.NamespaceSelector == nil {
+			errs = append(errs, field.Required(specPath.Child("namespaceSelector"),
+				"namespaceSelector is required when namespaceTTL is set"))
+		}
+	}
+	clusters := make(map[string]bool, len(spec.ClusterRefs))
+	for i, name := range spec.ClusterRefs {
+		if clusters[name] {
+			errs = append(errs, field.Duplicate(specPath.Child("clusterRefs").Index(i), name))
+		}
+		clusters[name] = true
+	}
+	if len(spec.ClusterRefs) > 0 {
+		if spec.ServiceAccountName != "" {
+			errs = append(errs, field.Forbidden(specPath.Child("serviceAccountName"),
+				"clusterRefs and serviceAccountName are mutually exclusive"))
+		}
+		if spec.MaintenanceNodeSelector != nil {
+			errs = append(errs, field.Forbidden(specPath.Child("maintenanceNodeSelector"),
+				"clusterRefs and maintenanceNodeSelector are mutually exclusive"))
+		}
+		if spec.PreferScaleDownNodes {
+			errs = append(errs, field.Forbidden(specPath.Child("preferScaleDownNodes"),
+				"clusterRefs and preferScaleDownNodes are mutually exclusive"))
+		}
+	}
+	return errs
+}
+
+// Validate checks the OperatorConfig, including the singleton name rule enforced by
+// the CRD.
+func (c *OperatorConfig) Validate() field.ErrorList {
+	var errs field.ErrorList
+	if c.Name != OperatorConfigName {
+		errs = append(errs, field.Invalid(field.NewPath("metadata", "name"), c.Name,
+			fmt.Sprintf("OperatorConfig is a singleton and must be named '%s'", OperatorConfigName)))
+	}
+	errs = append(errs, validateNotifications(c.Spec.Notifications, field.NewPath("spec", "notifications"))...)
+	if c.Spec.Audit != nil {
+		errs = append(errs, validateSyslog(c.Spec.Audit.Syslog, field.NewPath("spec", "audit", "syslog"))...)
+		errs = append(errs, validateSplunk(c.Spec.Audit.Splunk, field.NewPath("spec", "audit", "splunk"))...)
+		errs = append(errs, validateAuditFile(c.Spec.Audit.File, field.NewPath("spec", "audit", "file"))...)
+	}
+	errs = append(errs, validateDecisionPoint(c.Spec.DecisionPoint, field.NewPath("spec", "decisionPoint"))...)
+	if da := c.Spec.DisruptionAnnotations; da != nil {
+		path := field.NewPath("spec", "disruptionAnnotations", "annotations")
+		for i, match := range da.Annotations {
+			if match.Key == "" {
+				errs = append(errs, field.Required(path.Index(i).Child("key"), ""))
+			}
+		}
+	}
+	if mesh := c.Spec.ServiceMesh; mesh != nil {
+		path := field.NewPath("spec", "serviceMesh")
+		for i, key := range mesh.Annotations {
+			if key == "" {
+				errs = append(errs, field.Required(path.Child("annotations").Index(i), ""))
+			}
+		}
+		if mesh.GracePeriodSeconds != nil && *mesh.GracePeriodSeconds < 0 {
+			errs = append(errs, field.Invalid(path.Child("gracePeriodSeconds"), *mesh.GracePeriodSeconds, "must not be negative"))
+		}
+	}
+	errs = append(errs, validateChangeRecord(c.Spec.ChangeRecord, field.NewPath("spec", "changeRecord"))...)
+	if model := c.Spec.CostModel; model != nil {
+		path := field.NewPath("spec", "costModel", "prices")
+		for name, price := range model.Prices {
+			if p, err := strconv.ParseFloat(price, 64); err != nil || p < 0 {
+				errs = append(errs, field.Invalid(path.Key(string(name)), price, "must be a non-negative decimal number"))
+			}
+		}
+	}
+	return errs
+}
+
+// Validate checks that the ClusterTarget selects a kubeconfig.
+func (t *ClusterTarget) Validate() field.ErrorList {
+	var errs field.ErrorList
+	refPath := field.NewPath("spec", "kubeconfigSecretRef")
+	if t.Spec.KubeconfigSecretRef.Name == "" {
+		errs = append(errs, field.Required(refPath.Child("name"), ""))
+	}
+	if t.Spec.KubeconfigSecretRef.Key == "" {
+		errs = append(errs, field.Required(refPath.Child("key"), ""))
+	}
+	return errs
+}
+
+// Validate checks the ClusterCleanupDefaults.
+func (d *ClusterCleanupDefaults) Validate() field.ErrorList {
+	specPath := field.NewPath("spec")
+	errs := validateNotifications(d.Spec.Notifications, specPath.Child("notifications"))
+	return append(errs, validateArchive(d.Spec.Archive, specPath.Child("archive"))...)
+}
+
+// Validate checks that the CleanupSchedule's time zone, schedule and blackout
+// windows parse.
+func (s *CleanupSchedule) Validate() field.ErrorList {
+	var errs field.ErrorList
+	specPath := field.NewPath("spec")
+	if s.Spec.TimeZone != "" {
+		if _, err := time.LoadLocation(s.Spec.TimeZone); err != nil {
+			errs = append(errs, field.Invalid(specPath.Child("timeZone"), s.Spec.TimeZone, err.Error()))
+		}
+	}
+	if _, err := ParseSchedule(s.Spec.Schedule); err != nil {
+		errs = append(errs, field.Invalid(specPath.Child("schedule"), s.Spec.Schedule, err.Error()))
+	}
+	for i, window := range s.Spec.BlackoutWindows {
+		windowPath := specPath.Child("blackoutWindows").Index(i)
+		if _, err := ParseSchedule(window.Start); err != nil {
+			errs = append(errs, field.Invalid(windowPath.Child("start"), window.Start, err.Error()))
+		}
+		if d, err := ParseDuration(window.Duration); err != nil {
+			errs = append(errs, field.Invalid(windowPath.Child("duration"), window.Duration, err.Error()))
+		} else if d <= 0 {
+			errs = append(errs, field.Invalid(windowPath.Child("duration"), window.Duration, "must be positive"))
+		}
+	}
+	return errs
+}
+
+// Validate checks the PodRetentionPolicy's selectors, phases and duration.
+func (p *PodRetentionPolicy) Validate() field.ErrorList {
+	var errs field.ErrorList
+	specPath := field.NewPath("spec")
+	errs = append(errs, validateSelector(p.Spec.NamespaceSelector, specPath.Child("namespaceSelector"))...)
+	errs = append(errs, validateSelector(p.Spec.PodSelector, specPath.Child("podSelector"))...)
+	errs = append(errs, validatePhases(p.Spec.PodStatuses, specPath.Child("podStatuses"))...)
+	if _, err := ParseDuration(p.Spec.RetainFor); err != nil {
+		errs = append(errs, field.Invalid(specPath.Child("retainFor"), p.Spec.RetainFor, err.Error()))
+	}
+	return errs
+}
+
+// Validate checks the ClusterCleanupBudget's window and limits.
+func (b *ClusterCleanupBudget) Validate() field.ErrorList {
+	var errs field.ErrorList
+	specPath := field.NewPath("spec")
+	if b.Spec.Window != "" {
+		if d, err := ParseDuration(b.Spec.Window); err != nil {
+			errs = append(errs, field.Invalid(specPath.Child("window"), b.Spec.Window, err.Error()))
+		} else if d <= 0 {
+			errs = append(errs, field.Invalid(specPath.Child("window"), b.Spec.Window, "must be positive"))
+		}
+	}
+	if b.Spec.MaxDeletions == nil && b.Spec.MaxDeletionsPerNamespace == nil {
+		errs = append(errs, field.Required(specPath, "maxDeletions or maxDeletionsPerNamespace is required"))
+	}
+	return errs
+}
+
+// Validate checks the NodeDrainCleanup's selectors and action.
+func (d *NodeDrainCleanup) Validate() field.ErrorList {
+	var errs field.ErrorList
+	specPath := field.NewPath("spec")
+	errs = append(errs, validateSelector(d.Spec.NodeSelector, specPath.Child("nodeSelector"))...)
+	errs = append(errs, validateSelector(d.Spec.PodSelector, specPath.Child("podSelector"))...)
+	switch d.Spec.Action {
+	case "", DrainActionEvict, DrainActionDelete:
+	default:
+		errs = append(errs, field.NotSupported(specPath.Child("action"), d.Spec.Action,
+			[]string{string(DrainActionEvict), string(DrainActionDelete)}))
+	}
+	return errs
+}
+
+// Validate checks the CleanupRequest's selectors.
+func (r *CleanupRequest) Validate() field.ErrorList {
+	var errs field.ErrorList
+	specPath := field.NewPath("spec")
+	if r.Spec.PolicyName == "" {
+		errs = append(errs, field.Required(specPath.Child("policyName"), ""))
+	}
+	errs = append(errs, validateSelector(r.Spec.NamespaceSelector, specPath.Child("namespaceSelector"))...)
+	errs = append(errs, validateSelector(r.Spec.PodSelector, specPath.Child("podSelector"))...)
+	if r.Spec.Explain && !r.Spec.Preview {
+		errs = append(errs, field.Invalid(specPath.Child("explain"), r.Spec.Explain, "explain requires preview"))
+	}
+	return errs
+}
+
+// Validate checks the simulated policy spec like PodCleanupPolicy.Validate, with
+// errors reported under spec.policy.
+func (s *CleanupSimulation) Validate() field.ErrorList {
+	policy := &PodCleanupPolicy{Spec: s.Spec.Policy}
+	errs := policy.Validate()
+	for _, err := range errs {
+		err.Field = "spec.policy" + strings.TrimPrefix(err.Field, "spec")
+	}
+	return errs
+}
+
+func validatePhases(phases []corev1.PodPhase, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	for i, phase := range phases {
+		switch phase {
+		case corev1.PodPending, corev1.PodRunning, corev1.PodSucceeded, corev1.PodFailed, corev1.PodUnknown:
+		default:
+			errs = append(errs, field.NotSupported(path.Index(i), phase,
+				[]string{string(corev1.PodPending), string(corev1.PodRunning), string(corev1.PodSucceeded),
+					string(corev1.PodFailed), string(corev1.PodUnknown)}))
+		}
+	}
+	return errs
+}
+
+func validatePodConditionMatch(cond PodConditionMatch, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if cond.Type == "" {
+		errs = append(errs, field.Required(path.Child("type"), ""))
+	}
+	errs = append(errs, validateConditionStatus(cond.Status, path.Child("status"))...)
+	if cond.For != "" {
+		if _, err := ParseDuration(cond.For); err != nil {
+			errs = append(errs, field.Invalid(path.Child("for"), cond.For, err.Error()))
+		}
+	}
+	return errs
+}
+
+func validateCriteria(criteria []Criterion, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	for i, c := range criteria {
+		cPath := path.Index(i)
+		set := 0
+		if len(c.Phases) > 0 {
+			set++
+			errs = append(errs, validatePhases(c.Phases, cPath.Child("phases"))...)
+		}
+		if c.OlderThan != "" {
+			set++
+			if _, err := ParseDuration(c.OlderThan); err != nil {
+				errs = append(errs, field.Invalid(cPath.Child("olderThan"), c.OlderThan, err.Error()))
+			}
+		}
+		if len(c.WaitingReasons) > 0 {
+			set++
+		}
+		if len(c.TerminatedReasons) > 0 {
+			set++
+		}
+		if c.Condition != nil {
+			set++
+			errs = append(errs, validatePodConditionMatch(*c.Condition, cPath.Child("condition"))...)
+		}
+		if set != 1 {
+			errs = append(errs, field.Invalid(cPath, set, "exactly one criterion field must be set"))
+		}
+	}
+	return errs
+}
+
+func validateConditionStatus(status corev1.ConditionStatus, path *field.Path) field.ErrorList {
+	switch status {
+	case corev1.ConditionTrue, corev1.ConditionFalse, corev1.ConditionUnknown:
+		return nil
+	}
+	return field.ErrorList{field.NotSupported(path, status,
+		[]string{string(corev1.ConditionTrue), string(corev1.ConditionFalse), string(corev1.ConditionUnknown)})}
+}
+
+func validateSelector(selector *metav1.LabelSelector, path *field.Path) field.ErrorList {
+	if selector == nil {
+		return nil
+	}
+	if _, err := metav1.LabelSelectorAsSelector(selector); err != nil {
+		return field.ErrorList{field.Invalid(path, selector, err.Error())}
+	}
+	return nil
+}
+
+func validateArchive(archive *ArchiveSpec, path *field.Path) field.ErrorList {
+	if archive == nil {
+		return nil
+	}
+	var errs field.ErrorList
+	switch archive.Type {
+	case "", ArchiveConfigMap:
+	case ArchiveGCS:
+		if archive.GCS == nil || archive.GCS.Bucket == "" {
+			errs = append(errs, field.Required(path.Child("gcs", "bucket"), "gcs is required when type is GCS"))
+		} else {
+			errs = append(errs, validateSecretRef(archive.GCS.SecretRef, path.Child("gcs", "secretRef"))...)
+		}
+	case ArchiveAzureBlob:
+		if archive.AzureBlob == nil || archive.AzureBlob.Account == "" || archive.AzureBlob.Container == "" {
+			errs = append(errs, field.Required(path.Child("azureBlob"), "account and container are required when type is AzureBlob"))
+		} else {
+			errs = append(errs, validateSecretRef(archive.AzureBlob.SecretRef, path.Child("azureBlob", "secretRef"))...)
+		}
+	default:
+		errs = append(errs, field.NotSupported(path.Child("type"), archive.Type,
+			[]string{string(ArchiveConfigMap), string(ArchiveGCS), string(ArchiveAzureBlob)}))
+	}
+	return errs
+}
+
+func validateNotifications(endpoints []NotificationEndpoint, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	for i, endpoint := range endpoints {
+		u, err := url.Parse(endpoint.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errs = append(errs, field.Invalid(path.Index(i).Child("url"), endpoint.URL,
+				"must be an absolute http or https URL"))
+		}
+		switch endpoint.Format {
+		case "", NotificationFormatJSON, NotificationFormatTeams, NotificationFormatGoogleChat, NotificationFormatDatadog:
+		default:
+			errs = append(errs, field.NotSupported(path.Index(i).Child("format"), endpoint.Format,
+				[]string{string(NotificationFormatJSON), string(NotificationFormatTeams), string(NotificationFormatGoogleChat),
+					string(NotificationFormatDatadog)}))
+		}
+		if endpoint.Format == NotificationFormatDatadog && endpoint.SecretRef == nil {
+			errs = append(errs, field.Required(path.Index(i).Child("secretRef"), "the Datadog API key is required"))
+		}
+		if len(endpoint.Tags) > 0 && endpoint.Format != NotificationFormatDatadog {
+			errs = append(errs, field.Invalid(path.Index(i).Child("tags"), endpoint.Tags, "tags require format Datadog"))
+		}
+		if endpoint.Digest != nil {
+			if _, err := ParseDuration(endpoint.Digest.Interval); err != nil {
+				errs = append(errs, field.Invalid(path.Index(i).Child("digest", "interval"), endpoint.Digest.Interval, err.Error()))
+			}
+		}
+		errs = append(errs, validateSecretRef(endpoint.SecretRef, path.Index(i).Child("secretRef"))...)
+		if endpoint.Signing != nil {
+			errs = append(errs, validateSecretRef(&endpoint.Signing.SecretRef, path.Index(i).Child("signing", "secretRef"))...)
+		}
+	}
+	return errs
+}
+
+func validateChangeRecord(webhook *ChangeRecordWebhook, path *field.Path) field.ErrorList {
+	if webhook == nil {
+		return nil
+	}
+	var errs field.ErrorList
+	u, err := url.Parse(webhook.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		errs = append(errs, field.Invalid(path.Child("url"), webhook.URL, "must be an absolute http or https URL"))
+	}
+	if webhook.Template != "" {
+		if _, err := ParseChangeRecordTemplate(webhook.Template); err != nil {
+			errs = append(errs, field.Invalid(path.Child("template"), webhook.Template, err.Error()))
+		}
+	}
+	errs = append(errs, validateSecretRef(webhook.SecretRef, path.Child("secretRef"))...)
+	return errs
+}
+
+func validateDecisionPoint(point *DecisionPoint, path *field.Path) field.ErrorList {
+	if point == nil {
+		return nil
+	}
+	var errs field.ErrorList
+	u, err := url.Parse(point.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		errs = append(errs, field.Invalid(path.Child("url"), point.URL, "must be an absolute http or https URL"))
+	}
+	if strings.Trim(point.Path, "/") == "" {
+		errs = append(errs, field.Required(path.Child("path"), ""))
+	}
+	if point.Timeout != "" {
+		if _, err := ParseDuration(point.Timeout); err != nil {
+			errs = append(errs, field.Invalid(path.Child("timeout"), point.Timeout, err.Error()))
+		}
+	}
+	switch point.FailurePolicy {
+	case "", DecisionFailureDeny, DecisionFailureAllow:
+	default:
+		errs = append(errs, field.NotSupported(path.Child("failurePolicy"), point.FailurePolicy,
+			[]string{string(DecisionFailureDeny), string(DecisionFailureAllow)}))
+	}
+	errs = append(errs, validateSecretRef(point.SecretRef, path.Child("secretRef"))...)
+	return errs
+}
+
+func validateSyslog(output *SyslogOutput, path *field.Path) field.ErrorList {
+	if output == nil {
+		return nil
+	}
+	var errs field.ErrorList
+	if _, _, err := net.SplitHostPort(output.Address); err != nil {
+		errs = append(errs, field.Invalid(path.Child("address"), output.Address, "must be host:port"))
+	}
+	switch output.Protocol {
+	case "", SyslogUDP, SyslogTCP, SyslogTLS:
+	default:
+		errs = append(errs, field.NotSupported(path.Child("protocol"), output.Protocol,
+			[]string{string(SyslogUDP), string(SyslogTCP), string(SyslogTLS)}))
+	}
+	if output.CASecretRef != nil && output.Protocol != SyslogTLS {
+		errs = append(errs, field.Invalid(path.Child("caSecretRef"), output.CASecretRef.Name, "caSecretRef requires protocol TLS"))
+	}
+	errs = append(errs, validateSecretRef(output.CASecretRef, path.Child("caSecretRef"))...)
+	return errs
+}
+
+func validateExternalMatcher(matcher *ExternalMatcher, path *field.Path) field.ErrorList {
+	if matcher == nil {
+		return nil
+	}
+	var errs field.ErrorList
+	if _, _, err := net.SplitHostPort(matcher.Endpoint); err != nil {
+		errs = append(errs, field.Invalid(path.Child("endpoint"), matcher.Endpoint, "must be host:port"))
+	}
+	if matcher.Timeout != "" {
+		if _, err := ParseDuration(matcher.Timeout); err != nil {
+			errs = append(errs, field.Invalid(path.Child("timeout"), matcher.Timeout, err.Error()))
+		}
+	}
+	switch matcher.FailurePolicy {
+	case "", DecisionFailureDeny, DecisionFailureAllow:
+	default:
+		errs = append(errs, field.NotSupported(path.Child("failurePolicy"), matcher.FailurePolicy,
+			[]string{string(DecisionFailureDeny), string(DecisionFailureAllow)}))
+	}
+	if matcher.CASecretRef != nil && matcher.Insecure {
+		errs = append(errs, field.Invalid(path.Child("caSecretRef"), matcher.CASecretRef.Name, "caSecretRef requires TLS"))
+	}
+	errs = append(errs, validateSecretRef(matcher.CASecretRef, path.Child("caSecretRef"))...)
+	return errs
+}
+
+func validateHookJob(hook *HookJob, path *field.Path) field.ErrorList {
+	if hook == nil {
+		return nil
+	}
+	var errs field.ErrorList
+	if len(hook.JobTemplate.Spec.Template.Spec.Containers) == 0 {
+		errs = append(errs, field.Required(path.Child("jobTemplate", "spec", "template", "spec", "containers"), ""))
+	}
+	if hook.Timeout != "" {
+		if _, err := ParseDuration(hook.Timeout); err != nil {
+			errs = append(errs, field.Invalid(path.Child("timeout"), hook.Timeout, err.Error()))
+		}
+	}
+	switch hook.FailurePolicy {
+	case "", HookFailureFail, HookFailureIgnore:
+	default:
+		errs = append(errs, field.NotSupported(path.Child("failurePolicy"), hook.FailurePolicy,
+			[]string{string(HookFailureFail), string(HookFailureIgnore)}))
+	}
+	return errs
+}
+
+func validateSplunk(output *SplunkOutput, path *field.Path) field.ErrorList {
+	if output == nil {
+		return nil
+	}
+	var errs field.ErrorList
+	u, err := url.Parse(output.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		errs = append(errs, field.Invalid(path.Child("url"), output.URL, "must be an absolute http or https URL"))
+	}
+	if output.BatchSize != nil && (*output.BatchSize < 1 || *output.BatchSize > 1000) {
+		errs = append(errs, field.Invalid(path.Child("batchSize"), *output.BatchSize, "must be between 1 and 1000"))
+	}
+	errs = append(errs, validateSecretRef(&output.TokenSecretRef, path.Child("tokenSecretRef"))...)
+	errs = append(errs, validateSecretRef(output.CASecretRef, path.Child("caSecretRef"))...)
+	return errs
+}
+
+func validateAuditFile(output *FileOutput, path *field.Path) field.ErrorList {
+	if output == nil {
+		return nil
+	}
+	var errs field.ErrorList
+	if !strings.HasPrefix(output.Path, "/") || strings.HasSuffix(output.Path, "/") {
+		errs = append(errs, field.Invalid(path.Child("path"), output.Path, "must be the absolute path of a file"))
+	}
+	if output.MaxSizeMB != nil && *output.MaxSizeMB < 1 {
+		errs = append(errs, field.Invalid(path.Child("maxSizeMB"), *output.MaxSizeMB, "must be at least 1"))
+	}
+	if output.MaxAge != "" {
+		if _, err := ParseDuration(output.MaxAge); err != nil {
+			errs = append(errs, field.Invalid(path.Child("maxAge"), output.MaxAge, err.Error()))
+		}
+	}
+	if output.MaxBackups != nil && *output.MaxBackups < 1 {
+		errs = append(errs, field.Invalid(path.Child("maxBackups"), *output.MaxBackups, "must be at least 1"))
+	}
+	return errs
+}
+
+func validateSecretRef(ref *SecretKeyRef, path *field.Path) field.ErrorList {
+	if ref == nil {
+		return nil
+	}
+	var errs field.ErrorList
+	if ref.Name == "" {
+		errs = append(errs, field.Required(path.Child("name"), ""))
+	}
+	if ref.Key == "" {
+		errs = append(errs, field.Required(path.Child("key"), ""))
+	}
+	return errs
+}
@@ -10,27 +10,2006 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *AnnotationMatch) DeepCopyInto(out *AnnotationMatch) {
+	*out = *in
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *AnnotationMatch) DeepCopy() *AnnotationMatch {
+	if in == nil {
+		return nil
+	}
+	out := new(AnnotationMatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *ArchiveSpec) DeepCopyInto(out *ArchiveSpec) {
+	*out = *in
+	if in.GCS != nil {
+		in, out := &in.GCS, &out.GCS
+		*out = new(GCSArchive)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AzureBlob != nil {
+		in, out := &in.AzureBlob, &out.AzureBlob
+		*out = new(AzureBlobArchive)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *ArchiveSpec) DeepCopy() *ArchiveSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArchiveSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *AuditConfig) DeepCopyInto(out *AuditConfig) {
+	*out = *in
+	if in.Syslog != nil {
+		in, out := &in.Syslog, &out.Syslog
+		*out = new(SyslogOutput)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Splunk != nil {
+		in, out := &in.Splunk, &out.Splunk
+		*out = new(SplunkOutput)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.File != nil {
+		in, out := &in.File, &out.File
+		*out = new(FileOutput)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *AuditConfig) DeepCopy() *AuditConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *AzureBlobArchive) DeepCopyInto(out *AzureBlobArchive) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(SecretKeyRef)
+		**out = **in
+	}
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *AzureBlobArchive) DeepCopy() *AzureBlobArchive {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureBlobArchive)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *BlackoutWindow) DeepCopyInto(out *BlackoutWindow) {
+	*out = *in
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *BlackoutWindow) DeepCopy() *BlackoutWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(BlackoutWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *BudgetImpact) DeepCopyInto(out *BudgetImpact) {
+	*out = *in
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *BudgetImpact) DeepCopy() *BudgetImpact {
+	if in == nil {
+		return nil
+	}
+	out := new(BudgetImpact)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *CandidateDiff) DeepCopyInto(out *CandidateDiff) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	if in.AddedPods != nil {
+		in, out := &in.AddedPods, &out.AddedPods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ResolvedPods != nil {
+		in, out := &in.ResolvedPods, &out.ResolvedPods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *CandidateDiff) DeepCopy() *CandidateDiff {
+	if in == nil {
+		return nil
+	}
+	out := new(CandidateDiff)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *ChangeRecordWebhook) DeepCopyInto(out *ChangeRecordWebhook) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(SecretKeyRef)
+		**out = **in
+	}
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *ChangeRecordWebhook) DeepCopy() *ChangeRecordWebhook {
+	if in == nil {
+		return nil
+	}
+	out := new(ChangeRecordWebhook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *CleanupCount) DeepCopyInto(out *CleanupCount) {
+	*out = *in
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *CleanupCount) DeepCopy() *CleanupCount {
+	if in == nil {
+		return nil
+	}
+	out := new(CleanupCount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *CleanupReport) DeepCopyInto(out *CleanupReport) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *CleanupReport) DeepCopy() *CleanupReport {
+	if in == nil {
+		return nil
+	}
+	out := new(CleanupReport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements the runtime.Object interface.
+func (in *CleanupReport) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *CleanupReportList) DeepCopyInto(out *CleanupReportList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CleanupReport, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *CleanupReportList) DeepCopy() *CleanupReportList {
+	if in == nil {
+		return nil
+	}
+	out := new(CleanupReportList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements the runtime.Object interface.
+func (in *CleanupReportList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *CleanupReportStatus) DeepCopyInto(out *CleanupReportStatus) {
+	*out = *in
+	if in.PeriodStart != nil {
+		in, out := &in.PeriodStart, &out.PeriodStart
+		*out = (*in).DeepCopy()
+	}
+	if in.LastUpdateTime != nil {
+		in, out := &in.LastUpdateTime, &out.LastUpdateTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ByPolicy != nil {
+		in, out := &in.ByPolicy, &out.ByPolicy
+		*out = make([]CleanupCount, len(*in))
+		copy(*out, *in)
+	}
+	if in.ByPhase != nil {
+		in, out := &in.ByPhase, &out.ByPhase
+		*out = make([]CleanupCount, len(*in))
+		copy(*out, *in)
+	}
+	if in.TopOwners != nil {
+		in, out := &in.TopOwners, &out.TopOwners
+		*out = make([]OwnerCleanupCount, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *CleanupReportStatus) DeepCopy() *CleanupReportStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CleanupReportStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *CleanupRequest) DeepCopyInto(out *CleanupRequest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *CleanupRequest) DeepCopy() *CleanupRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(CleanupRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements the runtime.Object interface.
+func (in *CleanupRequest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *CleanupRequestList) DeepCopyInto(out *CleanupRequestList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CleanupRequest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *CleanupRequestList) DeepCopy() *CleanupRequestList {
+	if in == nil {
+		return nil
+	}
+	out := new(CleanupRequestList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements the runtime.Object interface.
+func (in *CleanupRequestList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *CleanupRequestSpec) DeepCopyInto(out *CleanupRequestSpec) {
+	*out = *in
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodSelector != nil {
+		in, out := &in.PodSelector, &out.PodSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *CleanupRequestSpec) DeepCopy() *CleanupRequestSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CleanupRequestSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *CleanupRequestStatus) DeepCopyInto(out *CleanupRequestStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Candidates != nil {
+		in, out := &in.Candidates, &out.Candidates
+		*out = make([]PreviewCandidate, len(*in))
+		copy(*out, *in)
+	}
+	if in.Decisions != nil {
+		in, out := &in.Decisions, &out.Decisions
+		*out = make([]PodDecision, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *CleanupRequestStatus) DeepCopy() *CleanupRequestStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CleanupRequestStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *CleanupRun) DeepCopyInto(out *CleanupRun) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *CleanupRun) DeepCopy() *CleanupRun {
+	if in == nil {
+		return nil
+	}
+	out := new(CleanupRun)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements the runtime.Object interface.
+func (in *CleanupRun) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *CleanupRunList) DeepCopyInto(out *CleanupRunList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CleanupRun, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *CleanupRunList) DeepCopy() *CleanupRunList {
+	if in == nil {
+		return nil
+	}
+	out := new(CleanupRunList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements the runtime.Object interface.
+func (in *CleanupRunList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *CleanupRunSpec) DeepCopyInto(out *CleanupRunSpec) {
+	*out = *in
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *CleanupRunSpec) DeepCopy() *CleanupRunSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CleanupRunSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *CleanupRunStatus) DeepCopyInto(out *CleanupRunStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Decisions != nil {
+		in, out := &in.Decisions, &out.Decisions
+		*out = make([]PodDecision, len(*in))
+		copy(*out, *in)
+	}
+	if in.ArchiveLocations != nil {
+		in, out := &in.ArchiveLocations, &out.ArchiveLocations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *CleanupRunStatus) DeepCopy() *CleanupRunStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CleanupRunStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *CleanupSchedule) DeepCopyInto(out *CleanupSchedule) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *CleanupSchedule) DeepCopy() *CleanupSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(CleanupSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements the runtime.Object interface.
+func (in *CleanupSchedule) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *CleanupScheduleList) DeepCopyInto(out *CleanupScheduleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CleanupSchedule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *CleanupScheduleList) DeepCopy() *CleanupScheduleList {
+	if in == nil {
+		return nil
+	}
+	out := new(CleanupScheduleList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements the runtime.Object interface.
+func (in *CleanupScheduleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *CleanupScheduleSpec) DeepCopyInto(out *CleanupScheduleSpec) {
+	*out = *in
+	if in.BlackoutWindows != nil {
+		in, out := &in.BlackoutWindows, &out.BlackoutWindows
+		*out = make([]BlackoutWindow, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *CleanupScheduleSpec) DeepCopy() *CleanupScheduleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CleanupScheduleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *CleanupSimulation) DeepCopyInto(out *CleanupSimulation) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *CleanupSimulation) DeepCopy() *CleanupSimulation {
+	if in == nil {
+		return nil
+	}
+	out := new(CleanupSimulation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements the runtime.Object interface.
+func (in *CleanupSimulation) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *CleanupSimulationList) DeepCopyInto(out *CleanupSimulationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CleanupSimulation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *CleanupSimulationList) DeepCopy() *CleanupSimulationList {
+	if in == nil {
+		return nil
+	}
+	out := new(CleanupSimulationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements the runtime.Object interface.
+func (in *CleanupSimulationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *CleanupSimulationSpec) DeepCopyInto(out *CleanupSimulationSpec) {
+	*out = *in
+	in.Policy.DeepCopyInto(&out.Policy)
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *CleanupSimulationSpec) DeepCopy() *CleanupSimulationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CleanupSimulationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *CleanupSimulationStatus) DeepCopyInto(out *CleanupSimulationStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ByNamespace != nil {
+		in, out := &in.ByNamespace, &out.ByNamespace
+		*out = make([]CleanupCount, len(*in))
+		copy(*out, *in)
+	}
+	if in.SamplePods != nil {
+		in, out := &in.SamplePods, &out.SamplePods
+		*out = make([]PreviewCandidate, len(*in))
+		copy(*out, *in)
+	}
+	if in.Budgets != nil {
+		in, out := &in.Budgets, &out.Budgets
+		*out = make([]BudgetImpact, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *CleanupSimulationStatus) DeepCopy() *CleanupSimulationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CleanupSimulationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *ClusterCleanupBudget) DeepCopyInto(out *ClusterCleanupBudget) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *ClusterCleanupBudget) DeepCopy() *ClusterCleanupBudget {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterCleanupBudget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements the runtime.Object interface.
+func (in *ClusterCleanupBudget) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *ClusterCleanupBudgetList) DeepCopyInto(out *ClusterCleanupBudgetList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterCleanupBudget, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *ClusterCleanupBudgetList) DeepCopy() *ClusterCleanupBudgetList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterCleanupBudgetList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements the runtime.Object interface.
+func (in *ClusterCleanupBudgetList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *ClusterCleanupBudgetSpec) DeepCopyInto(out *ClusterCleanupBudgetSpec) {
+	*out = *in
+	if in.MaxDeletions != nil {
+		in, out := &in.MaxDeletions, &out.MaxDeletions
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxDeletionsPerNamespace != nil {
+		in, out := &in.MaxDeletionsPerNamespace, &out.MaxDeletionsPerNamespace
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *ClusterCleanupBudgetSpec) DeepCopy() *ClusterCleanupBudgetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterCleanupBudgetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *ClusterCleanupBudgetStatus) DeepCopyInto(out *ClusterCleanupBudgetStatus) {
+	*out = *in
+	if in.ExhaustedNamespaces != nil {
+		in, out := &in.ExhaustedNamespaces, &out.ExhaustedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *ClusterCleanupBudgetStatus) DeepCopy() *ClusterCleanupBudgetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterCleanupBudgetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *ClusterCleanupDefaults) DeepCopyInto(out *ClusterCleanupDefaults) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *ClusterCleanupDefaults) DeepCopy() *ClusterCleanupDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterCleanupDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements the runtime.Object interface.
+func (in *ClusterCleanupDefaults) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *ClusterCleanupDefaultsList) DeepCopyInto(out *ClusterCleanupDefaultsList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterCleanupDefaults, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *ClusterCleanupDefaultsList) DeepCopy() *ClusterCleanupDefaultsList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterCleanupDefaultsList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements the runtime.Object interface.
+func (in *ClusterCleanupDefaultsList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *ClusterCleanupDefaultsSpec) DeepCopyInto(out *ClusterCleanupDefaultsSpec) {
+	*out = *in
+	if in.GracePeriodSeconds != nil {
+		in, out := &in.GracePeriodSeconds, &out.GracePeriodSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.RateLimit != nil {
+		in, out := &in.RateLimit, &out.RateLimit
+		*out = new(RateLimit)
+		**out = **in
+	}
+	if in.Notifications != nil {
+		in, out := &in.Notifications, &out.Notifications
+		*out = make([]NotificationEndpoint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Archive != nil {
+		in, out := &in.Archive, &out.Archive
+		*out = new(ArchiveSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *ClusterCleanupDefaultsSpec) DeepCopy() *ClusterCleanupDefaultsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterCleanupDefaultsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *ClusterRunStatus) DeepCopyInto(out *ClusterRunStatus) {
+	*out = *in
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *ClusterRunStatus) DeepCopy() *ClusterRunStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterRunStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *ClusterTarget) DeepCopyInto(out *ClusterTarget) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *ClusterTarget) DeepCopy() *ClusterTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements the runtime.Object interface.
+func (in *ClusterTarget) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *ClusterTargetList) DeepCopyInto(out *ClusterTargetList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterTarget, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *ClusterTargetList) DeepCopy() *ClusterTargetList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterTargetList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements the runtime.Object interface.
+func (in *ClusterTargetList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *ClusterTargetSpec) DeepCopyInto(out *ClusterTargetSpec) {
+	*out = *in
+	out.KubeconfigSecretRef = in.KubeconfigSecretRef
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *ClusterTargetSpec) DeepCopy() *ClusterTargetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterTargetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *CostModel) DeepCopyInto(out *CostModel) {
+	*out = *in
+	if in.Prices != nil {
+		in, out := &in.Prices, &out.Prices
+		*out = make(map[corev1.ResourceName]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *CostModel) DeepCopy() *CostModel {
+	if in == nil {
+		return nil
+	}
+	out := new(CostModel)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *CriteriaGroup) DeepCopyInto(out *CriteriaGroup) {
+	*out = *in
+	if in.AllOf != nil {
+		in, out := &in.AllOf, &out.AllOf
+		*out = make([]Criterion, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *CriteriaGroup) DeepCopy() *CriteriaGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(CriteriaGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *Criterion) DeepCopyInto(out *Criterion) {
+	*out = *in
+	if in.Phases != nil {
+		in, out := &in.Phases, &out.Phases
+		*out = make([]corev1.PodPhase, len(*in))
+		copy(*out, *in)
+	}
+	if in.WaitingReasons != nil {
+		in, out := &in.WaitingReasons, &out.WaitingReasons
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TerminatedReasons != nil {
+		in, out := &in.TerminatedReasons, &out.TerminatedReasons
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Condition != nil {
+		in, out := &in.Condition, &out.Condition
+		*out = new(PodConditionMatch)
+		**out = **in
+	}
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *Criterion) DeepCopy() *Criterion {
+	if in == nil {
+		return nil
+	}
+	out := new(Criterion)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *DecisionPoint) DeepCopyInto(out *DecisionPoint) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(SecretKeyRef)
+		**out = **in
+	}
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *DecisionPoint) DeepCopy() *DecisionPoint {
+	if in == nil {
+		return nil
+	}
+	out := new(DecisionPoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *DisruptionAnnotations) DeepCopyInto(out *DisruptionAnnotations) {
+	*out = *in
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make([]AnnotationMatch, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *DisruptionAnnotations) DeepCopy() *DisruptionAnnotations {
+	if in == nil {
+		return nil
+	}
+	out := new(DisruptionAnnotations)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *Duration) DeepCopyInto(out *Duration) {
+	*out = *in
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *Duration) DeepCopy() *Duration {
+	if in == nil {
+		return nil
+	}
+	out := new(Duration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *ExternalMatcher) DeepCopyInto(out *ExternalMatcher) {
+	*out = *in
+	if in.CASecretRef != nil {
+		in, out := &in.CASecretRef, &out.CASecretRef
+		*out = new(SecretKeyRef)
+		**out = **in
+	}
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *ExternalMatcher) DeepCopy() *ExternalMatcher {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalMatcher)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *FileOutput) DeepCopyInto(out *FileOutput) {
+	*out = *in
+	if in.MaxSizeMB != nil {
+		in, out := &in.MaxSizeMB, &out.MaxSizeMB
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxBackups != nil {
+		in, out := &in.MaxBackups, &out.MaxBackups
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *FileOutput) DeepCopy() *FileOutput {
+	if in == nil {
+		return nil
+	}
+	out := new(FileOutput)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *GCSArchive) DeepCopyInto(out *GCSArchive) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(SecretKeyRef)
+		**out = **in
+	}
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *GCSArchive) DeepCopy() *GCSArchive {
+	if in == nil {
+		return nil
+	}
+	out := new(GCSArchive)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *HookJob) DeepCopyInto(out *HookJob) {
+	*out = *in
+	in.JobTemplate.DeepCopyInto(&out.JobTemplate)
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *HookJob) DeepCopy() *HookJob {
+	if in == nil {
+		return nil
+	}
+	out := new(HookJob)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *KeepNewest) DeepCopyInto(out *KeepNewest) {
+	*out = *in
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *KeepNewest) DeepCopy() *KeepNewest {
+	if in == nil {
+		return nil
+	}
+	out := new(KeepNewest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *MatchCriteria) DeepCopyInto(out *MatchCriteria) {
+	*out = *in
+	if in.AllOf != nil {
+		in, out := &in.AllOf, &out.AllOf
+		*out = make([]Criterion, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AnyOf != nil {
+		in, out := &in.AnyOf, &out.AnyOf
+		*out = make([]CriteriaGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *MatchCriteria) DeepCopy() *MatchCriteria {
+	if in == nil {
+		return nil
+	}
+	out := new(MatchCriteria)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *NamespaceTTL) DeepCopyInto(out *NamespaceTTL) {
+	*out = *in
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *NamespaceTTL) DeepCopy() *NamespaceTTL {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceTTL)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *NodeConditionMatch) DeepCopyInto(out *NodeConditionMatch) {
+	*out = *in
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *NodeConditionMatch) DeepCopy() *NodeConditionMatch {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeConditionMatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *NodeDrainCleanup) DeepCopyInto(out *NodeDrainCleanup) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *NodeDrainCleanup) DeepCopy() *NodeDrainCleanup {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeDrainCleanup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements the runtime.Object interface.
+func (in *NodeDrainCleanup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *NodeDrainCleanupList) DeepCopyInto(out *NodeDrainCleanupList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NodeDrainCleanup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *NodeDrainCleanupList) DeepCopy() *NodeDrainCleanupList {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeDrainCleanupList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements the runtime.Object interface.
+func (in *NodeDrainCleanupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *NodeDrainCleanupSpec) DeepCopyInto(out *NodeDrainCleanupSpec) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodSelector != nil {
+		in, out := &in.PodSelector, &out.PodSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GracePeriodSeconds != nil {
+		in, out := &in.GracePeriodSeconds, &out.GracePeriodSeconds
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *NodeDrainCleanupSpec) DeepCopy() *NodeDrainCleanupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeDrainCleanupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *NodeDrainCleanupStatus) DeepCopyInto(out *NodeDrainCleanupStatus) {
+	*out = *in
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]NodeDrainStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *NodeDrainCleanupStatus) DeepCopy() *NodeDrainCleanupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeDrainCleanupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *NodeDrainStatus) DeepCopyInto(out *NodeDrainStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CurrentPriority != nil {
+		in, out := &in.CurrentPriority, &out.CurrentPriority
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *NodeDrainStatus) DeepCopy() *NodeDrainStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeDrainStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *NotificationDigest) DeepCopyInto(out *NotificationDigest) {
+	*out = *in
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *NotificationDigest) DeepCopy() *NotificationDigest {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationDigest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *NotificationEndpoint) DeepCopyInto(out *NotificationEndpoint) {
+	*out = *in
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Digest != nil {
+		in, out := &in.Digest, &out.Digest
+		*out = new(NotificationDigest)
+		**out = **in
+	}
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(SecretKeyRef)
+		**out = **in
+	}
+	if in.Signing != nil {
+		in, out := &in.Signing, &out.Signing
+		*out = new(WebhookSigning)
+		**out = **in
+	}
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *NotificationEndpoint) DeepCopy() *NotificationEndpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationEndpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *OperatorConfig) DeepCopyInto(out *OperatorConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *OperatorConfig) DeepCopy() *OperatorConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OperatorConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements the runtime.Object interface.
+func (in *OperatorConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *OperatorConfigList) DeepCopyInto(out *OperatorConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OperatorConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *OperatorConfigList) DeepCopy() *OperatorConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(OperatorConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements the runtime.Object interface.
+func (in *OperatorConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *OperatorConfigSpec) DeepCopyInto(out *OperatorConfigSpec) {
+	*out = *in
+	if in.GracePeriodSeconds != nil {
+		in, out := &in.GracePeriodSeconds, &out.GracePeriodSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.RateLimit != nil {
+		in, out := &in.RateLimit, &out.RateLimit
+		*out = new(RateLimit)
+		**out = **in
+	}
+	if in.MaxConcurrentAPICalls != nil {
+		in, out := &in.MaxConcurrentAPICalls, &out.MaxConcurrentAPICalls
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TenantQuota != nil {
+		in, out := &in.TenantQuota, &out.TenantQuota
+		*out = new(TenantQuota)
+		**out = **in
+	}
+	if in.ProtectedNamespaces != nil {
+		in, out := &in.ProtectedNamespaces, &out.ProtectedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Notifications != nil {
+		in, out := &in.Notifications, &out.Notifications
+		*out = make([]NotificationEndpoint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RunReports != nil {
+		in, out := &in.RunReports, &out.RunReports
+		*out = new(RunReports)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Audit != nil {
+		in, out := &in.Audit, &out.Audit
+		*out = new(AuditConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DecisionPoint != nil {
+		in, out := &in.DecisionPoint, &out.DecisionPoint
+		*out = new(DecisionPoint)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DisruptionAnnotations != nil {
+		in, out := &in.DisruptionAnnotations, &out.DisruptionAnnotations
+		*out = new(DisruptionAnnotations)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceMesh != nil {
+		in, out := &in.ServiceMesh, &out.ServiceMesh
+		*out = new(ServiceMesh)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CostModel != nil {
+		in, out := &in.CostModel, &out.CostModel
+		*out = new(CostModel)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ChangeRecord != nil {
+		in, out := &in.ChangeRecord, &out.ChangeRecord
+		*out = new(ChangeRecordWebhook)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *OperatorConfigSpec) DeepCopy() *OperatorConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OperatorConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *OrphanedCriteria) DeepCopyInto(out *OrphanedCriteria) {
+	*out = *in
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *OrphanedCriteria) DeepCopy() *OrphanedCriteria {
+	if in == nil {
+		return nil
+	}
+	out := new(OrphanedCriteria)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *OwnerCleanupCount) DeepCopyInto(out *OwnerCleanupCount) {
+	*out = *in
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *OwnerCleanupCount) DeepCopy() *OwnerCleanupCount {
+	if in == nil {
+		return nil
+	}
+	out := new(OwnerCleanupCount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *PhaseMaxAge) DeepCopyInto(out *PhaseMaxAge) {
+	*out = *in
+	out.MaxAge = in.MaxAge
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *PhaseMaxAge) DeepCopy() *PhaseMaxAge {
+	if in == nil {
+		return nil
+	}
+	out := new(PhaseMaxAge)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
 func (in *PodCleanupPolicy) DeepCopyInto(out *PodCleanupPolicy) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *PodCleanupPolicy) DeepCopy() *PodCleanupPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PodCleanupPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements the runtime.Object interface.
+func (in *PodCleanupPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *PodCleanupPolicyList) DeepCopyInto(out *PodCleanupPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PodCleanupPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *PodCleanupPolicyList) DeepCopy() *PodCleanupPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(PodCleanupPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements the runtime.Object interface.
+func (in *PodCleanupPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *PodCleanupPolicySpec) DeepCopyInto(out *PodCleanupPolicySpec) {
+	*out = *in
+	if in.StartingDeadlineSeconds != nil {
+		in, out := &in.StartingDeadlineSeconds, &out.StartingDeadlineSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodSelector != nil {
+		in, out := &in.PodSelector, &out.PodSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodStatuses != nil {
+		in, out := &in.PodStatuses, &out.PodStatuses
+		*out = make([]corev1.PodPhase, len(*in))
+		copy(*out, *in)
+	}
+	if in.PodConditions != nil {
+		in, out := &in.PodConditions, &out.PodConditions
+		*out = make([]PodConditionMatch, len(*in))
+		copy(*out, *in)
+	}
+	if in.Match != nil {
+		in, out := &in.Match, &out.Match
+		*out = new(MatchCriteria)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StuckOnVolumeClaim != nil {
+		in, out := &in.StuckOnVolumeClaim, &out.StuckOnVolumeClaim
+		*out = new(VolumeClaimCriteria)
+		**out = **in
+	}
+	if in.StuckOnSidecar != nil {
+		in, out := &in.StuckOnSidecar, &out.StuckOnSidecar
+		*out = new(SidecarCriteria)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Orphaned != nil {
+		in, out := &in.Orphaned, &out.Orphaned
+		*out = new(OrphanedCriteria)
+		**out = **in
+	}
+	if in.ExternalMatcher != nil {
+		in, out := &in.ExternalMatcher, &out.ExternalMatcher
+		*out = new(ExternalMatcher)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeLabelSelector != nil {
+		in, out := &in.NodeLabelSelector, &out.NodeLabelSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaintenanceNodeSelector != nil {
+		in, out := &in.MaintenanceNodeSelector, &out.MaintenanceNodeSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeConditions != nil {
+		in, out := &in.NodeConditions, &out.NodeConditions
+		*out = make([]NodeConditionMatch, len(*in))
+		copy(*out, *in)
+	}
+	if in.NodeTaints != nil {
+		in, out := &in.NodeTaints, &out.NodeTaints
+		*out = make([]TaintMatch, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxAge != nil {
+		in, out := &in.MaxAge, &out.MaxAge
+		*out = new(Duration)
+		**out = **in
+	}
+	if in.MaxAgeByPhase != nil {
+		in, out := &in.MaxAgeByPhase, &out.MaxAgeByPhase
+		*out = make([]PhaseMaxAge, len(*in))
+		copy(*out, *in)
+	}
+	if in.KeepNewest != nil {
+		in, out := &in.KeepNewest, &out.KeepNewest
+		*out = new(KeepNewest)
+		**out = **in
+	}
+	if in.AlertThreshold != nil {
+		in, out := &in.AlertThreshold, &out.AlertThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	if in.WarnBefore != nil {
+		in, out := &in.WarnBefore, &out.WarnBefore
+		*out = new(Duration)
+		**out = **in
+	}
+	if in.Logging != nil {
+		in, out := &in.Logging, &out.Logging
+		*out = new(PolicyLogging)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RunHistoryLimit != nil {
+		in, out := &in.RunHistoryLimit, &out.RunHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Archive != nil {
+		in, out := &in.Archive, &out.Archive
+		*out = new(ArchiveSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NamespaceTTL != nil {
+		in, out := &in.NamespaceTTL, &out.NamespaceTTL
+		*out = new(NamespaceTTL)
+		**out = **in
+	}
+	if in.Hooks != nil {
+		in, out := &in.Hooks, &out.Hooks
+		*out = new(RunHooks)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClusterRefs != nil {
+		in, out := &in.ClusterRefs, &out.ClusterRefs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.GracePeriodSeconds != nil {
+		in, out := &in.GracePeriodSeconds, &out.GracePeriodSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.RateLimit != nil {
+		in, out := &in.RateLimit, &out.RateLimit
+		*out = new(RateLimit)
+		**out = **in
+	}
+	if in.Notifications != nil {
+		in, out := &in.Notifications, &out.Notifications
+		*out = make([]NotificationEndpoint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *PodCleanupPolicySpec) DeepCopy() *PodCleanupPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PodCleanupPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *PodCleanupPolicyStatus) DeepCopyInto(out *PodCleanupPolicyStatus) {
+	*out = *in
+	if in.LastRunTime != nil {
+		in, out := &in.LastRunTime, &out.LastRunTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastScheduleTime != nil {
+		in, out := &in.LastScheduleTime, &out.LastScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextRunTime != nil {
+		in, out := &in.NextRunTime, &out.NextRunTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextRetryTime != nil {
+		in, out := &in.NextRetryTime, &out.NextRetryTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastManualRunTime != nil {
+		in, out := &in.LastManualRunTime, &out.LastManualRunTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]ClusterRunStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastPreview != nil {
+		in, out := &in.LastPreview, &out.LastPreview
+		*out = new(PolicyPreview)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastDryRunDiff != nil {
+		in, out := &in.LastDryRunDiff, &out.LastDryRunDiff
+		*out = new(CandidateDiff)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CurrentRun != nil {
+		in, out := &in.CurrentRun, &out.CurrentRun
+		*out = new(RunCheckpoint)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *PodCleanupPolicyStatus) DeepCopy() *PodCleanupPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PodCleanupPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *PodConditionMatch) DeepCopyInto(out *PodConditionMatch) {
+	*out = *in
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *PodConditionMatch) DeepCopy() *PodConditionMatch {
+	if in == nil {
+		return nil
+	}
+	out := new(PodConditionMatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *PodDecision) DeepCopyInto(out *PodDecision) {
+	*out = *in
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *PodDecision) DeepCopy() *PodDecision {
+	if in == nil {
+		return nil
+	}
+	out := new(PodDecision)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *PodRetentionPolicy) DeepCopyInto(out *PodRetentionPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
 }
 
 // DeepCopy creates a new object of the same type and copies all fields from this object.
-func (in *PodCleanupPolicy) DeepCopy() *PodCleanupPolicy {
+func (in *PodRetentionPolicy) DeepCopy() *PodRetentionPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(PodCleanupPolicy)
+	out := new(PodRetentionPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject implements the runtime.Object interface.
-func (in *PodCleanupPolicy) DeepCopyObject() runtime.Object {
+func (in *PodRetentionPolicy) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -38,13 +2017,13 @@ func (in *PodCleanupPolicy) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
-func (in *PodCleanupPolicyList) DeepCopyInto(out *PodCleanupPolicyList) {
+func (in *PodRetentionPolicyList) DeepCopyInto(out *PodRetentionPolicyList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]PodCleanupPolicy, len(*in))
+		*out = make([]PodRetentionPolicy, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -52,17 +2031,17 @@ func (in *PodCleanupPolicyList) DeepCopyInto(out *PodCleanupPolicyList) {
 }
 
 // DeepCopy creates a new object of the same type and copies all fields from this object.
-func (in *PodCleanupPolicyList) DeepCopy() *PodCleanupPolicyList {
+func (in *PodRetentionPolicyList) DeepCopy() *PodRetentionPolicyList {
 	if in == nil {
 		return nil
 	}
-	out := new(PodCleanupPolicyList)
+	out := new(PodRetentionPolicyList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject implements the runtime.Object interface.
-func (in *PodCleanupPolicyList) DeepCopyObject() runtime.Object {
+func (in *PodRetentionPolicyList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -70,7 +2049,7 @@ func (in *PodCleanupPolicyList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
-func (in *PodCleanupPolicySpec) DeepCopyInto(out *PodCleanupPolicySpec) {
+func (in *PodRetentionPolicySpec) DeepCopyInto(out *PodRetentionPolicySpec) {
 	*out = *in
 	if in.NamespaceSelector != nil {
 		in, out := &in.NamespaceSelector, &out.NamespaceSelector
@@ -90,37 +2069,328 @@ func (in *PodCleanupPolicySpec) DeepCopyInto(out *PodCleanupPolicySpec) {
 }
 
 // DeepCopy creates a new object of the same type and copies all fields from this object.
-func (in *PodCleanupPolicySpec) DeepCopy() *PodCleanupPolicySpec {
+func (in *PodRetentionPolicySpec) DeepCopy() *PodRetentionPolicySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PodCleanupPolicySpec)
+	out := new(PodRetentionPolicySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
-func (in *PodCleanupPolicyStatus) DeepCopyInto(out *PodCleanupPolicyStatus) {
+func (in *PolicyLogging) DeepCopyInto(out *PolicyLogging) {
 	*out = *in
-	if in.LastRunTime != nil {
-		in, out := &in.LastRunTime, &out.LastRunTime
-		*out = (*in).DeepCopy()
-	}
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
 		}
 	}
+	if in.LabelFields != nil {
+		in, out := &in.LabelFields, &out.LabelFields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy creates a new object of the same type and copies all fields from this object.
-func (in *PodCleanupPolicyStatus) DeepCopy() *PodCleanupPolicyStatus {
+func (in *PolicyLogging) DeepCopy() *PolicyLogging {
 	if in == nil {
 		return nil
 	}
-	out := new(PodCleanupPolicyStatus)
+	out := new(PolicyLogging)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *PolicyPreview) DeepCopyInto(out *PolicyPreview) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	if in.Candidates != nil {
+		in, out := &in.Candidates, &out.Candidates
+		*out = make([]PreviewCandidate, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *PolicyPreview) DeepCopy() *PolicyPreview {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyPreview)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *PreviewCandidate) DeepCopyInto(out *PreviewCandidate) {
+	*out = *in
+	out.Age = in.Age
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *PreviewCandidate) DeepCopy() *PreviewCandidate {
+	if in == nil {
+		return nil
+	}
+	out := new(PreviewCandidate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *RateLimit) DeepCopyInto(out *RateLimit) {
+	*out = *in
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *RateLimit) DeepCopy() *RateLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(RateLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *RunCheckpoint) DeepCopyInto(out *RunCheckpoint) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *RunCheckpoint) DeepCopy() *RunCheckpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(RunCheckpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *RunHooks) DeepCopyInto(out *RunHooks) {
+	*out = *in
+	if in.PreRun != nil {
+		in, out := &in.PreRun, &out.PreRun
+		*out = new(HookJob)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PostRun != nil {
+		in, out := &in.PostRun, &out.PostRun
+		*out = new(HookJob)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *RunHooks) DeepCopy() *RunHooks {
+	if in == nil {
+		return nil
+	}
+	out := new(RunHooks)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *RunReports) DeepCopyInto(out *RunReports) {
+	*out = *in
+	if in.HistoryLimit != nil {
+		in, out := &in.HistoryLimit, &out.HistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *RunReports) DeepCopy() *RunReports {
+	if in == nil {
+		return nil
+	}
+	out := new(RunReports)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *SecretKeyRef) DeepCopyInto(out *SecretKeyRef) {
+	*out = *in
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *SecretKeyRef) DeepCopy() *SecretKeyRef {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretKeyRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *ServiceMesh) DeepCopyInto(out *ServiceMesh) {
+	*out = *in
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.GracePeriodSeconds != nil {
+		in, out := &in.GracePeriodSeconds, &out.GracePeriodSeconds
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *ServiceMesh) DeepCopy() *ServiceMesh {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceMesh)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *SidecarCriteria) DeepCopyInto(out *SidecarCriteria) {
+	*out = *in
+	if in.Sidecars != nil {
+		in, out := &in.Sidecars, &out.Sidecars
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.QuitCommand != nil {
+		in, out := &in.QuitCommand, &out.QuitCommand
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *SidecarCriteria) DeepCopy() *SidecarCriteria {
+	if in == nil {
+		return nil
+	}
+	out := new(SidecarCriteria)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *SplunkOutput) DeepCopyInto(out *SplunkOutput) {
+	*out = *in
+	out.TokenSecretRef = in.TokenSecretRef
+	if in.BatchSize != nil {
+		in, out := &in.BatchSize, &out.BatchSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CASecretRef != nil {
+		in, out := &in.CASecretRef, &out.CASecretRef
+		*out = new(SecretKeyRef)
+		**out = **in
+	}
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *SplunkOutput) DeepCopy() *SplunkOutput {
+	if in == nil {
+		return nil
+	}
+	out := new(SplunkOutput)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *SyslogOutput) DeepCopyInto(out *SyslogOutput) {
+	*out = *in
+	if in.Facility != nil {
+		in, out := &in.Facility, &out.Facility
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CASecretRef != nil {
+		in, out := &in.CASecretRef, &out.CASecretRef
+		*out = new(SecretKeyRef)
+		**out = **in
+	}
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *SyslogOutput) DeepCopy() *SyslogOutput {
+	if in == nil {
+		return nil
+	}
+	out := new(SyslogOutput)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *TaintMatch) DeepCopyInto(out *TaintMatch) {
+	*out = *in
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *TaintMatch) DeepCopy() *TaintMatch {
+	if in == nil {
+		return nil
+	}
+	out := new(TaintMatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *TenantQuota) DeepCopyInto(out *TenantQuota) {
+	*out = *in
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *TenantQuota) DeepCopy() *TenantQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *VolumeClaimCriteria) DeepCopyInto(out *VolumeClaimCriteria) {
+	*out = *in
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *VolumeClaimCriteria) DeepCopy() *VolumeClaimCriteria {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeClaimCriteria)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type that is provided as a pointer.
+func (in *WebhookSigning) DeepCopyInto(out *WebhookSigning) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+}
+
+// DeepCopy creates a new object of the same type and copies all fields from this object.
+func (in *WebhookSigning) DeepCopy() *WebhookSigning {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookSigning)
 	in.DeepCopyInto(out)
 	return out
 }
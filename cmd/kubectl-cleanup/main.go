@@ -0,0 +1,359 @@
+// Command kubectl-cleanup is a kubectl plugin for inspecting and driving
+// PodCleanupPolicies through the operator's custom resources.
+//
+//	kubectl cleanup preview [-o json|yaml|table] [-sort-by key] <policy>
+//	                                    Show the pods a run would delete
+//	kubectl cleanup explain <policy>    Show why each pod would be deleted or kept
+//	kubectl cleanup run [-w] <policy>   Trigger a run now
+//	kubectl cleanup runs <policy>       Show recent runs
+//	kubectl cleanup watch <policy>      Tail the progress of the latest run
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
+	// to ensure that exec-based credentials work.
+	_ "k8s.io/client-go/plugin/pkg/client/auth"
+
+	cleanupv1 "github.com/aravindavvaru/pod-cleanup-operator/api/v1"
+	"github.com/aravindavvaru/pod-cleanup-operator/internal/controller"
+	"github.com/aravindavvaru/pod-cleanup-operator/internal/printer"
+)
+
+// pollInterval is how often run progress is polled while tailing.
+const pollInterval = 2 * time.Second
+
+var scheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cleanupv1.AddToScheme(scheme))
+}
+
+func usage() {
+	fmt.Fprintf(flag.CommandLine.Output(), `Usage: kubectl cleanup [flags] <command> <policy>
+
+Commands:
+  preview [-o json|yaml|table] [-sort-by namespace|pod|phase|age|rule] <policy>
+                      Show the pods a run of the policy would delete
+  explain <policy>    Show why a run of the policy would delete or keep each pod
+  run [-w] <policy>   Trigger a run of the policy now; -w tails its progress
+  runs <policy>       Show recent runs of the policy
+  watch <policy>      Tail the progress of the latest run of the policy
+
+Flags:
+`)
+	flag.PrintDefaults()
+}
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+	if flag.NArg() < 1 {
+		usage()
+		os.Exit(2)
+	}
+
+	// The client logs through controller-runtime.
+	ctrl.SetLogger(logr.Discard())
+
+	cfg, err := ctrl.GetConfig()
+	if err != nil {
+		fatal(err)
+	}
+	c, err := client.New(cfg, client.Options{Scheme: scheme})
+	if err != nil {
+		fatal(err)
+	}
+	p := &plugin{client: c}
+
+	ctx := ctrl.SetupSignalHandler()
+	command, args := flag.Arg(0), flag.Args()[1:]
+	switch command {
+	case "preview":
+		err = p.preview(ctx, args)
+	case "explain":
+		err = p.explain(ctx, args)
+	case "run":
+		err = p.run(ctx, args)
+	case "runs":
+		err = p.runs(ctx, args)
+	case "watch":
+		err = p.watch(ctx, args)
+	default:
+		err = fmt.Errorf("unknown command %q", command)
+	}
+	if err != nil {
+		fatal(err)
+	}
+}
+
+func fatal(err error) {
+	fmt.Fprintln(os.Stderr, "error:", err)
+	os.Exit(1)
+}
+
+// plugin implements the kubectl-cleanup subcommands.
+type plugin struct {
+	client client.Client
+}
+
+// policyArg parses the subcommand flags and returns the policy named by the single
+// remaining argument.
+func (p *plugin) policyArg(ctx context.Context, fs *flag.FlagSet, args []string) (*cleanupv1.PodCleanupPolicy, error) {
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if fs.NArg() != 1 {
+		return nil, fmt.Errorf("%s requires exactly one policy name", fs.Name())
+	}
+	policy := &cleanupv1.PodCleanupPolicy{}
+	if err := p.client.Get(ctx, client.ObjectKey{Name: fs.Arg(0)}, policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// preview prints the pods a run of the policy would delete.
+func (p *plugin) preview(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("preview", flag.ExitOnError)
+	var opts printer.Options
+	opts.Bind(fs)
+	policy, err := p.policyArg(ctx, fs, args)
+	if err != nil {
+		return err
+	}
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+	request, err := p.previewRequest(ctx, policy, false)
+	if err != nil {
+		return err
+	}
+	candidates := make([]controller.Candidate, 0, len(request.Status.Candidates))
+	for _, c := range request.Status.Candidates {
+		candidates = append(candidates, controller.Candidate{
+			Namespace: c.Namespace,
+			Name:      c.Name,
+			Phase:     c.Phase,
+			Age:       c.Age.Duration,
+			Rule:      c.Rule,
+		})
+	}
+	opts.Total = int(request.Status.PodsDeleted)
+	return opts.Print(os.Stdout, policy.Name, candidates)
+}
+
+// explain prints why a run of the policy would delete or keep each pod.
+func (p *plugin) explain(ctx context.Context, args []string) error {
+	policy, err := p.policyArg(ctx, flag.NewFlagSet("explain", flag.ExitOnError), args)
+	if err != nil {
+		return err
+	}
+	request, err := p.previewRequest(ctx, policy, true)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "NAMESPACE\tPOD\tSELECTED\tREASON\tMESSAGE")
+	for _, d := range request.Status.Decisions {
+		pod := d.Pod
+		if pod == "" {
+			pod = "*"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%t\t%s\t%s\n", d.Namespace, pod, d.Selected, d.Reason, d.Message)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if omitted := request.Status.DecisionsOmitted; omitted > 0 {
+		fmt.Printf("\n%d more decision(s) omitted\n", omitted)
+	}
+	return nil
+}
+
+// previewRequest has the operator preview the policy through a CleanupRequest, so
+// the preview honors its feature gates and OperatorConfig like any run, and returns
+// the finished request. The request is deleted once read.
+func (p *plugin) previewRequest(ctx context.Context, policy *cleanupv1.PodCleanupPolicy, explain bool) (*cleanupv1.CleanupRequest, error) {
+	request := &cleanupv1.CleanupRequest{
+		ObjectMeta: metav1.ObjectMeta{GenerateName: policy.Name + "-preview-"},
+		Spec:       cleanupv1.CleanupRequestSpec{PolicyName: policy.Name, Preview: true, Explain: explain},
+	}
+	if err := p.client.Create(ctx, request); err != nil {
+		return nil, err
+	}
+	defer func() {
+		// Not ctx, which is done when the preview was interrupted.
+		_ = p.client.Delete(context.Background(), request)
+	}()
+
+	for {
+		switch request.Status.Phase {
+		case cleanupv1.RunPhaseSucceeded:
+			return request, nil
+		case cleanupv1.RunPhaseFailed:
+			return nil, fmt.Errorf("preview of policy %s failed: %s", policy.Name, request.Status.Message)
+		}
+		if err := sleep(ctx); err != nil {
+			return nil, err
+		}
+		if err := p.client.Get(ctx, client.ObjectKeyFromObject(request), request); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// run triggers an immediate run through the run-now annotation.
+func (p *plugin) run(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("run", flag.ExitOnError)
+	follow := fs.Bool("w", false, "Wait for the run to start and tail its progress.")
+	policy, err := p.policyArg(ctx, fs, args)
+	if err != nil {
+		return err
+	}
+
+	requested := time.Now().Truncate(time.Second)
+	patch := client.MergeFrom(policy.DeepCopy())
+	if policy.Annotations == nil {
+		policy.Annotations = map[string]string{}
+	}
+	policy.Annotations[cleanupv1.AnnotationRunNow] = "true"
+	if err := p.client.Patch(ctx, policy, patch); err != nil {
+		return err
+	}
+	fmt.Printf("Run of policy %s requested\n", policy.Name)
+	if !*follow {
+		return nil
+	}
+
+	for {
+		record, err := p.latestRun(ctx, policy.Name)
+		if err != nil {
+			return err
+		}
+		if record != nil && !record.CreationTimestamp.Time.Before(requested) &&
+			record.Spec.Trigger == cleanupv1.TriggerManual {
+			return p.tail(ctx, record)
+		}
+		if err := sleep(ctx); err != nil {
+			return err
+		}
+	}
+}
+
+// runs prints the recorded runs of the policy, newest first.
+func (p *plugin) runs(ctx context.Context, args []string) error {
+	policy, err := p.policyArg(ctx, flag.NewFlagSet("runs", flag.ExitOnError), args)
+	if err != nil {
+		return err
+	}
+	records, err := p.listRuns(ctx, policy.Name)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "NAME\tTRIGGER\tDRYRUN\tPHASE\tPODS\tSTARTED\tDURATION\tMESSAGE")
+	for _, record := range records {
+		started, duration := "-", "-"
+		if s := record.Status.StartTime; s != nil {
+			started = s.Format(time.RFC3339)
+			if c := record.Status.CompletionTime; c != nil {
+				duration = c.Sub(s.Time).Round(time.Second).String()
+			}
+		}
+		fmt.Fprintf(w, "%s\t%s\t%t\t%s\t%d\t%s\t%s\t%s\n", record.Name, record.Spec.Trigger,
+			record.Spec.DryRun, record.Status.Phase, record.Status.PodsDeleted, started, duration,
+			record.Status.Message)
+	}
+	return w.Flush()
+}
+
+// watch tails the progress of the latest run of the policy.
+func (p *plugin) watch(ctx context.Context, args []string) error {
+	policy, err := p.policyArg(ctx, flag.NewFlagSet("watch", flag.ExitOnError), args)
+	if err != nil {
+		return err
+	}
+	record, err := p.latestRun(ctx, policy.Name)
+	if err != nil {
+		return err
+	}
+	if record == nil {
+		return fmt.Errorf("policy %s has no recorded runs", policy.Name)
+	}
+	return p.tail(ctx, record)
+}
+
+// tail prints progress of the run until it finishes.
+func (p *plugin) tail(ctx context.Context, record *cleanupv1.CleanupRun) error {
+	var last string
+	for {
+		status := record.Status
+		line := fmt.Sprintf("%s: %s, namespaces %d/%d, %d pod(s)", record.Name, status.Phase,
+			status.NamespacesProcessed, status.NamespacesTotal, status.PodsDeleted)
+		if line != last {
+			fmt.Println(line)
+			last = line
+		}
+		if status.Phase == cleanupv1.RunPhaseSucceeded || status.Phase == cleanupv1.RunPhaseFailed {
+			fmt.Println(status.Message)
+			return nil
+		}
+
+		if err := sleep(ctx); err != nil {
+			return err
+		}
+		if err := p.client.Get(ctx, client.ObjectKeyFromObject(record), record); err != nil {
+			return err
+		}
+	}
+}
+
+// listRuns returns the recorded runs of the policy, newest first.
+func (p *plugin) listRuns(ctx context.Context, policyName string) ([]cleanupv1.CleanupRun, error) {
+	runList := &cleanupv1.CleanupRunList{}
+	if err := p.client.List(ctx, runList, client.MatchingLabels{cleanupv1.LabelPolicy: policyName}); err != nil {
+		return nil, err
+	}
+	sort.Slice(runList.Items, func(i, j int) bool {
+		return runList.Items[j].CreationTimestamp.Before(&runList.Items[i].CreationTimestamp)
+	})
+	return runList.Items, nil
+}
+
+// latestRun returns the most recent run of the policy, or nil if there is none.
+func (p *plugin) latestRun(ctx context.Context, policyName string) (*cleanupv1.CleanupRun, error) {
+	records, err := p.listRuns(ctx, policyName)
+	if err != nil || len(records) == 0 {
+		return nil, err
+	}
+	return &records[0], nil
+}
+
+// sleep waits one poll interval, returning early if ctx is cancelled.
+func sleep(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(pollInterval):
+		return nil
+	}
+}
@@ -2,24 +2,37 @@ package main
 
 import (
 	"flag"
+	"net/http"
 	"os"
+	"strings"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-based credentials work.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/clock"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 
 	cleanupv1 "github.com/aravindavvaru/pod-cleanup-operator/api/v1"
 	"github.com/aravindavvaru/pod-cleanup-operator/internal/controller"
+	"github.com/aravindavvaru/pod-cleanup-operator/internal/features"
+	"github.com/aravindavvaru/pod-cleanup-operator/internal/report"
 )
 
+// openMetricsPath serves the metrics in the OpenMetrics format, with exemplars.
+const openMetricsPath = "/metrics/openmetrics"
+
 var (
 	scheme   = runtime.NewScheme()
 	setupLog = ctrl.Log.WithName("setup")
@@ -34,6 +47,15 @@ func main() {
 	var metricsAddr string
 	var enableLeaderElection bool
 	var probeAddr string
+	var namespacedPodAccess bool
+	var enableReportAPI bool
+	var metricsCertDir string
+	var operatorNamespace string
+	var forceDryRun bool
+	var podListChunkSize int64
+	var scopePodWatches bool
+	var progressInterval time.Duration
+	var namespaceReportInterval time.Duration
 
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080",
 		"The address the metric endpoint binds to.")
@@ -42,17 +64,91 @@ func main() {
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.BoolVar(&namespacedPodAccess, "namespaced-pod-access", false,
+		"List pods with namespaced API calls instead of a cluster-wide pod cache, so the operator "+
+			"only needs pod permissions in the namespaces it cleans up.")
+	flag.BoolVar(&enableReportAPI, "enable-report-api", false,
+		"Serve read-only JSON summaries of policies, runs, candidates and workload clusters under "+report.PathPrefix+
+			" on the metrics endpoint. Requests are authenticated and authorized against the API server.")
+	flag.StringVar(&metricsCertDir, "metrics-cert-dir", "",
+		"The directory holding tls.crt and tls.key, served by the metrics endpoint when the report API "+
+			"is enabled. Defaults to a self-signed certificate.")
+	flag.StringVar(&operatorNamespace, "operator-namespace", os.Getenv("POD_NAMESPACE"),
+		"The namespace the operator runs in, where run report ConfigMaps are written. "+
+			"Defaults to the POD_NAMESPACE environment variable.")
+	flag.BoolVar(&forceDryRun, "force-dry-run", false,
+		"Force every policy into dry-run mode regardless of its spec, e.g. while onboarding the "+
+			"operator or during a change freeze. Policies report it with a DryRunForced condition.")
+	flag.Int64Var(&podListChunkSize, "pod-list-chunk-size", 500,
+		"The number of pods per list call when pods are read from the API server rather than "+
+			"the cache, which bounds the memory a run needs in large namespaces.")
+	flag.BoolVar(&scopePodWatches, "scope-pod-watches", false,
+		"Cache only the pods of namespaces selected by the policies' namespaceSelectors instead of "+
+			"every pod in the cluster. Pod caches are started and stopped as namespaces gain or lose labels.")
+	flag.DurationVar(&progressInterval, "progress-interval", 5*time.Second,
+		"The minimum time between status writes of a run's progress to its CleanupRun and checkpoint. "+
+			"Progress in between is coalesced into the next write.")
+	flag.DurationVar(&namespaceReportInterval, "namespace-report-interval", 0,
+		"How often the pods removed in a namespace are added to the CleanupReport in that namespace, "+
+			"e.g. 10m. Zero disables CleanupReports.")
+	flag.Func("feature-gates",
+		"A set of key=value pairs that describe feature gates for alpha/experimental features. "+
+			"Options are: "+strings.Join(features.Gate.KnownFeatures(), ", "), features.Gate.Set)
 
 	opts := zap.Options{Development: true}
 	opts.BindFlags(flag.CommandLine)
 	flag.Parse()
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
+	setupLog.Info("Feature gates configured", "featureGates", features.Gate.String())
+	if features.Enabled(features.EventDrivenMode) && namespacedPodAccess {
+		setupLog.Info("EventDrivenMode has no effect with --namespaced-pod-access, as pods are not watched")
+	}
+
+	var clientOpts client.Options
+	if namespacedPodAccess || scopePodWatches {
+		// Read pods straight from the API server, or from the per-namespace
+		// caches of scoped pod watches, so no cluster-wide pod informer (and,
+		// with namespaced pod access, its list/watch permission) is required.
+		clientOpts.Cache = &client.CacheOptions{DisableFor: []client.Object{&corev1.Pod{}}}
+	}
+
+	cfg := ctrl.GetConfigOrDie()
+	backpressure := controller.NewBackpressure(clock.RealClock{})
+	cfg.Wrap(backpressure.WrapTransport)
 
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
+	// The report API is served by the metrics server, which is configured before the
+	// manager exists; its client and previewer are filled in once the manager is built.
+	reportAPI := &report.Handler{}
+	var extraHandlers map[string]http.Handler
+	if enableReportAPI {
+		reviewClient, err := client.New(cfg, client.Options{Scheme: scheme})
+		if err != nil {
+			setupLog.Error(err, "Unable to create report API client")
+			os.Exit(1)
+		}
+		extraHandlers = map[string]http.Handler{report.PathPrefix: report.WithAuth(reviewClient, reportAPI)}
+	}
+	if extraHandlers == nil {
+		extraHandlers = make(map[string]http.Handler)
+	}
+	// The built-in /metrics endpoint only serves the text format, which has no
+	// exemplars; this one negotiates OpenMetrics, exposing the run IDs of samples.
+	extraHandlers[openMetricsPath] = promhttp.HandlerFor(metrics.Registry, promhttp.HandlerOpts{
+		ErrorHandling:     promhttp.HTTPErrorOnError,
+		EnableOpenMetrics: true,
+	})
+
+	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
 		Scheme: scheme,
+		Client: clientOpts,
 		Metrics: metricsserver.Options{
 			BindAddress: metricsAddr,
+			// The report API receives bearer tokens, which must not cross the network
+			// in plain text.
+			SecureServing: enableReportAPI,
+			CertDir:       metricsCertDir,
+			ExtraHandlers: extraHandlers,
 		},
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
@@ -63,13 +159,65 @@ func main() {
 		os.Exit(1)
 	}
 
-	if err = (&controller.PodCleanupPolicyReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
-	}).SetupWithManager(mgr); err != nil {
+	policyReconciler := &controller.PodCleanupPolicyReconciler{
+		Client:                  mgr.GetClient(),
+		Scheme:                  mgr.GetScheme(),
+		RestConfig:              mgr.GetConfig(),
+		Recorder:                mgr.GetEventRecorderFor("podcleanuppolicy-controller"),
+		APIReader:               mgr.GetAPIReader(),
+		OperatorNamespace:       operatorNamespace,
+		ForceDryRun:             forceDryRun,
+		Backpressure:            backpressure,
+		CachedPods:              !namespacedPodAccess && !scopePodWatches,
+		ScopePodWatches:         scopePodWatches,
+		ProgressInterval:        progressInterval,
+		PodListChunkSize:        podListChunkSize,
+		NamespaceReportInterval: namespaceReportInterval,
+	}
+	if err = policyReconciler.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "Unable to create controller", "controller", "PodCleanupPolicy")
 		os.Exit(1)
 	}
+	if err = (&controller.CleanupRequestReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("cleanuprequest-controller"),
+		Policies: policyReconciler,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "Unable to create controller", "controller", "CleanupRequest")
+		os.Exit(1)
+	}
+	if err = (&controller.CleanupSimulationReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("cleanupsimulation-controller"),
+		Policies: policyReconciler,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "Unable to create controller", "controller", "CleanupSimulation")
+		os.Exit(1)
+	}
+	if err = (&controller.ClusterCleanupBudgetReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Policies: policyReconciler,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "Unable to create controller", "controller", "ClusterCleanupBudget")
+		os.Exit(1)
+	}
+	if features.Enabled(features.NodeDrain) {
+		if err = (&controller.NodeDrainCleanupReconciler{
+			Client:    mgr.GetClient(),
+			Scheme:    mgr.GetScheme(),
+			APIReader: mgr.GetAPIReader(),
+			Recorder:  mgr.GetEventRecorderFor("nodedraincleanup-controller"),
+			Policies:  policyReconciler,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "Unable to create controller", "controller", "NodeDrainCleanup")
+			os.Exit(1)
+		}
+	}
+	reportAPI.Client = mgr.GetClient()
+	reportAPI.Previewer = policyReconciler
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "Unable to set up health check")
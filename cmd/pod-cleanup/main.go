@@ -0,0 +1,60 @@
+// Command pod-cleanup works with pod-cleanup-operator resources before they are applied.
+//
+//	pod-cleanup validate -f <file|dir|->...   Validate resources before applying them
+//	pod-cleanup preview -f <file|->           Show the pods a policy would delete before applying it
+//	pod-cleanup run -f <file|->               Run a policy once, e.g. from a Job
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+)
+
+func usage() {
+	fmt.Fprintf(flag.CommandLine.Output(), `Usage: pod-cleanup <command> [flags]
+
+Commands:
+  validate -f <file|dir|->   Validate cleanup resources the way the operator does
+  preview -f <file|-> [-o json|yaml|table] [-sort-by namespace|pod|phase|age|rule]
+                             Show the pods a PodCleanupPolicy would delete in the
+                             cluster of the current kubeconfig context, without
+                             applying it
+  run -f <file|-> [-dry-run] [-pushgateway url] [-job name]
+                             Run a PodCleanupPolicy once against the cluster of
+                             the current kubeconfig context (or of the pod it runs
+                             in), optionally pushing its metrics to a Pushgateway
+`)
+}
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+	if flag.NArg() < 1 {
+		usage()
+		os.Exit(2)
+	}
+
+	command, args := flag.Arg(0), flag.Args()[1:]
+	var (
+		ok  bool
+		err error
+	)
+	switch command {
+	case "validate":
+		ok, err = validate(args)
+	case "preview":
+		ok, err = true, preview(args)
+	case "run":
+		ok, err = true, run(args)
+	default:
+		err = fmt.Errorf("unknown command %q", command)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(2)
+	}
+	if !ok {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
+	// to ensure that exec-based credentials work.
+	_ "k8s.io/client-go/plugin/pkg/client/auth"
+
+	cleanupv1 "github.com/aravindavvaru/pod-cleanup-operator/api/v1"
+	"github.com/aravindavvaru/pod-cleanup-operator/internal/controller"
+	"github.com/aravindavvaru/pod-cleanup-operator/internal/printer"
+)
+
+// preview prints the pods the PodCleanupPolicy in a manifest would delete in the
+// cluster of the current kubeconfig context, without applying the policy.
+func preview(args []string) error {
+	fset := flag.NewFlagSet("preview", flag.ExitOnError)
+	file := fset.String("f", "", "File or - (stdin) containing the PodCleanupPolicy manifest.")
+	var opts printer.Options
+	opts.Bind(fset)
+	if err := fset.Parse(args); err != nil {
+		return err
+	}
+	if *file == "" {
+		return errors.New("preview requires -f")
+	}
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+	policy, err := readPolicy(*file)
+	if err != nil {
+		return err
+	}
+	if errs := policy.Validate(); len(errs) > 0 {
+		return fmt.Errorf("PodCleanupPolicy %s is invalid: %w", policy.Name, errs.ToAggregate())
+	}
+
+	// The controller logic used by preview logs through controller-runtime.
+	ctrl.SetLogger(logr.Discard())
+	reconciler, err := newReconciler()
+	if err != nil {
+		return err
+	}
+	candidates, err := reconciler.Preview(context.Background(), policy)
+	if err != nil {
+		return err
+	}
+	return opts.Print(os.Stdout, policy.Name, candidates)
+}
+
+// newReconciler returns a reconciler running policies outside the operator, against
+// the cluster of the current kubeconfig context or, in a pod, its own cluster.
+func newReconciler() (*controller.PodCleanupPolicyReconciler, error) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cleanupv1.AddToScheme(scheme))
+	cfg, err := ctrl.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+	c, err := client.New(cfg, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, err
+	}
+	return &controller.PodCleanupPolicyReconciler{
+		Client:     c,
+		Scheme:     scheme,
+		RestConfig: cfg,
+		APIReader:  c,
+	}, nil
+}
+
+// readPolicy decodes the single PodCleanupPolicy in a manifest file. Documents of
+// other kinds are skipped.
+func readPolicy(path string) (*cleanupv1.PodCleanupPolicy, error) {
+	var in io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	var policy *cleanupv1.PodCleanupPolicy
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(in))
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		if len(strings.TrimSpace(string(doc))) == 0 {
+			continue
+		}
+		var meta metav1.TypeMeta
+		if err := yaml.Unmarshal(doc, &meta); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		if meta.Kind != "PodCleanupPolicy" {
+			continue
+		}
+		if policy != nil {
+			return nil, fmt.Errorf("%s: contains more than one PodCleanupPolicy", path)
+		}
+		policy = &cleanupv1.PodCleanupPolicy{}
+		if err := yaml.UnmarshalStrict(doc, policy); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	if policy == nil {
+		return nil, fmt.Errorf("%s: contains no PodCleanupPolicy", path)
+	}
+	return policy, nil
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/push"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// run runs the PodCleanupPolicy in a manifest once against the cluster of the current
+// kubeconfig context, or the cluster of the pod it runs in, e.g. from a Job.
+func run(args []string) error {
+	fset := flag.NewFlagSet("run", flag.ExitOnError)
+	file := fset.String("f", "", "File or - (stdin) containing the PodCleanupPolicy manifest.")
+	dryRun := fset.Bool("dry-run", false, "Only report the pods the policy would delete.")
+	pushgateway := fset.String("pushgateway", "",
+		"URL of a Prometheus Pushgateway to push the run's metrics to, e.g. http://pushgateway:9091.")
+	job := fset.String("job", "", "Job of the metrics pushed to the Pushgateway. Defaults to pod-cleanup-<policy>.")
+	opts := zap.Options{}
+	opts.BindFlags(fset)
+	if err := fset.Parse(args); err != nil {
+		return err
+	}
+	if *file == "" {
+		return errors.New("run requires -f")
+	}
+	policy, err := readPolicy(*file)
+	if err != nil {
+		return err
+	}
+	if errs := policy.Validate(); len(errs) > 0 {
+		return fmt.Errorf("PodCleanupPolicy %s is invalid: %w", policy.Name, errs.ToAggregate())
+	}
+
+	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
+	reconciler, err := newReconciler()
+	if err != nil {
+		return err
+	}
+	deleted, runErr := reconciler.RunOnce(ctrl.SetupSignalHandler(), policy, *dryRun)
+	verb := "deleted"
+	if *dryRun || policy.Spec.DryRun {
+		verb = "would be deleted"
+	}
+	fmt.Printf("PodCleanupPolicy %s: %d pod(s) %s\n", policy.Name, deleted, verb)
+
+	if *pushgateway != "" {
+		if *job == "" {
+			*job = "pod-cleanup-" + policy.Name
+		}
+		if err := pushMetrics(*pushgateway, *job, policy.Name, deleted, runErr); err != nil {
+			err = fmt.Errorf("pushing metrics: %w", err)
+			if runErr == nil {
+				return err
+			}
+			fmt.Fprintln(os.Stderr, "error:", err)
+		}
+	}
+	return runErr
+}
+
+// pushMetrics pushes the operator's metrics, as collected during the run, to the
+// job's group of the Pushgateway, together with the outcome of the run. Metrics are
+// added to the group rather than replacing it, so the last success of earlier runs
+// survives a failed run.
+func pushMetrics(url, job, policyName string, deleted int, runErr error) error {
+	registry := prometheus.NewRegistry()
+	labels := prometheus.Labels{"policy": policyName}
+	podsDeleted := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name:        "podcleanup_oneshot_pods_deleted",
+		Help:        "Pods deleted or evicted (or, in dry runs, that would be) by the last one-shot run.",
+		ConstLabels: labels,
+	})
+	failed := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name:        "podcleanup_oneshot_failed",
+		Help:        "1 if the last one-shot run failed, 0 otherwise.",
+		ConstLabels: labels,
+	})
+	registry.MustRegister(podsDeleted, failed)
+	podsDeleted.Set(float64(deleted))
+	if runErr != nil {
+		failed.Set(1)
+	} else {
+		lastSuccess := prometheus.NewGauge(prometheus.GaugeOpts{
+			Name:        "podcleanup_oneshot_last_success_timestamp_seconds",
+			Help:        "Unix time at which the last successful one-shot run finished.",
+			ConstLabels: labels,
+		})
+		registry.MustRegister(lastSuccess)
+		lastSuccess.SetToCurrentTime()
+	}
+
+	return push.New(url, job).Gatherer(prometheus.Gatherers{metrics.Registry, registry}).Add()
+}
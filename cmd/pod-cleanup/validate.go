@@ -0,0 +1,184 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+
+	cleanupv1 "github.com/aravindavvaru/pod-cleanup-operator/api/v1"
+)
+
+// validatable is a cleanup resource that can check itself.
+type validatable interface {
+	Validate() field.ErrorList
+}
+
+// newObject returns an empty object of a cleanup kind, or nil for other kinds.
+func newObject(kind string) validatable {
+	switch kind {
+	case "PodCleanupPolicy":
+		return &cleanupv1.PodCleanupPolicy{}
+	case "OperatorConfig":
+		return &cleanupv1.OperatorConfig{}
+	case "ClusterCleanupDefaults":
+		return &cleanupv1.ClusterCleanupDefaults{}
+	case "CleanupRequest":
+		return &cleanupv1.CleanupRequest{}
+	case "CleanupSimulation":
+		return &cleanupv1.CleanupSimulation{}
+	case "PodRetentionPolicy":
+		return &cleanupv1.PodRetentionPolicy{}
+	case "CleanupSchedule":
+		return &cleanupv1.CleanupSchedule{}
+	case "ClusterTarget":
+		return &cleanupv1.ClusterTarget{}
+	case "ClusterCleanupBudget":
+		return &cleanupv1.ClusterCleanupBudget{}
+	case "NodeDrainCleanup":
+		return &cleanupv1.NodeDrainCleanup{}
+	}
+	return nil
+}
+
+// fileList collects repeated -f flags.
+type fileList []string
+
+func (f *fileList) String() string     { return strings.Join(*f, ",") }
+func (f *fileList) Set(v string) error { *f = append(*f, v); return nil }
+
+// validate checks every cleanup resource in the given files and reports whether all
+// of them are valid. Resources of other kinds are skipped.
+func validate(args []string) (bool, error) {
+	fset := flag.NewFlagSet("validate", flag.ExitOnError)
+	var files fileList
+	fset.Var(&files, "f", "File, directory or - (stdin) containing YAML or JSON manifests. May be repeated.")
+	if err := fset.Parse(args); err != nil {
+		return false, err
+	}
+	if len(files) == 0 {
+		return false, errors.New("validate requires at least one -f")
+	}
+
+	valid := true
+	for _, name := range files {
+		paths, err := expand(name)
+		if err != nil {
+			return false, err
+		}
+		for _, path := range paths {
+			ok, err := validateFile(path)
+			if err != nil {
+				return false, err
+			}
+			valid = valid && ok
+		}
+	}
+	return valid, nil
+}
+
+// expand resolves a -f argument to the manifest files it names.
+func expand(name string) ([]string, error) {
+	if name == "-" {
+		return []string{name}, nil
+	}
+	info, err := os.Stat(name)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{name}, nil
+	}
+
+	var paths []string
+	err = filepath.WalkDir(name, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		switch filepath.Ext(path) {
+		case ".yaml", ".yml", ".json":
+			if !d.IsDir() {
+				paths = append(paths, path)
+			}
+		}
+		return nil
+	})
+	return paths, err
+}
+
+// validateFile validates every document in a manifest file.
+func validateFile(path string) (bool, error) {
+	var in io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return false, err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	valid := true
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(in))
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return valid, nil
+		}
+		if err != nil {
+			return false, fmt.Errorf("%s: %w", path, err)
+		}
+		if len(strings.TrimSpace(string(doc))) == 0 {
+			continue
+		}
+		if !validateDocument(path, doc) {
+			valid = false
+		}
+	}
+}
+
+// validateDocument validates a single manifest and prints the result.
+func validateDocument(path string, doc []byte) bool {
+	var meta struct {
+		metav1.TypeMeta   `json:",inline"`
+		metav1.ObjectMeta `json:"metadata,omitempty"`
+	}
+	if err := yaml.Unmarshal(doc, &meta); err != nil {
+		fmt.Printf("%s: %v\n", path, err)
+		return false
+	}
+	obj := newObject(meta.Kind)
+	if obj == nil {
+		return true
+	}
+	id := fmt.Sprintf("%s: %s %s", path, meta.Kind, meta.Name)
+
+	if meta.APIVersion != cleanupv1.GroupVersion.String() {
+		fmt.Printf("%s: apiVersion %q is not %q\n", id, meta.APIVersion, cleanupv1.GroupVersion.String())
+		return false
+	}
+	// Strict decoding reports unknown and duplicate fields, which the API server
+	// would otherwise drop silently.
+	if err := yaml.UnmarshalStrict(doc, obj); err != nil {
+		fmt.Printf("%s: %v\n", id, err)
+		return false
+	}
+	if errs := obj.Validate(); len(errs) > 0 {
+		for _, err := range errs {
+			fmt.Printf("%s: %v\n", id, err)
+		}
+		return false
+	}
+	fmt.Printf("%s: valid\n", id)
+	return true
+}
@@ -0,0 +1,100 @@
+// Package archive stores the manifests of removed pods in an archive backend.
+package archive
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	cleanupv1 "github.com/aravindavvaru/pod-cleanup-operator/api/v1"
+)
+
+const (
+	// ConfigMapKey is the ConfigMap binaryData key holding a chunk.
+	ConfigMapKey = "pods.yaml.gz"
+	// defaultTimeout bounds how long storing a single chunk may take.
+	defaultTimeout = 30 * time.Second
+	// runIDMetadata is the metadata key under which object storage sinks record the
+	// ID of the run that archived an object.
+	runIDMetadata = "run_id"
+)
+
+// Chunk is a part of a run's archive.
+type Chunk struct {
+	// Run is the CleanupRun that removed the pods.
+	Run *cleanupv1.CleanupRun
+	// Index numbers the chunks of a run from 0.
+	Index int
+	// Data is a gzip-compressed multi-document YAML stream of pod manifests. The
+	// concatenated chunks of a run form a valid multi-member gzip stream.
+	Data []byte
+	// Last marks the final chunk of the run, which may be empty.
+	Last bool
+}
+
+// objectName returns the name object storage sinks store a run's archive under:
+// <prefix><policy>/<run>.yaml.gz.
+func (c Chunk) objectName(prefix string) string {
+	return fmt.Sprintf("%s%s/%s.yaml.gz", prefix, c.Run.Spec.PolicyName, c.Run.Name)
+}
+
+// ArchiveSink stores the chunks of one run's archive, in order. A sink is used for
+// a single run.
+type ArchiveSink interface {
+	// Store stores the chunk and returns the location of the archive once the chunk
+	// completes an object, or "" while an object is still being uploaded.
+	Store(ctx context.Context, c Chunk) (string, error)
+}
+
+// ConfigMapSink stores chunks in ConfigMaps owned by the chunk's CleanupRun, so they
+// are garbage-collected along with the run history.
+type ConfigMapSink struct {
+	Client    client.Client
+	Scheme    *runtime.Scheme
+	Namespace string
+}
+
+// Store creates a ConfigMap holding the chunk, since a run's archive may exceed the
+// size of a single ConfigMap, and returns its name.
+func (s *ConfigMapSink) Store(ctx context.Context, c Chunk) (string, error) {
+	if len(c.Data) == 0 {
+		return "", nil
+	}
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-archive-%d", c.Run.Name, c.Index),
+			Namespace: s.Namespace,
+			Labels: map[string]string{
+				cleanupv1.LabelPolicy:     c.Run.Spec.PolicyName,
+				cleanupv1.LabelArchiveRun: c.Run.Name,
+				cleanupv1.LabelRunID:      string(c.Run.Spec.RunID),
+			},
+		},
+		BinaryData: map[string][]byte{ConfigMapKey: c.Data},
+	}
+	if err := controllerutil.SetOwnerReference(c.Run, cm, s.Scheme); err != nil {
+		return "", err
+	}
+	if err := s.Client.Create(ctx, cm); err != nil {
+		return "", fmt.Errorf("creating ConfigMap %s: %w", cm.Name, err)
+	}
+	return cm.Name, nil
+}
+
+// checkResponse returns an error for a failed upload, including the start of the
+// response body, which object stores use to explain the failure.
+func checkResponse(resp *http.Response) error {
+	if resp.StatusCode < 300 {
+		return nil
+	}
+	body := make([]byte, 512)
+	n, _ := resp.Body.Read(body)
+	return fmt.Errorf("unexpected response status %s: %s", resp.Status, body[:n])
+}
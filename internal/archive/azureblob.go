@@ -0,0 +1,96 @@
+package archive
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// azureBlobVersion is the Blob service REST API version requests are made with.
+const azureBlobVersion = "2021-08-06"
+
+// AzureBlobSink stores a run's archive as a single block blob in an Azure Blob
+// Storage container, uploading each chunk as a block and committing the block list
+// with the last one. It is authorized by a shared access signature.
+type AzureBlobSink struct {
+	Account   string
+	Container string
+	Prefix    string
+	// SASToken is a shared access signature with create and write permission on
+	// the container, with or without the leading "?".
+	SASToken string
+	Client   *http.Client
+
+	blockIDs []string
+}
+
+// NewAzureBlobSink returns an AzureBlobSink for the container.
+func NewAzureBlobSink(account, container, prefix, sasToken string) *AzureBlobSink {
+	return &AzureBlobSink{
+		Account:   account,
+		Container: container,
+		Prefix:    prefix,
+		SASToken:  strings.TrimPrefix(sasToken, "?"),
+		Client:    &http.Client{Timeout: defaultTimeout},
+	}
+}
+
+// Store uploads the chunk as a block and, with the last chunk, commits the blob, with
+// the run ID in its run_id metadata, and returns its URL, without the signature.
+func (s *AzureBlobSink) Store(ctx context.Context, c Chunk) (string, error) {
+	blobURL := fmt.Sprintf("https://%s.blob.core.windows.net/%s/%s",
+		s.Account, url.PathEscape(s.Container), (&url.URL{Path: c.objectName(s.Prefix)}).EscapedPath())
+
+	if len(c.Data) > 0 {
+		// Block IDs must all have the same length.
+		id := base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%08d", c.Index)))
+		query := "comp=block&blockid=" + url.QueryEscape(id)
+		if err := s.put(ctx, blobURL, query, nil, c.Data); err != nil {
+			return "", fmt.Errorf("uploading block: %w", err)
+		}
+		s.blockIDs = append(s.blockIDs, id)
+	}
+	if !c.Last || len(s.blockIDs) == 0 {
+		return "", nil
+	}
+
+	blockList, err := xml.Marshal(struct {
+		XMLName xml.Name `xml:"BlockList"`
+		Latest  []string `xml:"Latest"`
+	}{Latest: s.blockIDs})
+	if err != nil {
+		return "", err
+	}
+	header := http.Header{
+		"x-ms-blob-content-type":     {"application/gzip"},
+		"x-ms-meta-" + runIDMetadata: {string(c.Run.Spec.RunID)},
+	}
+	if err := s.put(ctx, blobURL, "comp=blocklist", header, blockList); err != nil {
+		return "", fmt.Errorf("committing block list: %w", err)
+	}
+	return blobURL, nil
+}
+
+// put makes a signed PUT request to the blob with the query and extra headers.
+func (s *AzureBlobSink) put(ctx context.Context, blobURL, query string, header http.Header, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, blobURL+"?"+query+"&"+s.SASToken, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	req.Header.Set("x-ms-version", azureBlobVersion)
+
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return checkResponse(resp)
+}
@@ -0,0 +1,264 @@
+package archive
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	gcsUploadURL = "https://storage.googleapis.com/upload/storage/v1/b/%s/o?uploadType=resumable&name=%s"
+	gcsScope     = "https://www.googleapis.com/auth/devstorage.read_write"
+	// gcsChunkAlign is the granularity of the parts of a resumable upload; every
+	// part but the last must be a multiple of it.
+	gcsChunkAlign = 256 << 10
+	// gcsMetadataTokenURL serves access tokens for the workload's Google service
+	// account on GKE (with Workload Identity) and Compute Engine.
+	gcsMetadataTokenURL = "http://metadata.google.internal/computeMetadata/v1/instance/service-accounts/default/token"
+)
+
+// GCSSink stores a run's archive as a single object in a Google Cloud Storage
+// bucket, streaming the chunks through a resumable upload. It authenticates with a
+// service account key or, without one, as the workload's service account.
+type GCSSink struct {
+	Bucket string
+	Prefix string
+	Client *http.Client
+
+	key *serviceAccountKey
+
+	// session is the URI of the run's resumable upload, offset the number of bytes
+	// uploaded, and pending the data held back to align the next part.
+	session string
+	offset  int
+	pending []byte
+
+	mu      sync.Mutex
+	token   string
+	expires time.Time
+}
+
+// serviceAccountKey holds the fields of a Google service account key file used to
+// obtain access tokens.
+type serviceAccountKey struct {
+	ClientEmail string `json:"client_email"`
+	PrivateKey  string `json:"private_key"`
+	TokenURI    string `json:"token_uri"`
+
+	signer *rsa.PrivateKey
+}
+
+// NewGCSSink returns a GCSSink for the bucket. keyJSON is a service account key file,
+// or empty to use the metadata server.
+func NewGCSSink(bucket, prefix string, keyJSON []byte) (*GCSSink, error) {
+	s := &GCSSink{Bucket: bucket, Prefix: prefix, Client: &http.Client{Timeout: defaultTimeout}}
+	if len(keyJSON) == 0 {
+		return s, nil
+	}
+	key := &serviceAccountKey{}
+	if err := json.Unmarshal(keyJSON, key); err != nil {
+		return nil, fmt.Errorf("decoding service account key: %w", err)
+	}
+	block, _ := pem.Decode([]byte(key.PrivateKey))
+	if block == nil {
+		return nil, fmt.Errorf("service account key has no PEM private key")
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parsing service account private key: %w", err)
+	}
+	var ok bool
+	if key.signer, ok = parsed.(*rsa.PrivateKey); !ok {
+		return nil, fmt.Errorf("service account private key is not an RSA key")
+	}
+	s.key = key
+	return s, nil
+}
+
+// Store uploads the chunk as part of the run's object and, with the last chunk,
+// completes the object and returns its gs:// URL.
+func (s *GCSSink) Store(ctx context.Context, c Chunk) (string, error) {
+	name := c.objectName(s.Prefix)
+	if s.session == "" {
+		if len(c.Data) == 0 {
+			return "", nil
+		}
+		if err := s.startUpload(ctx, name, string(c.Run.Spec.RunID)); err != nil {
+			return "", fmt.Errorf("starting upload: %w", err)
+		}
+	}
+
+	// pending is only updated once the part is uploaded, so a failed chunk can be
+	// stored again.
+	data := append(append([]byte(nil), s.pending...), c.Data...)
+	part := data
+	if !c.Last {
+		part = part[:len(part)/gcsChunkAlign*gcsChunkAlign]
+		if len(part) == 0 {
+			s.pending = data
+			return "", nil
+		}
+	}
+	if err := s.uploadPart(ctx, part, c.Last); err != nil {
+		return "", fmt.Errorf("uploading part: %w", err)
+	}
+	s.offset += len(part)
+	s.pending = data[len(part):]
+	if !c.Last {
+		return "", nil
+	}
+	return fmt.Sprintf("gs://%s/%s", s.Bucket, name), nil
+}
+
+// startUpload starts the resumable upload of the object, which carries the run ID
+// in its run_id metadata.
+func (s *GCSSink) startUpload(ctx context.Context, name, runID string) error {
+	token, err := s.accessToken(ctx)
+	if err != nil {
+		return fmt.Errorf("getting GCS access token: %w", err)
+	}
+	object, err := json.Marshal(map[string]any{"metadata": map[string]string{runIDMetadata: runID}})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		fmt.Sprintf(gcsUploadURL, url.PathEscape(s.Bucket), url.QueryEscape(name)), bytes.NewReader(object))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+	req.Header.Set("X-Upload-Content-Type", "application/gzip")
+
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if err := checkResponse(resp); err != nil {
+		return err
+	}
+	s.session = resp.Header.Get("Location")
+	if s.session == "" {
+		return fmt.Errorf("response has no upload session URI")
+	}
+	return nil
+}
+
+// uploadPart uploads the next part of the object, the last one completing it.
+func (s *GCSSink) uploadPart(ctx context.Context, part []byte, last bool) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.session, bytes.NewReader(part))
+	if err != nil {
+		return err
+	}
+	total := "*"
+	if last {
+		total = strconv.Itoa(s.offset + len(part))
+	}
+	if len(part) == 0 {
+		req.Header.Set("Content-Range", "bytes */"+total)
+	} else {
+		req.Header.Set("Content-Range", fmt.Sprintf("bytes %d-%d/%s", s.offset, s.offset+len(part)-1, total))
+	}
+
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// 308 Resume Incomplete acknowledges a part of an unfinished upload.
+	if !last && resp.StatusCode == http.StatusPermanentRedirect {
+		return nil
+	}
+	return checkResponse(resp)
+}
+
+// accessToken returns a cached access token, fetching a new one shortly before it
+// expires.
+func (s *GCSSink) accessToken(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.token != "" && time.Until(s.expires) > time.Minute {
+		return s.token, nil
+	}
+
+	var req *http.Request
+	var err error
+	if s.key != nil {
+		req, err = s.key.tokenRequest(ctx)
+	} else {
+		req, err = http.NewRequestWithContext(ctx, http.MethodGet, gcsMetadataTokenURL, nil)
+		if req != nil {
+			req.Header.Set("Metadata-Flavor", "Google")
+		}
+	}
+	if err != nil {
+		return "", err
+	}
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if err := checkResponse(resp); err != nil {
+		return "", err
+	}
+	var token struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", fmt.Errorf("decoding token: %w", err)
+	}
+	s.token = token.AccessToken
+	s.expires = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+	return s.token, nil
+}
+
+// tokenRequest returns a request exchanging a signed JWT for an access token, per
+// Google's OAuth 2.0 flow for service accounts.
+func (k *serviceAccountKey) tokenRequest(ctx context.Context) (*http.Request, error) {
+	now := time.Now()
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"RS256","typ":"JWT"}`))
+	claims, err := json.Marshal(map[string]any{
+		"iss":   k.ClientEmail,
+		"scope": gcsScope,
+		"aud":   k.TokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	unsigned := header + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(unsigned))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, k.signer, crypto.SHA256, digest[:])
+	if err != nil {
+		return nil, fmt.Errorf("signing token request: %w", err)
+	}
+
+	form := url.Values{
+		"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+		"assertion":  {unsigned + "." + base64.RawURLEncoding.EncodeToString(signature)},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, k.TokenURI, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return req, nil
+}
@@ -0,0 +1,59 @@
+// Package audit sends a record of every pod the operator removes, and of every run,
+// to audit outputs.
+package audit
+
+import (
+	"context"
+	"time"
+)
+
+// RecordType distinguishes the audit records.
+type RecordType string
+
+const (
+	// PodRemoved records a pod deleted or evicted by a run.
+	PodRemoved RecordType = "PodRemoved"
+	// RunFinished records the end of a run.
+	RunFinished RecordType = "RunFinished"
+	// NamespaceDeleted records a namespace deleted by a run once its namespaceTTL
+	// expired.
+	NamespaceDeleted RecordType = "NamespaceDeleted"
+)
+
+// Record is an audit record.
+type Record struct {
+	// SchemaVersion is cleanupv1.RecordSchemaVersion.
+	SchemaVersion string     `json:"schemaVersion"`
+	Type          RecordType `json:"type"`
+	Time          time.Time  `json:"time"`
+	// RunID is the unique ID of the run, as in its CleanupRun and run report.
+	RunID  string `json:"runID"`
+	Policy string `json:"policy"`
+	DryRun bool   `json:"dryRun,omitempty"`
+	// Drain is the NodeDrainCleanup that removed the pod of a PodRemoved record
+	// outside of a run, in which case RunID and Policy are empty.
+	Drain string `json:"drain,omitempty"`
+	// Cluster is the ClusterTarget of a PodRemoved or NamespaceDeleted record in a
+	// workload cluster, or empty in the operator's own cluster.
+	Cluster string `json:"cluster,omitempty"`
+
+	// Namespace, Pod, UID, Phase, Node, AgeSeconds and Action describe the pod of a
+	// PodRemoved record. Action is Delete or Evict. A NamespaceDeleted record sets
+	// Namespace, UID and AgeSeconds to describe the namespace.
+	Namespace  string `json:"namespace,omitempty"`
+	Pod        string `json:"pod,omitempty"`
+	UID        string `json:"uid,omitempty"`
+	Phase      string `json:"phase,omitempty"`
+	Node       string `json:"node,omitempty"`
+	AgeSeconds int64  `json:"ageSeconds,omitempty"`
+	Action     string `json:"action,omitempty"`
+
+	// PodsDeleted and Error describe the outcome of a RunFinished record.
+	PodsDeleted int    `json:"podsDeleted,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+// Sink is an audit output.
+type Sink interface {
+	Write(ctx context.Context, r Record) error
+}
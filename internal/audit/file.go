@@ -0,0 +1,219 @@
+package audit
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// DefaultFileMaxSize is the size at which a file is rotated by default.
+	DefaultFileMaxSize = 100 << 20
+	// DefaultFileMaxBackups is the number of rotated files kept by default.
+	DefaultFileMaxBackups = 10
+	// rotatedTimeFormat is the time of rotation in the names of rotated files. It
+	// sorts in the order of rotation.
+	rotatedTimeFormat = "2006-01-02T15-04-05.000"
+)
+
+// File appends records as JSON lines to a file. The file is rotated once it would
+// exceed MaxSize bytes or has been written to for MaxAge: it is renamed with the
+// time of the rotation, e.g. audit-2024-05-01T03-00-02.417.log for audit.log, and a
+// new file is started. Rotated files are gzipped if Compress is set, and all but the
+// newest MaxBackups are deleted. Compression and deletion happen in the background,
+// so writing a record never waits for them.
+type File struct {
+	Path string
+	// MaxSize is the size in bytes at which the file is rotated.
+	MaxSize int64
+	// MaxAge is how long a file is written to before it is rotated, or 0 to only
+	// rotate by size.
+	MaxAge     time.Duration
+	Compress   bool
+	MaxBackups int
+
+	mu     sync.Mutex
+	file   *os.File
+	size   int64
+	opened time.Time
+
+	// millMu serializes compressing and deleting rotated files; milling counts the
+	// passes in progress, which Close waits for.
+	millMu  sync.Mutex
+	milling sync.WaitGroup
+}
+
+// NewFile returns a File output appending to path, which is opened with the first
+// record.
+func NewFile(path string, maxSize int64, maxAge time.Duration, compress bool, maxBackups int) *File {
+	if maxSize < 1 {
+		maxSize = DefaultFileMaxSize
+	}
+	if maxBackups < 1 {
+		maxBackups = DefaultFileMaxBackups
+	}
+	return &File{Path: path, MaxSize: maxSize, MaxAge: maxAge, Compress: compress, MaxBackups: maxBackups}
+}
+
+// Write appends the record to the file, rotating it first if it is due.
+func (f *File) Write(_ context.Context, r Record) error {
+	line, err := json.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("encoding audit record: %w", err)
+	}
+	line = append(line, '\n')
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.file == nil {
+		if err := f.open(); err != nil {
+			return err
+		}
+	}
+	if f.size > 0 && (f.size+int64(len(line)) > f.MaxSize || (f.MaxAge > 0 && time.Since(f.opened) >= f.MaxAge)) {
+		if err := f.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := f.file.Write(line)
+	f.size += int64(n)
+	if err != nil {
+		return fmt.Errorf("writing audit file %s: %w", f.Path, err)
+	}
+	return nil
+}
+
+// Close closes the file and waits for rotated files to be compressed and deleted.
+func (f *File) Close() error {
+	f.mu.Lock()
+	var err error
+	if f.file != nil {
+		err = f.file.Close()
+		f.file = nil
+	}
+	f.mu.Unlock()
+	f.milling.Wait()
+	return err
+}
+
+// open opens the file for appending, creating it if needed. f.mu must be held.
+func (f *File) open() error {
+	file, err := os.OpenFile(f.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o640)
+	if err != nil {
+		return fmt.Errorf("opening audit file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("opening audit file: %w", err)
+	}
+	f.file, f.size, f.opened = file, info.Size(), time.Now()
+	return nil
+}
+
+// rotate renames the file, starts a new one and mills the rotated files in the
+// background. f.mu must be held.
+func (f *File) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return fmt.Errorf("closing audit file %s: %w", f.Path, err)
+	}
+	f.file = nil
+	prefix, ext := f.rotatedName()
+	rotated := prefix + time.Now().UTC().Format(rotatedTimeFormat) + ext
+	if err := os.Rename(f.Path, rotated); err != nil {
+		return fmt.Errorf("rotating audit file %s: %w", f.Path, err)
+	}
+	if err := f.open(); err != nil {
+		return err
+	}
+	f.milling.Add(1)
+	go func() {
+		defer f.milling.Done()
+		if err := f.mill(rotated); err != nil {
+			log.Log.WithName("audit").Error(err, "Failed to clean up rotated audit files", "path", f.Path)
+		}
+	}()
+	return nil
+}
+
+// rotatedName returns the path of rotated files before and after their time of
+// rotation: /var/log/audit- and .log for /var/log/audit.log.
+func (f *File) rotatedName() (string, string) {
+	ext := filepath.Ext(f.Path)
+	return strings.TrimSuffix(f.Path, ext) + "-", ext
+}
+
+// mill compresses the file just rotated if Compress is set, and deletes all but the
+// newest MaxBackups rotated files.
+func (f *File) mill(rotated string) error {
+	f.millMu.Lock()
+	defer f.millMu.Unlock()
+	if f.Compress {
+		if err := compressFile(rotated); err != nil {
+			return err
+		}
+	}
+
+	prefix, ext := f.rotatedName()
+	entries, err := os.ReadDir(filepath.Dir(f.Path))
+	if err != nil {
+		return err
+	}
+	var backups []string
+	for _, entry := range entries {
+		path := filepath.Join(filepath.Dir(f.Path), entry.Name())
+		name := strings.TrimSuffix(path, ".gz")
+		if entry.Type().IsRegular() && strings.HasPrefix(name, prefix) && strings.HasSuffix(name, ext) {
+			if _, err := time.Parse(rotatedTimeFormat, strings.TrimSuffix(strings.TrimPrefix(name, prefix), ext)); err == nil {
+				backups = append(backups, path)
+			}
+		}
+	}
+	// The names sort in the order of rotation; the newest are kept.
+	slices.Sort(backups)
+	for _, path := range backups[:max(len(backups)-f.MaxBackups, 0)] {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// compressFile replaces the file with a gzipped copy named path.gz.
+func compressFile(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	dst, err := os.OpenFile(path+".gz", os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o640)
+	if err != nil {
+		return err
+	}
+	gz := gzip.NewWriter(dst)
+	if _, err := io.Copy(gz, src); err != nil {
+		dst.Close()
+		os.Remove(path + ".gz")
+		return fmt.Errorf("compressing %s: %w", path, err)
+	}
+	if err := gz.Close(); err != nil {
+		dst.Close()
+		os.Remove(path + ".gz")
+		return fmt.Errorf("compressing %s: %w", path, err)
+	}
+	if err := dst.Close(); err != nil {
+		os.Remove(path + ".gz")
+		return fmt.Errorf("compressing %s: %w", path, err)
+	}
+	return os.Remove(path)
+}
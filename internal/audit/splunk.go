@@ -0,0 +1,231 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// DefaultSplunkBatchSize is the number of records sent in one request by default.
+	DefaultSplunkBatchSize = 50
+	// splunkTimeout bounds a single request to the collector.
+	splunkTimeout = 10 * time.Second
+	// splunkAttempts is the number of times a batch is sent before it is dropped.
+	splunkAttempts = 3
+	// splunkBackoff is the delay before the first retry; it doubles with every
+	// further attempt.
+	splunkBackoff = time.Second
+	// splunkQueueSize is the number of full batches waiting to be sent before further
+	// records are dropped.
+	splunkQueueSize = 100
+)
+
+// Splunk sends records as events to a Splunk HTTP Event Collector. Records are
+// buffered in batches of BatchSize; a batch is queued when it is full or a
+// RunFinished record arrives, and queued batches are sent in the background, so
+// writing a record never waits for the collector. Requests that fail with a network
+// error, 429 or 5xx are retried with backoff; batches that still cannot be sent are
+// logged and dropped, as are records written while the queue is full.
+type Splunk struct {
+	// URL is the event endpoint of the collector.
+	URL   string
+	Token string
+	// Index, Source and SourceType are set on every event, unless empty.
+	Index      string
+	Source     string
+	SourceType string
+	BatchSize  int
+	Client     *http.Client
+
+	hostname string
+
+	mu     sync.Mutex
+	batch  []Record
+	closed bool
+
+	// queue holds the batches waiting to be sent; done is closed once the sender has
+	// sent the last of them after Close. Cancelling stop abandons the batches left.
+	queue chan []Record
+	done  chan struct{}
+	stop  context.CancelFunc
+}
+
+// splunkEvent is the envelope of a record in a request to the collector.
+type splunkEvent struct {
+	// Time is in seconds since the epoch.
+	Time       float64 `json:"time"`
+	Host       string  `json:"host,omitempty"`
+	Index      string  `json:"index,omitempty"`
+	Source     string  `json:"source,omitempty"`
+	SourceType string  `json:"sourcetype,omitempty"`
+	Event      Record  `json:"event"`
+}
+
+// NewSplunk returns a Splunk output posting to the collector at url with the HEC
+// token, and starts sending its batches until it is closed. A nil client uses one
+// with the default timeout.
+func NewSplunk(url, token string, batchSize int, client *http.Client) *Splunk {
+	hostname, _ := os.Hostname()
+	if batchSize < 1 {
+		batchSize = DefaultSplunkBatchSize
+	}
+	if client == nil {
+		client = &http.Client{Timeout: splunkTimeout}
+	}
+	ctx, stop := context.WithCancel(context.Background())
+	s := &Splunk{
+		URL:        url,
+		Token:      token,
+		SourceType: "_json",
+		BatchSize:  batchSize,
+		Client:     client,
+		hostname:   hostname,
+		queue:      make(chan []Record, splunkQueueSize),
+		done:       make(chan struct{}),
+		stop:       stop,
+	}
+	go s.send(ctx)
+	return s
+}
+
+// Write buffers the record, and queues the buffered records when the batch is full
+// or the record ends a run. It fails if the queue is full or the output is closed.
+func (s *Splunk) Write(_ context.Context, r Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return fmt.Errorf("dropping audit record: Splunk output is closed")
+	}
+	s.batch = append(s.batch, r)
+	if len(s.batch) < s.BatchSize && r.Type != RunFinished {
+		return nil
+	}
+	batch := s.batch
+	s.batch = nil
+	select {
+	case s.queue <- batch:
+		return nil
+	default:
+		return fmt.Errorf("dropping %d audit record(s): Splunk queue is full", len(batch))
+	}
+}
+
+// Close queues the buffered records, stops accepting new ones and waits until the
+// queued batches are sent or ctx is done, in which case the batches left are dropped.
+func (s *Splunk) Close(ctx context.Context) error {
+	s.mu.Lock()
+	dropped := 0
+	if !s.closed {
+		s.closed = true
+		if len(s.batch) > 0 {
+			select {
+			case s.queue <- s.batch:
+			default:
+				dropped = len(s.batch)
+			}
+			s.batch = nil
+		}
+		close(s.queue)
+	}
+	s.mu.Unlock()
+
+	select {
+	case <-s.done:
+		if dropped > 0 {
+			return fmt.Errorf("dropping %d audit record(s): Splunk queue is full", dropped)
+		}
+		return nil
+	case <-ctx.Done():
+		s.stop()
+		return fmt.Errorf("dropping %d unsent audit batch(es): %w", len(s.queue), ctx.Err())
+	}
+}
+
+// send sends the queued batches until the queue is closed and drained, or ctx is
+// done.
+func (s *Splunk) send(ctx context.Context) {
+	defer close(s.done)
+	for batch := range s.queue {
+		if ctx.Err() != nil {
+			return
+		}
+		if err := s.sendBatch(ctx, batch); err != nil {
+			log.Log.WithName("audit").Error(err, "Failed to send audit records", "url", s.URL)
+		}
+	}
+}
+
+// sendBatch sends one batch, retrying failed requests.
+func (s *Splunk) sendBatch(ctx context.Context, batch []Record) error {
+	var body bytes.Buffer
+	enc := json.NewEncoder(&body)
+	for _, r := range batch {
+		event := splunkEvent{
+			Time:       float64(r.Time.UnixMilli()) / 1000,
+			Host:       s.hostname,
+			Index:      s.Index,
+			Source:     s.Source,
+			SourceType: s.SourceType,
+			Event:      r,
+		}
+		if err := enc.Encode(event); err != nil {
+			return fmt.Errorf("encoding audit record: %w", err)
+		}
+	}
+
+	backoff := splunkBackoff
+	for attempt := 1; ; attempt++ {
+		retry, err := s.post(ctx, body.Bytes())
+		if err == nil {
+			return nil
+		}
+		if !retry || attempt == splunkAttempts {
+			return fmt.Errorf("sending %d audit record(s) to Splunk: %w", len(batch), err)
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("sending %d audit record(s) to Splunk: %w", len(batch), ctx.Err())
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// post sends one request, reporting whether a failed request may be retried.
+func (s *Splunk) post(ctx context.Context, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Splunk "+s.Token)
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+
+	// The collector explains errors as {"text": ..., "code": ...}.
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	var hecErr struct {
+		Text string `json:"text"`
+	}
+	if json.Unmarshal(msg, &hecErr) == nil && hecErr.Text != "" {
+		msg = []byte(hecErr.Text)
+	}
+	err = fmt.Errorf("collector returned %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500, err
+}
@@ -0,0 +1,151 @@
+package audit
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// appName is the APP-NAME of every message.
+	appName = "pod-cleanup-operator"
+	// sdID is the SD-ID of the structured data element carrying the record's keys.
+	// 32473 is the private enterprise number reserved for documentation (RFC 5612).
+	sdID = "cleanup@32473"
+	// DefaultFacility is local0.
+	DefaultFacility = 16
+	// syslogTimeout bounds connecting to the server and writing a single message.
+	syslogTimeout = 5 * time.Second
+)
+
+// Syslog severities used by the records.
+const (
+	severityWarning = 4
+	severityNotice  = 5
+	severityInfo    = 6
+)
+
+// Syslog sends records as RFC 5424 messages to a syslog server: one datagram per
+// message over UDP, octet-counted frames (RFC 6587) over TCP and TLS. The MSG part
+// is the record as JSON. A Syslog keeps its connection open between records and
+// redials once when a write fails.
+type Syslog struct {
+	// Network is "udp", "tcp" or "tls".
+	Network string
+	Address string
+	// TLSConfig configures "tls" connections.
+	TLSConfig *tls.Config
+	Facility  int
+
+	hostname string
+
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+// NewSyslog returns a Syslog output writing to address over network.
+func NewSyslog(network, address string, facility int, tlsConfig *tls.Config) *Syslog {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "-"
+	}
+	return &Syslog{Network: network, Address: address, TLSConfig: tlsConfig, Facility: facility, hostname: hostname}
+}
+
+// Write sends the record.
+func (s *Syslog) Write(ctx context.Context, r Record) error {
+	msg, err := s.format(r)
+	if err != nil {
+		return err
+	}
+	if s.Network != "udp" {
+		msg = append([]byte(fmt.Sprintf("%d ", len(msg))), msg...)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for attempt := 0; ; attempt++ {
+		if s.conn == nil {
+			if s.conn, err = s.dial(ctx); err != nil {
+				return fmt.Errorf("connecting to syslog server %s: %w", s.Address, err)
+			}
+		}
+		_ = s.conn.SetWriteDeadline(time.Now().Add(syslogTimeout))
+		if _, err = s.conn.Write(msg); err == nil {
+			return nil
+		}
+		s.conn.Close()
+		s.conn = nil
+		if attempt > 0 {
+			return fmt.Errorf("writing to syslog server %s: %w", s.Address, err)
+		}
+	}
+}
+
+// Close closes the connection to the server.
+func (s *Syslog) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
+
+func (s *Syslog) dial(ctx context.Context) (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: syslogTimeout}
+	if s.Network == "tls" {
+		tlsDialer := &tls.Dialer{NetDialer: dialer, Config: s.TLSConfig}
+		return tlsDialer.DialContext(ctx, "tcp", s.Address)
+	}
+	return dialer.DialContext(ctx, s.Network, s.Address)
+}
+
+// format renders the record as an RFC 5424 message:
+// <PRI>1 TIMESTAMP HOSTNAME APP-NAME - MSGID [STRUCTURED-DATA] MSG.
+func (s *Syslog) format(r Record) ([]byte, error) {
+	body, err := json.Marshal(r)
+	if err != nil {
+		return nil, fmt.Errorf("encoding audit record: %w", err)
+	}
+	severity := severityInfo
+	switch {
+	case r.Error != "":
+		severity = severityWarning
+	case r.Type == PodRemoved, r.Type == NamespaceDeleted:
+		severity = severityNotice
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "<%d>1 %s %s %s - %s [%s", s.Facility*8+severity,
+		r.Time.UTC().Format("2006-01-02T15:04:05.000000Z"), s.hostname, appName, r.Type, sdID)
+	params := []struct{ name, value string }{
+		{"schemaVersion", r.SchemaVersion},
+		{"runID", r.RunID},
+		{"policy", r.Policy},
+		{"drain", r.Drain},
+		{"cluster", r.Cluster},
+		{"namespace", r.Namespace},
+		{"pod", r.Pod},
+		{"action", r.Action},
+	}
+	for _, p := range params {
+		if p.value != "" {
+			fmt.Fprintf(&b, " %s=\"%s\"", p.name, sdEscaper.Replace(p.value))
+		}
+	}
+	b.WriteString("] ")
+	b.Write(body)
+	return []byte(b.String()), nil
+}
+
+// sdEscaper escapes the characters RFC 5424 reserves in PARAM-VALUE.
+var sdEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`)
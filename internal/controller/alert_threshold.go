@@ -0,0 +1,64 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// alertThresholdError stops a run whose candidates exceed the policy's alertThreshold.
+type alertThresholdError struct {
+	candidates int
+	threshold  int32
+}
+
+func (e *alertThresholdError) Error() string {
+	return fmt.Sprintf("%d pod(s) would be deleted, more than alertThreshold %d; acknowledge the run to delete them",
+		e.candidates, e.threshold)
+}
+
+// isAlertThresholdExceeded reports whether err stopped a run because of the policy's
+// alertThreshold.
+func isAlertThresholdExceeded(err error) bool {
+	var exceeded *alertThresholdError
+	return errors.As(err, &exceeded)
+}
+
+// checkAlertThreshold counts the pods a run would delete or evict, without side
+// effects, and returns an alertThresholdError if they exceed the policy's
+// alertThreshold. Dry runs and acknowledged runs are not checked.
+func (r *PodCleanupPolicyReconciler) checkAlertThreshold(ctx context.Context, run *cleanupRun) error {
+	threshold := run.policy.Spec.AlertThreshold
+	if threshold == nil || run.dryRun || run.acknowledged {
+		return nil
+	}
+	probe := &cleanupRun{
+		id:               run.id,
+		policy:           run.policy,
+		spec:             run.spec,
+		config:           run.config,
+		schedule:         run.schedule,
+		limiter:          run.limiter,
+		clusterName:      run.clusterName,
+		cluster:          run.cluster,
+		clusterReader:    run.clusterReader,
+		podClient:        run.podClient,
+		podCache:         run.podCache,
+		dryRun:           true,
+		preview:          true,
+		started:          run.started,
+		higherPriority:   run.higherPriority,
+		protectors:       run.protectors,
+		retentions:       run.retentions,
+		matcher:          run.matcher,
+		maintenanceNodes: run.maintenanceNodes,
+		scaleDownNodes:   run.scaleDownNodes,
+	}
+	if _, err := r.runCleanup(ctx, probe); err != nil {
+		return fmt.Errorf("counting candidates for alertThreshold: %w", err)
+	}
+	if len(probe.candidates) > int(*threshold) {
+		return &alertThresholdError{candidates: len(probe.candidates), threshold: *threshold}
+	}
+	return nil
+}
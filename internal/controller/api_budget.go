@@ -0,0 +1,92 @@
+package controller
+
+import (
+	"context"
+	"sync"
+
+	cleanupv1 "github.com/aravindavvaru/pod-cleanup-operator/api/v1"
+)
+
+// apiBudget bounds the pod list, delete and evict calls in flight across all runs.
+// Its zero value is an unlimited budget.
+type apiBudget struct {
+	mu sync.Mutex
+	// limit is the number of calls allowed in flight, or 0 for no limit.
+	limit int
+	inUse int
+	// changed is closed, and replaced, when a call finishes or the limit changes.
+	changed chan struct{}
+}
+
+// acquire waits until a call may start. Every successful acquire must be followed
+// by a release.
+func (b *apiBudget) acquire(ctx context.Context) error {
+	for {
+		b.mu.Lock()
+		if b.limit == 0 || b.inUse < b.limit {
+			b.inUse++
+			b.mu.Unlock()
+			return nil
+		}
+		changed := b.waitChan()
+		b.mu.Unlock()
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return context.Cause(ctx)
+		}
+	}
+}
+
+// release marks a call as finished.
+func (b *apiBudget) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.inUse--
+	b.notify()
+}
+
+// setLimit applies the OperatorConfig's maxConcurrentAPICalls; nil removes the limit.
+func (b *apiBudget) setLimit(limit *int32) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	n := 0
+	if limit != nil {
+		n = int(*limit)
+	}
+	if n != b.limit {
+		b.limit = n
+		b.notify()
+	}
+}
+
+// waitChan returns the channel closed on the next change. b.mu must be held.
+func (b *apiBudget) waitChan() chan struct{} {
+	if b.changed == nil {
+		b.changed = make(chan struct{})
+	}
+	return b.changed
+}
+
+// notify wakes up the waiting calls. b.mu must be held.
+func (b *apiBudget) notify() {
+	if b.changed != nil {
+		close(b.changed)
+		b.changed = nil
+	}
+}
+
+// withAPIBudget runs call within the API budget.
+func (r *PodCleanupPolicyReconciler) withAPIBudget(ctx context.Context, call func() error) error {
+	if err := r.apiBudget.acquire(ctx); err != nil {
+		return err
+	}
+	defer r.apiBudget.release()
+	return call()
+}
+
+// applyAPIBudget updates the API budget from the OperatorConfig.
+func (r *PodCleanupPolicyReconciler) applyAPIBudget(config *cleanupv1.OperatorConfigSpec) {
+	r.apiBudget.setLimit(config.MaxConcurrentAPICalls)
+}
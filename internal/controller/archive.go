@@ -0,0 +1,222 @@
+package controller
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/yaml"
+
+	cleanupv1 "github.com/aravindavvaru/pod-cleanup-operator/api/v1"
+	"github.com/aravindavvaru/pod-cleanup-operator/internal/archive"
+)
+
+const (
+	// archiveChunkBytes is the compressed size at which an archive chunk is stored.
+	// It leaves headroom below the ConfigMap size limit for the data the compressor
+	// still buffers and for the last manifest added.
+	archiveChunkBytes = 768 << 10
+	// azureSASTokenEnv names the environment variable holding the shared access
+	// signature for AzureBlob archives.
+	azureSASTokenEnv = "AZURE_STORAGE_SAS_TOKEN"
+	// maxArchivedEvents caps the Events archived per pod.
+	maxArchivedEvents = 100
+)
+
+// podArchive collects the manifests of a run's removed pods and stores them in
+// chunks.
+type podArchive struct {
+	spec *cleanupv1.ArchiveSpec
+	// sink is created for the first pod archived.
+	sink archive.ArchiveSink
+	buf  bytes.Buffer
+	gz   *gzip.Writer
+	// pods counts the manifests in the current chunk; archived counts those stored.
+	pods     int
+	archived int
+	// chunks counts the chunks stored.
+	chunks int
+	// locations lists where the chunks stored so far are, in order.
+	locations []string
+}
+
+// archiveSinkFor returns the sink of the policy's archive backend, with the
+// credentials its secretRef selects.
+func (r *PodCleanupPolicyReconciler) archiveSinkFor(ctx context.Context, spec *cleanupv1.ArchiveSpec) (archive.ArchiveSink, error) {
+	switch spec.Type {
+	case cleanupv1.ArchiveGCS:
+		var key string
+		if spec.GCS.SecretRef != nil {
+			var err error
+			if key, err = r.secretValue(ctx, spec.GCS.SecretRef); err != nil {
+				return nil, err
+			}
+		}
+		return archive.NewGCSSink(spec.GCS.Bucket, spec.GCS.Prefix, []byte(key))
+	case cleanupv1.ArchiveAzureBlob:
+		token := os.Getenv(azureSASTokenEnv)
+		if spec.AzureBlob.SecretRef != nil {
+			var err error
+			if token, err = r.secretValue(ctx, spec.AzureBlob.SecretRef); err != nil {
+				return nil, err
+			}
+		}
+		if token == "" {
+			return nil, fmt.Errorf("AzureBlob archive needs a shared access signature in a secretRef or %s", azureSASTokenEnv)
+		}
+		return archive.NewAzureBlobSink(spec.AzureBlob.Account, spec.AzureBlob.Container, spec.AzureBlob.Prefix, token), nil
+	default:
+		if r.OperatorNamespace == "" {
+			return nil, fmt.Errorf("ConfigMap archive needs the operator namespace")
+		}
+		return &archive.ConfigMapSink{Client: r.Client, Scheme: r.Scheme, Namespace: r.OperatorNamespace}, nil
+	}
+}
+
+// archivePod adds the pod's manifest to the run's archive, storing the current chunk
+// once it is full. An error means the pod must not be removed, since its manifest
+// (or that of a pod removed earlier) could not be stored.
+func (r *PodCleanupPolicyReconciler) archivePod(ctx context.Context, run *cleanupRun, pod *corev1.Pod) error {
+	a := run.archive
+	if a == nil {
+		return nil
+	}
+	if run.record == nil {
+		return fmt.Errorf("archive needs a CleanupRun record")
+	}
+	if a.sink == nil {
+		sink, err := r.archiveSinkFor(ctx, a.spec)
+		if err != nil {
+			return err
+		}
+		a.sink = sink
+	}
+
+	manifest := pod.DeepCopy()
+	manifest.APIVersion, manifest.Kind = "v1", "Pod"
+	manifest.ManagedFields = nil
+	if manifest.Annotations == nil {
+		manifest.Annotations = make(map[string]string)
+	}
+	manifest.Annotations[cleanupv1.LabelRunID] = string(run.id)
+	objects := []any{manifest}
+	if a.spec.IncludeEvents {
+		for _, event := range r.podEvents(ctx, run, pod) {
+			objects = append(objects, event)
+		}
+	}
+
+	if a.gz == nil {
+		a.gz = gzip.NewWriter(&a.buf)
+	}
+	for _, obj := range objects {
+		data, err := yaml.Marshal(obj)
+		if err != nil {
+			return fmt.Errorf("encoding pod %s/%s: %w", pod.Namespace, pod.Name, err)
+		}
+		if _, err := a.gz.Write(append([]byte("---\n"), data...)); err != nil {
+			return err
+		}
+	}
+	a.pods++
+	if a.buf.Len() >= archiveChunkBytes {
+		return flushArchive(ctx, run, false)
+	}
+	return nil
+}
+
+//+kubebuilder:rbac:groups="",resources=events,verbs=list
+
+// podEvents returns the newest maxArchivedEvents Events about the pod, oldest first. They are listed from the API server, which filters them by the
+// pod's UID. Failing to list them is logged; the pod is archived without them.
+func (r *PodCleanupPolicyReconciler) podEvents(ctx context.Context, run *cleanupRun, pod *corev1.Pod) []*corev1.Event {
+	eventList := &corev1.EventList{}
+	if err := run.clusterReader.List(ctx, eventList, client.InNamespace(pod.Namespace),
+		client.MatchingFieldsSelector{Selector: fields.OneTermEqualSelector("involvedObject.uid", string(pod.UID))}); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list pod events for the archive", "pod", pod.Name, "namespace", pod.Namespace)
+		return nil
+	}
+	events := make([]*corev1.Event, 0, len(eventList.Items))
+	for i := range eventList.Items {
+		event := &eventList.Items[i]
+		event.APIVersion, event.Kind = "v1", "Event"
+		event.ManagedFields = nil
+		events = append(events, event)
+	}
+	sort.Slice(events, func(i, j int) bool { return eventTime(events[i]).Before(eventTime(events[j])) })
+	if len(events) > maxArchivedEvents {
+		events = events[len(events)-maxArchivedEvents:]
+	}
+	return events
+}
+
+// eventTime returns when the Event last occurred.
+func eventTime(event *corev1.Event) time.Time {
+	switch {
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	}
+	return event.CreationTimestamp.Time
+}
+
+// flushArchive stores the current chunk of the run's archive, or its final chunk
+// with last set. Only archiveChunkBytes of compressed manifests are held in memory;
+// object storage sinks stream the chunks into a single object per run.
+func flushArchive(ctx context.Context, run *cleanupRun, last bool) error {
+	a := run.archive
+	if a == nil || a.sink == nil || (a.pods == 0 && !last) {
+		return nil
+	}
+	var data []byte
+	if a.pods > 0 {
+		if err := a.gz.Close(); err != nil {
+			return err
+		}
+		data = bytes.Clone(a.buf.Bytes())
+	}
+	location, err := a.sink.Store(ctx, archive.Chunk{
+		Run:   run.record,
+		Index: a.chunks,
+		Data:  data,
+		Last:  last,
+	})
+	if err != nil {
+		return fmt.Errorf("storing archive chunk: %w", err)
+	}
+
+	if location != "" {
+		a.locations = append(a.locations, location)
+	}
+	if data == nil {
+		return nil
+	}
+	a.chunks++
+	a.archived += a.pods
+	a.pods = 0
+	a.buf.Reset()
+	a.gz.Reset(&a.buf)
+	return nil
+}
+
+// closeArchive stores the last chunk of the run's archive. Failures are logged and
+// reported as an event; the pods of the chunk have already been removed.
+func (r *PodCleanupPolicyReconciler) closeArchive(ctx context.Context, run *cleanupRun) {
+	if run.archive == nil {
+		return
+	}
+	if err := flushArchive(ctx, run, true); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to store pod archive", "pods", run.archive.pods)
+		r.runEventf(run, corev1.EventTypeWarning, "ArchiveFailed",
+			"Manifests of %d removed pod(s) could not be archived: %v", run.archive.pods, err)
+	}
+}
@@ -0,0 +1,328 @@
+package controller
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	cleanupv1 "github.com/aravindavvaru/pod-cleanup-operator/api/v1"
+	"github.com/aravindavvaru/pod-cleanup-operator/internal/audit"
+)
+
+// auditCloseTimeout bounds sending the records buffered by an audit output being
+// closed.
+const auditCloseTimeout = 10 * time.Second
+
+// auditSinks returns the audit outputs configured in the OperatorConfig. Outputs are
+// kept between runs, so the syslog connection is reused and Splunk batches span
+// records, and rebuilt when their configuration or Secrets change.
+func (r *PodCleanupPolicyReconciler) auditSinks(ctx context.Context, config *cleanupv1.OperatorConfigSpec) ([]audit.Sink, error) {
+	var sinks []audit.Sink
+	syslog, err := r.syslogSink(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	if syslog != nil {
+		sinks = append(sinks, syslog)
+	}
+	splunk, err := r.splunkSink(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	if splunk != nil {
+		sinks = append(sinks, splunk)
+	}
+	if file := r.fileSink(ctx, config); file != nil {
+		sinks = append(sinks, file)
+	}
+	return sinks, nil
+}
+
+// fileSink returns the file audit output, or nil if none is configured.
+func (r *PodCleanupPolicyReconciler) fileSink(ctx context.Context, config *cleanupv1.OperatorConfigSpec) *audit.File {
+	// The file is only opened with the first record, so building it is cheap.
+	var file *audit.File
+	key := ""
+	if config.Audit != nil && config.Audit.File != nil {
+		file = newAuditFile(config.Audit.File)
+		key = fmt.Sprintf("%s|%d|%s|%t|%d", file.Path, file.MaxSize, file.MaxAge, file.Compress, file.MaxBackups)
+	}
+
+	r.auditMu.Lock()
+	defer r.auditMu.Unlock()
+	if key != r.fileKey {
+		if r.file != nil {
+			if err := r.file.Close(); err != nil {
+				log.FromContext(ctx).Error(err, "Failed to close the audit file")
+			}
+		}
+		r.file, r.fileKey = file, key
+	}
+	return r.file
+}
+
+// syslogSink returns the syslog audit output, or nil if none is configured.
+func (r *PodCleanupPolicyReconciler) syslogSink(ctx context.Context, config *cleanupv1.OperatorConfigSpec) (*audit.Syslog, error) {
+	var output *cleanupv1.SyslogOutput
+	if config.Audit != nil {
+		output = config.Audit.Syslog
+	}
+
+	var ca string
+	if output != nil && output.CASecretRef != nil {
+		var err error
+		if ca, err = r.secretValue(ctx, output.CASecretRef); err != nil {
+			return nil, fmt.Errorf("reading syslog CA bundle: %w", err)
+		}
+	}
+	key := ""
+	if output != nil {
+		facility := audit.DefaultFacility
+		if output.Facility != nil {
+			facility = int(*output.Facility)
+		}
+		key = fmt.Sprintf("%s|%s|%d|%s", output.Protocol, output.Address, facility, ca)
+	}
+
+	r.auditMu.Lock()
+	defer r.auditMu.Unlock()
+	if key != r.syslogKey {
+		if r.syslog != nil {
+			r.syslog.Close()
+			r.syslog = nil
+		}
+		r.syslogKey = ""
+		if output != nil {
+			syslog, err := newSyslog(output, ca)
+			if err != nil {
+				return nil, err
+			}
+			r.syslog, r.syslogKey = syslog, key
+		}
+	}
+	return r.syslog, nil
+}
+
+// splunkSink returns the Splunk audit output, or nil if none is configured. Records
+// buffered by an output being replaced are sent in the background before it is
+// dropped.
+func (r *PodCleanupPolicyReconciler) splunkSink(ctx context.Context, config *cleanupv1.OperatorConfigSpec) (*audit.Splunk, error) {
+	var output *cleanupv1.SplunkOutput
+	if config.Audit != nil {
+		output = config.Audit.Splunk
+	}
+
+	var token, ca string
+	if output != nil {
+		var err error
+		if token, err = r.secretValue(ctx, &output.TokenSecretRef); err != nil {
+			return nil, fmt.Errorf("reading Splunk HEC token: %w", err)
+		}
+		if output.CASecretRef != nil {
+			if ca, err = r.secretValue(ctx, output.CASecretRef); err != nil {
+				return nil, fmt.Errorf("reading Splunk CA bundle: %w", err)
+			}
+		}
+	}
+	key := ""
+	if output != nil {
+		batchSize := audit.DefaultSplunkBatchSize
+		if output.BatchSize != nil {
+			batchSize = int(*output.BatchSize)
+		}
+		key = fmt.Sprintf("%s|%s|%s|%s|%s|%d|%s", output.URL, token, output.Index, output.Source,
+			output.SourceType, batchSize, ca)
+	}
+
+	r.auditMu.Lock()
+	defer r.auditMu.Unlock()
+	if key != r.splunkKey {
+		if r.splunk != nil {
+			go closeSplunk(log.FromContext(ctx), r.splunk)
+			r.splunk = nil
+		}
+		r.splunkKey = ""
+		if output != nil {
+			splunk, err := newSplunk(output, token, ca)
+			if err != nil {
+				return nil, err
+			}
+			r.splunk, r.splunkKey = splunk, key
+		}
+	}
+	return r.splunk, nil
+}
+
+// closeSplunk sends the records buffered by a Splunk output and closes it.
+func closeSplunk(logger logr.Logger, splunk *audit.Splunk) {
+	ctx, cancel := context.WithTimeout(context.Background(), auditCloseTimeout)
+	defer cancel()
+	if err := splunk.Close(ctx); err != nil {
+		logger.Error(err, "Failed to send buffered audit records")
+	}
+}
+
+// closeAuditSinks waits until ctx is done, then sends the records the audit outputs
+// still buffer and closes them, so they are not lost when the operator shuts down.
+// Splunk records written afterwards are dropped.
+func (r *PodCleanupPolicyReconciler) closeAuditSinks(ctx context.Context) error {
+	<-ctx.Done()
+	r.auditMu.Lock()
+	defer r.auditMu.Unlock()
+	logger := log.FromContext(ctx)
+	if r.splunk != nil {
+		closeSplunk(logger, r.splunk)
+	}
+	if r.syslog != nil {
+		if err := r.syslog.Close(); err != nil {
+			logger.Error(err, "Failed to close the syslog audit output")
+		}
+	}
+	if r.file != nil {
+		if err := r.file.Close(); err != nil {
+			logger.Error(err, "Failed to close the audit file")
+		}
+	}
+	return nil
+}
+
+// newSplunk builds the Splunk output, trusting the PEM bundle ca if it is not empty.
+func newSplunk(output *cleanupv1.SplunkOutput, token, ca string) (*audit.Splunk, error) {
+	batchSize := audit.DefaultSplunkBatchSize
+	if output.BatchSize != nil {
+		batchSize = int(*output.BatchSize)
+	}
+	splunk := audit.NewSplunk(output.URL, token, batchSize, nil)
+	if ca != "" {
+		tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12, RootCAs: x509.NewCertPool()}
+		if !tlsConfig.RootCAs.AppendCertsFromPEM([]byte(ca)) {
+			return nil, errors.New("Splunk CA bundle contains no PEM certificates")
+		}
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = tlsConfig
+		splunk.Client.Transport = transport
+	}
+	splunk.Index = output.Index
+	splunk.Source = output.Source
+	if output.SourceType != "" {
+		splunk.SourceType = output.SourceType
+	}
+	return splunk, nil
+}
+
+// newAuditFile builds the file output. Validation guarantees maxAge parses.
+func newAuditFile(output *cleanupv1.FileOutput) *audit.File {
+	var maxSize int64
+	if output.MaxSizeMB != nil {
+		maxSize = int64(*output.MaxSizeMB) << 20
+	}
+	var maxAge time.Duration
+	if output.MaxAge != "" {
+		maxAge, _ = cleanupv1.ParseDuration(output.MaxAge)
+	}
+	maxBackups := audit.DefaultFileMaxBackups
+	if output.MaxBackups != nil {
+		maxBackups = int(*output.MaxBackups)
+	}
+	return audit.NewFile(output.Path, maxSize, maxAge, output.Compress, maxBackups)
+}
+
+// newSyslog builds the syslog output, trusting the PEM bundle ca if it is not empty.
+func newSyslog(output *cleanupv1.SyslogOutput, ca string) (*audit.Syslog, error) {
+	facility := audit.DefaultFacility
+	if output.Facility != nil {
+		facility = int(*output.Facility)
+	}
+	protocol := output.Protocol
+	if protocol == "" {
+		protocol = cleanupv1.SyslogUDP
+	}
+	var tlsConfig *tls.Config
+	if protocol == cleanupv1.SyslogTLS {
+		tlsConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+		if ca != "" {
+			tlsConfig.RootCAs = x509.NewCertPool()
+			if !tlsConfig.RootCAs.AppendCertsFromPEM([]byte(ca)) {
+				return nil, errors.New("syslog CA bundle contains no PEM certificates")
+			}
+		}
+	}
+	return audit.NewSyslog(strings.ToLower(string(protocol)), output.Address, facility, tlsConfig), nil
+}
+
+// writeAudit fills in the run's fields of the record and sends it to every audit
+// output. Failures are logged and never fail the run.
+func (r *PodCleanupPolicyReconciler) writeAudit(ctx context.Context, run *cleanupRun, record audit.Record) {
+	record.RunID = string(run.id)
+	record.Policy = run.policy.Name
+	record.DryRun = run.dryRun
+	r.writeAuditRecord(ctx, run.config, record)
+}
+
+// writeAuditRecord stamps the record and sends it to every audit output the
+// OperatorConfig configures. Failures are logged.
+func (r *PodCleanupPolicyReconciler) writeAuditRecord(ctx context.Context, config *cleanupv1.OperatorConfigSpec, record audit.Record) {
+	logger := log.FromContext(ctx)
+	sinks, err := r.auditSinks(ctx, config)
+	if err != nil {
+		logger.Error(err, "Failed to configure audit outputs")
+		return
+	}
+	if len(sinks) == 0 {
+		return
+	}
+	record.SchemaVersion = cleanupv1.RecordSchemaVersion
+	record.Time = r.Clock.Now()
+	for _, sink := range sinks {
+		if err := sink.Write(ctx, record); err != nil {
+			logger.Error(err, "Failed to write audit record", "type", record.Type)
+		}
+	}
+}
+
+// auditPodRemoved records a pod the run deleted or evicted.
+func (r *PodCleanupPolicyReconciler) auditPodRemoved(ctx context.Context, run *cleanupRun, pod *corev1.Pod, age time.Duration, action cleanupv1.RuleAction) {
+	r.writeAudit(ctx, run, audit.Record{
+		Type:       audit.PodRemoved,
+		Cluster:    run.clusterName,
+		Namespace:  pod.Namespace,
+		Pod:        pod.Name,
+		UID:        string(pod.UID),
+		Phase:      string(pod.Status.Phase),
+		Node:       pod.Spec.NodeName,
+		AgeSeconds: int64(age / time.Second),
+		Action:     string(action),
+	})
+}
+
+// auditNamespaceDeleted records a namespace the run deleted once it expired.
+func (r *PodCleanupPolicyReconciler) auditNamespaceDeleted(ctx context.Context, run *cleanupRun, ns *corev1.Namespace, age time.Duration) {
+	r.writeAudit(ctx, run, audit.Record{
+		Type:       audit.NamespaceDeleted,
+		Cluster:    run.clusterName,
+		Namespace:  ns.Name,
+		UID:        string(ns.UID),
+		AgeSeconds: int64(age / time.Second),
+	})
+}
+
+// auditRunFinished records the outcome of a run. Preview runs are not audited.
+func (r *PodCleanupPolicyReconciler) auditRunFinished(ctx context.Context, run *cleanupRun, deleted int, runErr error) {
+	if run.preview {
+		return
+	}
+	record := audit.Record{Type: audit.RunFinished, PodsDeleted: deleted}
+	if runErr != nil {
+		record.Error = runErr.Error()
+	}
+	r.writeAudit(ctx, run, record)
+}
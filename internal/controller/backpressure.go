@@ -0,0 +1,114 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	"k8s.io/utils/clock"
+)
+
+const (
+	// backpressureInitialRate is the deletion rate, per second, imposed when the API
+	// server first throttles the operator.
+	backpressureInitialRate = 10
+	// backpressureMinRate is the lowest deletion rate backpressure imposes.
+	backpressureMinRate = 0.5
+	// backpressureMaxRate is the rate above which backpressure lifts its limit.
+	backpressureMaxRate = 100
+	// backpressureRecovery is how long the API server must not throttle the operator
+	// before the deletion rate doubles.
+	backpressureRecovery = 30 * time.Second
+)
+
+// Backpressure slows down pod deletions while the API server throttles the operator
+// through API Priority and Fairness, so the operator backs off instead of having its
+// ServiceAccount's requests rejected cluster-wide. Every throttled response halves
+// the deletion rate; each backpressureRecovery without one doubles it, until the
+// limit is lifted. A nil Backpressure never slows deletions.
+type Backpressure struct {
+	clock clock.PassiveClock
+
+	mu      sync.Mutex
+	limiter *rate.Limiter
+	// changed is when the rate was last lowered or raised.
+	changed time.Time
+}
+
+// NewBackpressure returns a Backpressure measuring time with clock.
+func NewBackpressure(clock clock.PassiveClock) *Backpressure {
+	return &Backpressure{clock: clock, limiter: rate.NewLimiter(rate.Inf, 1)}
+}
+
+// WrapTransport watches the responses of rt for throttling. It is meant for
+// rest.Config.Wrap, so every client built from the config reports to b.
+func (b *Backpressure) WrapTransport(rt http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		resp, err := rt.RoundTrip(req)
+		if err == nil && throttled(resp) {
+			b.throttled()
+		}
+		return resp, err
+	})
+}
+
+// throttled reports whether the API server rejected the request to shed load. Only
+// Priority and Fairness and the max-in-flight filter send Retry-After with a 429;
+// evictions blocked by a PodDisruptionBudget do not.
+func throttled(resp *http.Response) bool {
+	return resp.StatusCode == http.StatusTooManyRequests && resp.Header.Get("Retry-After") != ""
+}
+
+// throttled halves the deletion rate.
+func (b *Backpressure) throttled() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	apiThrottledTotal.Inc()
+
+	now := b.clock.Now()
+	limit := rate.Limit(backpressureInitialRate)
+	if current := b.limiter.Limit(); current != rate.Inf {
+		limit = max(current/2, backpressureMinRate)
+	}
+	b.setLimit(now, limit)
+}
+
+// Wait blocks until the next deletion may proceed.
+func (b *Backpressure) Wait(ctx context.Context) error {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	if current := b.limiter.Limit(); current != rate.Inf {
+		now := b.clock.Now()
+		if now.Sub(b.changed) >= backpressureRecovery {
+			limit := current * 2
+			if limit > backpressureMaxRate {
+				limit = rate.Inf
+			}
+			b.setLimit(now, limit)
+		}
+	}
+	b.mu.Unlock()
+	return b.limiter.Wait(ctx)
+}
+
+// setLimit sets the deletion rate. b.mu must be held.
+func (b *Backpressure) setLimit(now time.Time, limit rate.Limit) {
+	b.limiter.SetLimitAt(now, limit)
+	b.changed = now
+	if limit == rate.Inf {
+		backpressureRate.Set(0)
+	} else {
+		backpressureRate.Set(float64(limit))
+	}
+}
+
+// roundTripperFunc adapts a function to http.RoundTripper.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
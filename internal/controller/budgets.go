@@ -0,0 +1,311 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	cleanupv1 "github.com/aravindavvaru/pod-cleanup-operator/api/v1"
+	"github.com/aravindavvaru/pod-cleanup-operator/internal/quota"
+)
+
+//+kubebuilder:rbac:groups=cleanup.example.com,resources=clustercleanupbudgets,verbs=get;list;watch
+
+const (
+	// budgetTotal keys the deletions of all namespaces in a budget's tracker; no
+	// namespace has an empty name.
+	budgetTotal = ""
+	// defaultBudgetWindow is the window of budgets that do not set one.
+	defaultBudgetWindow = time.Hour
+	// budgetUsageConfigMap is the ConfigMap in the operator namespace the usage of
+	// the budgets is stored in.
+	budgetUsageConfigMap = "pod-cleanup-budget-usage"
+)
+
+// budgetUsage counts the deletions against one ClusterCleanupBudget, in total and
+// per namespace. Usage is kept in memory and stored in a ConfigMap in the operator
+// namespace, from which it is read before the first deletion is reserved, so a
+// restart or leader change does not reset the budgets. Stored deletions are counted
+// in periods of budgetUsagePeriod and restored at the end of their period, so a
+// budget only gets stricter across a restart.
+type budgetUsage struct {
+	uid       types.UID
+	window    time.Duration
+	deletions *quota.Tracker
+}
+
+// storedBudgetUsage is the stored usage of one ClusterCleanupBudget.
+type storedBudgetUsage struct {
+	UID types.UID `json:"uid"`
+	// WindowSeconds is the window the deletions were counted within.
+	WindowSeconds int64 `json:"windowSeconds"`
+	// Deletions counts the deletions in total, keyed by budgetTotal, and per
+	// namespace, as returned by quota.Tracker.Usage.
+	Deletions map[string]map[int64]int `json:"deletions"`
+}
+
+// budgetUsagePeriod returns the resolution the usage of a budget with window is
+// stored at: a hundredth of the window, but at least a minute.
+func budgetUsagePeriod(window time.Duration) time.Duration {
+	return max(window/100, time.Minute)
+}
+
+// reserve reserves a deletion in namespace within the limits of spec, and reports
+// whether it did. Nothing is reserved if a limit is reached.
+func (u *budgetUsage) reserve(namespace string, spec *cleanupv1.ClusterCleanupBudgetSpec) bool {
+	if !reserveDeletion(u.deletions, budgetTotal, spec.MaxDeletions) {
+		return false
+	}
+	if !reserveDeletion(u.deletions, namespace, spec.MaxDeletionsPerNamespace) {
+		u.deletions.Release(budgetTotal)
+		return false
+	}
+	return true
+}
+
+// reserveDeletion reserves a deletion for key if it does not exceed limit, if set.
+func reserveDeletion(deletions *quota.Tracker, key string, limit *int32) bool {
+	if limit == nil {
+		deletions.Record(key)
+		return true
+	}
+	return deletions.Reserve(key, int(*limit))
+}
+
+// budgetReservation is the budget reserved for deleting one pod.
+type budgetReservation struct {
+	namespace string
+	usages    []*budgetUsage
+}
+
+// loadBudgetUsage reads the stored usage of the budgets once. The caller must hold
+// r.budgetsMu.
+func (r *PodCleanupPolicyReconciler) loadBudgetUsage(ctx context.Context) error {
+	if r.budgetsLoaded || r.budgetStore == nil {
+		return nil
+	}
+	stored := map[string]storedBudgetUsage{}
+	if err := r.budgetStore.read(ctx, &stored); err != nil {
+		return fmt.Errorf("reading ClusterCleanupBudget usage: %w", err)
+	}
+	r.storedBudgets = stored
+	r.budgetsLoaded = true
+	return nil
+}
+
+// storeBudgetUsage stores the usage of the budgets if it changed. Usage that could
+// not be stored is stored the next time.
+func (r *PodCleanupPolicyReconciler) storeBudgetUsage(ctx context.Context) {
+	r.budgetsMu.Lock()
+	defer r.budgetsMu.Unlock()
+	if !r.budgetsDirty || !r.budgetsLoaded {
+		return
+	}
+	// Usage not restored yet, of budgets no run has reserved from since the
+	// restart, is kept as read.
+	stored := make(map[string]storedBudgetUsage, len(r.budgets)+len(r.storedBudgets))
+	for name, usage := range r.storedBudgets {
+		stored[name] = usage
+	}
+	for name, usage := range r.budgets {
+		stored[name] = storedBudgetUsage{
+			UID:           usage.uid,
+			WindowSeconds: int64(usage.window / time.Second),
+			Deletions:     usage.deletions.Usage(budgetUsagePeriod(usage.window)),
+		}
+	}
+	if err := r.budgetStore.write(ctx, stored); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to store ClusterCleanupBudget usage", "configMap", budgetUsageConfigMap)
+		return
+	}
+	r.budgetsDirty = false
+}
+
+// usageOf returns the usage of budget, restoring its stored usage, and starting over
+// when the budget was recreated or its window changed. The caller must hold
+// r.budgetsMu.
+func (r *PodCleanupPolicyReconciler) usageOf(budget *cleanupv1.ClusterCleanupBudget) *budgetUsage {
+	window := defaultBudgetWindow
+	if budget.Spec.Window != "" {
+		// Validated by the caller.
+		window, _ = cleanupv1.ParseDuration(budget.Spec.Window)
+	}
+	usage := r.budgets[budget.Name]
+	if usage == nil || usage.uid != budget.UID || usage.window != window {
+		usage = &budgetUsage{uid: budget.UID, window: window, deletions: quota.NewTracker(window, r.Clock)}
+		if stored, ok := r.storedBudgets[budget.Name]; ok {
+			if stored.UID == budget.UID && stored.WindowSeconds == int64(window/time.Second) {
+				usage.deletions.Restore(stored.Deletions)
+			}
+			delete(r.storedBudgets, budget.Name)
+		}
+		if r.budgets == nil {
+			r.budgets = make(map[string]*budgetUsage)
+		}
+		r.budgets[budget.Name] = usage
+		r.budgetsDirty = true
+	}
+	return usage
+}
+
+// reserveBudget reserves a deletion in namespace from every valid
+// ClusterCleanupBudget. If one of them is exhausted, nothing is reserved and its
+// name is returned.
+func (r *PodCleanupPolicyReconciler) reserveBudget(ctx context.Context, namespace string) (*budgetReservation, string, error) {
+	budgets := &cleanupv1.ClusterCleanupBudgetList{}
+	if err := r.List(ctx, budgets); err != nil {
+		return nil, "", err
+	}
+
+	r.budgetsMu.Lock()
+	defer r.budgetsMu.Unlock()
+	if err := r.loadBudgetUsage(ctx); err != nil {
+		return nil, "", err
+	}
+	reservation := &budgetReservation{namespace: namespace}
+	for i := range budgets.Items {
+		budget := &budgets.Items[i]
+		if errs := budget.Validate(); len(errs) > 0 {
+			log.FromContext(ctx).V(1).Info("Ignoring invalid ClusterCleanupBudget", "budget", budget.Name, "errors", errs.ToAggregate())
+			continue
+		}
+		usage := r.usageOf(budget)
+		if !usage.reserve(namespace, &budget.Spec) {
+			r.releaseReservation(reservation)
+			return nil, budget.Name, nil
+		}
+		reservation.usages = append(reservation.usages, usage)
+		r.budgetsDirty = true
+	}
+	return reservation, "", nil
+}
+
+// releaseBudget returns the budget reserved for a deletion that did not happen.
+func (r *PodCleanupPolicyReconciler) releaseBudget(reservation *budgetReservation) {
+	r.budgetsMu.Lock()
+	defer r.budgetsMu.Unlock()
+	r.releaseReservation(reservation)
+}
+
+// releaseReservation returns the budget reserved by reservation. The caller must hold
+// r.budgetsMu.
+func (r *PodCleanupPolicyReconciler) releaseReservation(reservation *budgetReservation) {
+	for _, usage := range reservation.usages {
+		usage.deletions.Release(budgetTotal)
+		usage.deletions.Release(reservation.namespace)
+	}
+	if len(reservation.usages) > 0 {
+		r.budgetsDirty = true
+	}
+}
+
+// simulatedBudget replays deletions against the usage of one ClusterCleanupBudget
+// without recording them.
+type simulatedBudget struct {
+	budget *cleanupv1.ClusterCleanupBudget
+	usage  *budgetUsage
+	impact cleanupv1.BudgetImpact
+	// added counts the simulated deletions, in total and per namespace.
+	added map[string]int
+}
+
+// allows reports whether the budget would let another pod in namespace be removed.
+func (s *simulatedBudget) allows(namespace string) bool {
+	if max := s.budget.Spec.MaxDeletions; max != nil &&
+		s.usage.deletions.Count(budgetTotal)+s.added[budgetTotal] >= int(*max) {
+		return false
+	}
+	if max := s.budget.Spec.MaxDeletionsPerNamespace; max != nil &&
+		s.usage.deletions.Count(namespace)+s.added[namespace] >= int(*max) {
+		return false
+	}
+	return true
+}
+
+// simulateBudgets replays the removal of candidates, in order, against every valid
+// ClusterCleanupBudget the way reserveBudget would, without reserving anything. It
+// returns the impact on each budget, sorted by name, and the number of candidates
+// that would be deferred.
+func (r *PodCleanupPolicyReconciler) simulateBudgets(ctx context.Context, candidates []Candidate) ([]cleanupv1.BudgetImpact, int, error) {
+	budgets := &cleanupv1.ClusterCleanupBudgetList{}
+	if err := r.List(ctx, budgets); err != nil {
+		return nil, 0, err
+	}
+
+	r.budgetsMu.Lock()
+	defer r.budgetsMu.Unlock()
+	if err := r.loadBudgetUsage(ctx); err != nil {
+		return nil, 0, err
+	}
+	var simulated []*simulatedBudget
+	for i := range budgets.Items {
+		budget := &budgets.Items[i]
+		if errs := budget.Validate(); len(errs) > 0 {
+			continue
+		}
+		usage := r.usageOf(budget)
+		simulated = append(simulated, &simulatedBudget{
+			budget: budget,
+			usage:  usage,
+			impact: cleanupv1.BudgetImpact{Name: budget.Name, Deletions: int32(usage.deletions.Count(budgetTotal))},
+			added:  make(map[string]int),
+		})
+	}
+
+	deferred := 0
+	for _, c := range candidates {
+		i := slices.IndexFunc(simulated, func(s *simulatedBudget) bool { return !s.allows(c.Namespace) })
+		if i >= 0 {
+			simulated[i].impact.WouldDefer++
+			deferred++
+			continue
+		}
+		for _, s := range simulated {
+			s.added[budgetTotal]++
+			s.added[c.Namespace]++
+			s.impact.WouldDelete++
+		}
+	}
+
+	impacts := make([]cleanupv1.BudgetImpact, 0, len(simulated))
+	for _, s := range simulated {
+		impacts = append(impacts, s.impact)
+	}
+	slices.SortFunc(impacts, func(a, b cleanupv1.BudgetImpact) int { return strings.Compare(a.Name, b.Name) })
+	return impacts, deferred, nil
+}
+
+// budgetStatus returns the deletions counted against budget within its window and
+// the namespaces that exhausted maxDeletionsPerNamespace, sorted by name.
+func (r *PodCleanupPolicyReconciler) budgetStatus(ctx context.Context, budget *cleanupv1.ClusterCleanupBudget) (int, []string, error) {
+	r.budgetsMu.Lock()
+	defer r.budgetsMu.Unlock()
+	if err := r.loadBudgetUsage(ctx); err != nil {
+		return 0, nil, err
+	}
+	usage := r.usageOf(budget)
+	var exhausted []string
+	if max := budget.Spec.MaxDeletionsPerNamespace; max != nil {
+		for _, namespace := range usage.deletions.Tenants() {
+			if namespace != budgetTotal && usage.deletions.Count(namespace) >= int(*max) {
+				exhausted = append(exhausted, namespace)
+			}
+		}
+		slices.Sort(exhausted)
+	}
+	return usage.deletions.Count(budgetTotal), exhausted, nil
+}
+
+// forgetBudget drops the usage of a deleted budget.
+func (r *PodCleanupPolicyReconciler) forgetBudget(name string) {
+	r.budgetsMu.Lock()
+	defer r.budgetsMu.Unlock()
+	delete(r.budgets, name)
+	delete(r.storedBudgets, name)
+	r.budgetsDirty = true
+}
@@ -0,0 +1,59 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	cleanupv1 "github.com/aravindavvaru/pod-cleanup-operator/api/v1"
+)
+
+// annotateCandidate marks a pod that a dry run would delete with the policy name and
+// the time it would be deleted. Pods that already carry the same values are left alone.
+func (r *PodCleanupPolicyReconciler) annotateCandidate(ctx context.Context, run *cleanupRun, pod *corev1.Pod) {
+	deletionTime := run.candidateDeletionTime(r.Clock.Now()).UTC().Format(time.RFC3339)
+	if pod.Annotations[cleanupv1.AnnotationCandidateOf] == run.policy.Name &&
+		pod.Annotations[cleanupv1.AnnotationCandidateDeletionTime] == deletionTime {
+		return
+	}
+
+	patch := client.MergeFrom(pod.DeepCopy())
+	if pod.Annotations == nil {
+		pod.Annotations = map[string]string{}
+	}
+	pod.Annotations[cleanupv1.AnnotationCandidateOf] = run.policy.Name
+	pod.Annotations[cleanupv1.AnnotationCandidateDeletionTime] = deletionTime
+	if err := run.podClient.Patch(ctx, pod, patch); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to annotate cleanup candidate",
+			"namespace", pod.Namespace, "pod", pod.Name)
+	}
+}
+
+// clearCandidateAnnotation removes the candidate and deletion warning annotations this
+// policy set on a pod that is no longer a candidate.
+func (r *PodCleanupPolicyReconciler) clearCandidateAnnotation(ctx context.Context, run *cleanupRun, pod *corev1.Pod) {
+	if run.preview || pod.Annotations[cleanupv1.AnnotationCandidateOf] != run.policy.Name {
+		return
+	}
+
+	patch := client.MergeFrom(pod.DeepCopy())
+	delete(pod.Annotations, cleanupv1.AnnotationCandidateOf)
+	delete(pod.Annotations, cleanupv1.AnnotationCandidateDeletionTime)
+	delete(pod.Annotations, cleanupv1.AnnotationDeletionAt)
+	if err := run.podClient.Patch(ctx, pod, patch); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to clear cleanup candidate annotation",
+			"namespace", pod.Namespace, "pod", pod.Name)
+	}
+}
+
+// candidateDeletionTime is when the policy would delete its current candidates if it
+// were not a dry run: its next scheduled run, or now for unscheduled policies.
+func (run *cleanupRun) candidateDeletionTime(now time.Time) time.Time {
+	if run.schedule == nil {
+		return now
+	}
+	return run.schedule.Next(now)
+}
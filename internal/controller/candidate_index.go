@@ -0,0 +1,185 @@
+package controller
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	cleanupv1 "github.com/aravindavvaru/pod-cleanup-operator/api/v1"
+)
+
+// candidateIndex keeps the candidates of every policy, the pods matching its
+// podSelector in one of its podStatuses, up to date from pod watch events, so runs
+// process just those pods instead of listing every pod of the selected namespaces.
+// A policy's candidates in a namespace are read from the pod cache once, on the
+// first run using them, and only updated from events afterwards. Every other
+// criterion, such as the age of a pod, is still evaluated when the run processes it.
+type candidateIndex struct {
+	mu       sync.Mutex
+	policies map[types.UID]*indexedCandidates
+}
+
+// indexedCandidates are the candidates of one policy.
+type indexedCandidates struct {
+	// generation and key are the generation of the policy and the key of the spec
+	// the candidates were selected by.
+	generation int64
+	key        string
+	selector   labels.Selector
+	phases     []corev1.PodPhase
+	// pods holds the names of the candidates by namespace, for the namespaces read
+	// from the pod cache.
+	pods map[string]sets.Set[string]
+}
+
+func newCandidateIndex() *candidateIndex {
+	return &candidateIndex{policies: make(map[types.UID]*indexedCandidates)}
+}
+
+// candidateKey identifies the podSelector and podStatuses of a policy spec.
+func candidateKey(spec *cleanupv1.PodCleanupPolicySpec) (string, labels.Selector, error) {
+	selector := labels.Everything()
+	if spec.PodSelector != nil {
+		var err error
+		if selector, err = metav1.LabelSelectorAsSelector(spec.PodSelector); err != nil {
+			return "", nil, err
+		}
+	}
+	phases := make([]string, 0, len(spec.PodStatuses))
+	for _, phase := range spec.PodStatuses {
+		phases = append(phases, string(phase))
+	}
+	slices.Sort(phases)
+	return selector.String() + "/" + strings.Join(slices.Compact(phases), ","), selector, nil
+}
+
+// matches reports whether the pod is a candidate of the policy.
+func (pc *indexedCandidates) matches(pod *corev1.Pod) bool {
+	if len(pc.phases) > 0 && !slices.Contains(pc.phases, pod.Status.Phase) {
+		return false
+	}
+	return pc.selector.Matches(labels.Set(pod.Labels))
+}
+
+// candidates returns the names of the policy's candidates in namespace, in name
+// order, reading them from reader if the index does not hold them yet. It returns
+// false if the index cannot be used for the policy: for policies not stored in the
+// API server, and for runs narrowing the podSelector of their policy, such as those
+// of CleanupRequests.
+func (x *candidateIndex) candidates(ctx context.Context, reader client.Reader, policy *cleanupv1.PodCleanupPolicy, namespace string) ([]string, bool, error) {
+	if policy.UID == "" {
+		return nil, false, nil
+	}
+	key, selector, err := candidateKey(&policy.Spec)
+	if err != nil {
+		return nil, false, err
+	}
+
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	pc := x.policies[policy.UID]
+	switch {
+	case pc == nil || policy.Generation > pc.generation:
+		pc = &indexedCandidates{
+			generation: policy.Generation,
+			key:        key,
+			selector:   selector,
+			phases:     slices.Clone(policy.Spec.PodStatuses),
+			pods:       make(map[string]sets.Set[string]),
+		}
+		x.policies[policy.UID] = pc
+	case pc.key != key:
+		return nil, false, nil
+	}
+
+	names, ok := pc.pods[namespace]
+	if !ok {
+		// Events are held back while the namespace is read, and those delivered
+		// afterwards applied on top, so no change of a candidate is lost.
+		podList := &corev1.PodList{}
+		if err := reader.List(ctx, podList, client.InNamespace(namespace), client.MatchingLabelsSelector{Selector: selector},
+			client.UnsafeDisableDeepCopy); err != nil {
+			return nil, false, err
+		}
+		names = sets.New[string]()
+		for i := range podList.Items {
+			if pc.matches(&podList.Items[i]) {
+				names.Insert(podList.Items[i].Name)
+			}
+		}
+		pc.pods[namespace] = names
+	}
+	return sets.List(names), true, nil
+}
+
+// update adds the pod to or removes it from the candidates of every policy whose
+// candidates in its namespace are held.
+func (x *candidateIndex) update(pod *corev1.Pod, deleted bool) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	for _, pc := range x.policies {
+		names, ok := pc.pods[pod.Namespace]
+		if !ok {
+			continue
+		}
+		if !deleted && pc.matches(pod) {
+			names.Insert(pod.Name)
+		} else {
+			names.Delete(pod.Name)
+		}
+	}
+}
+
+// forget drops the candidates of a deleted policy.
+func (x *candidateIndex) forget(uid types.UID) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	delete(x.policies, uid)
+}
+
+// podHandler updates the index from the events of a pod informer.
+func (x *candidateIndex) podHandler() toolscache.ResourceEventHandler {
+	return toolscache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if pod, ok := obj.(*corev1.Pod); ok {
+				x.update(pod, false)
+			}
+		},
+		UpdateFunc: func(_, obj interface{}) {
+			if pod, ok := obj.(*corev1.Pod); ok {
+				x.update(pod, false)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			if pod, ok := obj.(*corev1.Pod); ok {
+				x.update(pod, true)
+			}
+		},
+	}
+}
+
+// policyHandler drops the candidates of deleted policies.
+func (x *candidateIndex) policyHandler() toolscache.ResourceEventHandler {
+	return toolscache.ResourceEventHandlerFuncs{
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			if policy, ok := obj.(*cleanupv1.PodCleanupPolicy); ok {
+				x.forget(policy.UID)
+			}
+		},
+	}
+}
@@ -0,0 +1,84 @@
+package controller
+
+import (
+	"context"
+	"sort"
+	"text/template"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	cleanupv1 "github.com/aravindavvaru/pod-cleanup-operator/api/v1"
+	"github.com/aravindavvaru/pod-cleanup-operator/internal/notify"
+)
+
+// fileChangeRecord files a change record for a run that deleted more pods than the
+// threshold of the OperatorConfig's changeRecord. Failures are logged and reported
+// with a ChangeRecordFailed Event; they never fail the run.
+func (r *PodCleanupPolicyReconciler) fileChangeRecord(ctx context.Context, run *cleanupRun, trigger cleanupv1.RunTrigger, deleted int, runErr error) {
+	webhook := run.config.ChangeRecord
+	if webhook == nil || run.dryRun || deleted <= int(webhook.Threshold) {
+		return
+	}
+	logger := log.FromContext(ctx)
+
+	record := notify.ChangeRecord{
+		SchemaVersion:  cleanupv1.RecordSchemaVersion,
+		RunID:          string(run.id),
+		Policy:         run.policy.Name,
+		Trigger:        string(trigger),
+		StartTime:      run.started,
+		CompletionTime: r.Clock.Now(),
+		PodsDeleted:    deleted,
+		Namespaces:     make([]string, 0, len(run.removed.counts)),
+	}
+	if run.record != nil {
+		record.Run = run.record.Name
+	}
+	if run.reportName != "" {
+		record.Report = run.reportName
+		record.ReportNamespace = r.OperatorNamespace
+	}
+	for ns := range run.removed.counts {
+		record.Namespaces = append(record.Namespaces, ns)
+	}
+	sort.Strings(record.Namespaces)
+	if runErr != nil {
+		record.Error = runErr.Error()
+	}
+
+	recorder, err := r.changeRecorder(ctx, webhook)
+	if err == nil {
+		err = recorder.File(ctx, record)
+	}
+	if err != nil {
+		logger.Error(err, "Failed to file change record")
+		r.runEventf(run, corev1.EventTypeWarning, "ChangeRecordFailed",
+			"Could not file the change record for a run that deleted %d pod(s): %v", deleted, err)
+		return
+	}
+	logger.Info("Filed change record", "podsDeleted", deleted)
+}
+
+// changeRecorder returns the ChangeRecorder of the webhook, with its template parsed
+// and its token read.
+func (r *PodCleanupPolicyReconciler) changeRecorder(ctx context.Context, webhook *cleanupv1.ChangeRecordWebhook) (*notify.ChangeRecorder, error) {
+	var tmpl *template.Template
+	if webhook.Template != "" {
+		var err error
+		if tmpl, err = cleanupv1.ParseChangeRecordTemplate(webhook.Template); err != nil {
+			return nil, err
+		}
+	}
+	recorder := notify.NewChangeRecorder(webhook.URL, tmpl)
+	recorder.ContentType = webhook.ContentType
+	recorder.Headers = webhook.Headers
+	if webhook.SecretRef != nil {
+		token, err := r.secretValue(ctx, webhook.SecretRef)
+		if err != nil {
+			return nil, err
+		}
+		recorder.Token = token
+	}
+	return recorder, nil
+}
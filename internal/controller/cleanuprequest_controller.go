@@ -0,0 +1,263 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	cleanupv1 "github.com/aravindavvaru/pod-cleanup-operator/api/v1"
+)
+
+// CleanupRequestReconciler executes CleanupRequests.
+type CleanupRequestReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	// Recorder emits Events on requests.
+	Recorder record.EventRecorder
+	// Policies runs the requested policies, sharing rate limits and quotas with
+	// scheduled runs.
+	Policies *PodCleanupPolicyReconciler
+}
+
+//+kubebuilder:rbac:groups=cleanup.example.com,resources=cleanuprequests,verbs=get;list;watch
+//+kubebuilder:rbac:groups=cleanup.example.com,resources=cleanuprequests/status,verbs=get;update;patch
+
+// Reconcile executes a new CleanupRequest exactly once. Requests that have started are
+// never run again: a request found Running was interrupted by a controller restart and
+// is marked Failed.
+func (r *CleanupRequestReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	request := &cleanupv1.CleanupRequest{}
+	if err := r.Get(ctx, req.NamespacedName, request); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	switch request.Status.Phase {
+	case cleanupv1.RunPhaseSucceeded, cleanupv1.RunPhaseFailed:
+		return ctrl.Result{}, nil
+	case cleanupv1.RunPhaseRunning:
+		return ctrl.Result{}, r.fail(ctx, request, "Interrupted", "Run was interrupted before it completed")
+	}
+
+	if errs := request.Validate(); len(errs) > 0 {
+		return ctrl.Result{}, r.fail(ctx, request, "InvalidSpec", errs.ToAggregate().Error())
+	}
+
+	policy := &cleanupv1.PodCleanupPolicy{}
+	if err := r.Get(ctx, client.ObjectKey{Name: request.Spec.PolicyName}, policy); err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, r.fail(ctx, request, "PolicyNotFound",
+				fmt.Sprintf("PodCleanupPolicy %q not found", request.Spec.PolicyName))
+		}
+		return ctrl.Result{}, err
+	}
+	if errs := policy.Validate(); len(errs) > 0 {
+		return ctrl.Result{}, r.fail(ctx, request, "InvalidPolicy",
+			fmt.Sprintf("PodCleanupPolicy %q is invalid: %v", policy.Name, errs.ToAggregate()))
+	}
+	if err := disabledFeatureError(policy); err != nil {
+		return ctrl.Result{}, r.fail(ctx, request, "FeatureDisabled",
+			fmt.Sprintf("PodCleanupPolicy %q needs a disabled feature: %v", policy.Name, err))
+	}
+	if policy.Spec.Action == cleanupv1.ActionProtect {
+		return ctrl.Result{}, r.fail(ctx, request, "ProtectPolicy",
+			fmt.Sprintf("PodCleanupPolicy %q is a Protect policy and has no runs", policy.Name))
+	}
+	if policy.Spec.Suspend {
+		return ctrl.Result{}, r.fail(ctx, request, "PolicySuspended",
+			fmt.Sprintf("PodCleanupPolicy %q is suspended", policy.Name))
+	}
+
+	// The selectors narrow this run only; the policy itself is never updated.
+	policy.Spec.NamespaceSelector = intersectSelectors(policy.Spec.NamespaceSelector, request.Spec.NamespaceSelector)
+	policy.Spec.PodSelector = intersectSelectors(policy.Spec.PodSelector, request.Spec.PodSelector)
+	if request.Spec.Preview {
+		return ctrl.Result{}, r.preview(ctx, request, policy)
+	}
+
+	run, err := r.Policies.newRun(ctx, policy, nil)
+	if errors.IsNotFound(err) {
+		return ctrl.Result{}, r.fail(ctx, request, "DefaultsNotFound",
+			fmt.Sprintf("ClusterCleanupDefaults %q not found", policy.Spec.DefaultsFrom))
+	}
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	run.limiter = r.Policies.policyLimiter(policy.Name, run.spec.RateLimit)
+	run.dryRun = run.dryRun || request.Spec.DryRun
+	run.acknowledged = request.Spec.AcknowledgeAlert
+	run.explaining = policy.Spec.Explain && run.dryRun
+
+	// Record that the run started before running it, so it is never repeated.
+	now := metav1.NewTime(r.Policies.Clock.Now())
+	if err := updateStatus(ctx, r.Client, request, func() {
+		request.Status.Phase = cleanupv1.RunPhaseRunning
+		request.Status.StartTime = &now
+		request.Status.DryRun = run.dryRun
+	}); err != nil {
+		return ctrl.Result{}, err
+	}
+	logger.Info("Executing CleanupRequest", "policy", policy.Name, "dryRun", run.dryRun)
+
+	r.Policies.startRunRecord(ctx, run, cleanupv1.TriggerRequest)
+	runCtx, done := r.Policies.trackRun(ctx, run)
+	deleted, runErr := r.Policies.runWithHooks(runCtx, run)
+	done()
+	r.Policies.closeArchive(ctx, run)
+	r.Policies.finishRunRecord(ctx, run, deleted, runErr)
+	r.Policies.pruneRunHistory(ctx, policy)
+	r.Policies.writeRunReport(ctx, run, cleanupv1.TriggerRequest, deleted, runErr)
+
+	completed := metav1.NewTime(r.Policies.Clock.Now())
+	phase, eventType, reason := cleanupv1.RunPhaseSucceeded, corev1.EventTypeNormal, "RunSucceeded"
+	message := fmt.Sprintf("%d pod(s) deleted", deleted)
+	switch {
+	case runErr != nil:
+		phase, eventType, reason = cleanupv1.RunPhaseFailed, corev1.EventTypeWarning, "RunFailed"
+		message = runErr.Error()
+	case run.dryRun:
+		message = fmt.Sprintf("%d pod(s) would be deleted", deleted)
+	}
+	r.Recorder.AnnotatedEventf(request, map[string]string{cleanupv1.LabelRunID: string(run.id)},
+		eventType, reason, "%s", message)
+	if err := updateStatus(ctx, r.Client, request, func() {
+		status := &request.Status
+		status.Phase = phase
+		status.Message = message
+		status.CompletionTime = &completed
+		status.PodsDeleted = int32(deleted)
+		status.PodsSkippedByPriority = int32(run.skippedByPriority)
+		status.PodsProtected = int32(run.protected)
+		status.PodsRetained = int32(run.retained)
+		status.PodsDeferredByQuota = int32(run.deferredByQuota)
+		status.PodsDeferredByBudget = int32(run.deferredByBudget)
+		if run.record != nil {
+			status.RunName = run.record.Name
+		}
+	}); err != nil {
+		logger.Error(err, "Failed to update CleanupRequest status")
+		return ctrl.Result{}, err
+	}
+
+	r.Policies.sendNotifications(ctx, run, deleted, runErr)
+	r.Policies.fileChangeRecord(ctx, run, cleanupv1.TriggerRequest, deleted, runErr)
+	r.Policies.auditRunFinished(ctx, run, deleted, runErr)
+	return ctrl.Result{}, nil
+}
+
+// preview evaluates the request's policy as a preview run, which deletes, annotates
+// and records nothing, and lists the pods a run would delete in the request's status,
+// with the decision taken for every evaluated pod if the request explains.
+func (r *CleanupRequestReconciler) preview(ctx context.Context, request *cleanupv1.CleanupRequest, policy *cleanupv1.PodCleanupPolicy) error {
+	run, err := r.Policies.previewRun(ctx, policy)
+	if errors.IsNotFound(err) {
+		return r.fail(ctx, request, "DefaultsNotFound",
+			fmt.Sprintf("ClusterCleanupDefaults %q not found", policy.Spec.DefaultsFrom))
+	}
+	if err != nil {
+		return err
+	}
+	run.explaining = request.Spec.Explain
+
+	now := metav1.NewTime(r.Policies.Clock.Now())
+	if err := updateStatus(ctx, r.Client, request, func() {
+		request.Status.Phase = cleanupv1.RunPhaseRunning
+		request.Status.StartTime = &now
+		request.Status.DryRun = true
+	}); err != nil {
+		return err
+	}
+	log.FromContext(ctx).Info("Previewing CleanupRequest", "policy", policy.Name)
+
+	runCtx, done := r.Policies.trackRun(ctx, run)
+	_, runErr := r.Policies.runCleanup(runCtx, run)
+	done()
+
+	completed := metav1.NewTime(r.Policies.Clock.Now())
+	if runErr != nil {
+		r.Recorder.Event(request, corev1.EventTypeWarning, "PreviewFailed", runErr.Error())
+		return updateStatus(ctx, r.Client, request, func() {
+			request.Status.Phase = cleanupv1.RunPhaseFailed
+			request.Status.CompletionTime = &completed
+			request.Status.Message = runErr.Error()
+		})
+	}
+	message := fmt.Sprintf("%d pod(s) would be deleted", len(run.candidates))
+	r.Recorder.Event(request, corev1.EventTypeNormal, "PreviewSucceeded", message)
+	return updateStatus(ctx, r.Client, request, func() {
+		status := &request.Status
+		status.Phase = cleanupv1.RunPhaseSucceeded
+		status.Message = message
+		status.CompletionTime = &completed
+		status.PodsDeleted = int32(len(run.candidates))
+		status.PodsSkippedByPriority = int32(run.skippedByPriority)
+		status.PodsProtected = int32(run.protected)
+		status.PodsRetained = int32(run.retained)
+		status.Candidates = previewCandidates(run.candidates, cleanupv1.MaxRequestCandidates)
+		status.Decisions = run.decisions
+		if omitted := len(run.decisions) - cleanupv1.MaxRecordedDecisions; omitted > 0 {
+			status.Decisions = run.decisions[:cleanupv1.MaxRecordedDecisions]
+			status.DecisionsOmitted = int32(omitted)
+		}
+	})
+}
+
+// intersectSelectors returns a selector matching the objects both selectors match. A
+// nil selector matches everything.
+func intersectSelectors(a, b *metav1.LabelSelector) *metav1.LabelSelector {
+	if b == nil {
+		return a
+	}
+	if a == nil {
+		return b.DeepCopy()
+	}
+	selector := a.DeepCopy()
+	selector.MatchExpressions = append(selector.MatchExpressions, b.MatchExpressions...)
+	// The labels of b become expressions, so a label both require with different
+	// values matches nothing.
+	keys := make([]string, 0, len(b.MatchLabels))
+	for key := range b.MatchLabels {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		selector.MatchExpressions = append(selector.MatchExpressions, metav1.LabelSelectorRequirement{
+			Key:      key,
+			Operator: metav1.LabelSelectorOpIn,
+			Values:   []string{b.MatchLabels[key]},
+		})
+	}
+	return selector
+}
+
+// fail marks the request Failed without running it.
+func (r *CleanupRequestReconciler) fail(ctx context.Context, request *cleanupv1.CleanupRequest, reason, message string) error {
+	now := metav1.NewTime(r.Policies.Clock.Now())
+	r.Recorder.Event(request, corev1.EventTypeWarning, reason, message)
+	return updateStatus(ctx, r.Client, request, func() {
+		request.Status.Phase = cleanupv1.RunPhaseFailed
+		request.Status.CompletionTime = &now
+		request.Status.Message = message
+	})
+}
+
+// SetupWithManager registers the controller with the manager.
+func (r *CleanupRequestReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		// The spec is immutable, so only creations need a reconcile; status updates
+		// made while executing the request are dropped.
+		For(&cleanupv1.CleanupRequest{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
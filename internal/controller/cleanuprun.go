@@ -0,0 +1,148 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	cleanupv1 "github.com/aravindavvaru/pod-cleanup-operator/api/v1"
+)
+
+// defaultRunHistoryLimit is the number of finished CleanupRuns kept per policy
+// when the policy does not set runHistoryLimit.
+const defaultRunHistoryLimit = 10
+
+// startRunRecord creates the CleanupRun recording this run. Failing to record a run
+// is logged but does not prevent the run.
+func (r *PodCleanupPolicyReconciler) startRunRecord(ctx context.Context, run *cleanupRun, trigger cleanupv1.RunTrigger) {
+	logger := log.FromContext(ctx)
+
+	record := &cleanupv1.CleanupRun{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: run.policy.Name + "-",
+			Labels:       map[string]string{cleanupv1.LabelPolicy: run.policy.Name, cleanupv1.LabelRunID: string(run.id)},
+		},
+		Spec: cleanupv1.CleanupRunSpec{
+			PolicyName: run.policy.Name,
+			RunID:      run.id,
+			Trigger:    trigger,
+			DryRun:     run.dryRun,
+		},
+	}
+	if err := controllerutil.SetControllerReference(run.policy, record, r.Scheme); err != nil {
+		logger.Error(err, "Failed to set owner of CleanupRun")
+		return
+	}
+	if err := r.Create(ctx, record); err != nil {
+		logger.Error(err, "Failed to create CleanupRun")
+		return
+	}
+
+	now := metav1.NewTime(r.Clock.Now())
+	if err := updateStatus(ctx, r.Client, record, func() {
+		record.Status = cleanupv1.CleanupRunStatus{Phase: cleanupv1.RunPhaseRunning, StartTime: &now}
+	}); err != nil {
+		logger.Error(err, "Failed to update CleanupRun status", "cleanupRun", record.Name)
+	}
+	run.record = record
+}
+
+// runEventf records an Event about the run's policy, annotated with the run ID.
+func (r *PodCleanupPolicyReconciler) runEventf(run *cleanupRun, eventType, reason, messageFmt string, args ...any) {
+	r.Recorder.AnnotatedEventf(run.policy, map[string]string{cleanupv1.LabelRunID: string(run.id)},
+		eventType, reason, messageFmt, args...)
+}
+
+// reportProgress records how far the run has come in its CleanupRun, at most once
+// per ProgressInterval.
+func (r *PodCleanupPolicyReconciler) reportProgress(ctx context.Context, run *cleanupRun, processed, total, deleted int) {
+	if run.record == nil || !r.progressDue(&run.progressWritten) {
+		return
+	}
+	if err := updateStatus(ctx, r.Client, run.record, func() {
+		run.record.Status.NamespacesProcessed = int32(processed)
+		run.record.Status.NamespacesTotal = int32(total)
+		run.record.Status.PodsDeleted = int32(deleted)
+	}); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to update CleanupRun progress", "cleanupRun", run.record.Name)
+	}
+}
+
+// finishRunRecord records the outcome of the run in its CleanupRun.
+func (r *PodCleanupPolicyReconciler) finishRunRecord(ctx context.Context, run *cleanupRun, deleted int, runErr error) {
+	if run.record == nil {
+		return
+	}
+	now := metav1.NewTime(r.Clock.Now())
+	if err := updateStatus(ctx, r.Client, run.record, func() {
+		status := &run.record.Status
+		status.CompletionTime = &now
+		status.PodsDeleted = int32(deleted)
+		if status.NamespacesTotal > 0 {
+			status.NamespacesProcessed = status.NamespacesTotal
+		}
+		status.Decisions = run.decisions
+		if omitted := len(run.decisions) - cleanupv1.MaxRecordedDecisions; omitted > 0 {
+			status.Decisions = run.decisions[:cleanupv1.MaxRecordedDecisions]
+			status.DecisionsOmitted = int32(omitted)
+		}
+		if run.archive != nil {
+			status.PodsArchived = int32(run.archive.archived)
+			status.ArchiveLocations = run.archive.locations
+		}
+		if runErr != nil {
+			status.Phase = cleanupv1.RunPhaseFailed
+			status.Message = runErr.Error()
+		} else {
+			status.Phase = cleanupv1.RunPhaseSucceeded
+			status.Message = fmt.Sprintf("%d pod(s) deleted", deleted)
+			if run.dryRun {
+				status.Message = fmt.Sprintf("%d pod(s) would be deleted", deleted)
+			}
+		}
+	}); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to update CleanupRun status", "cleanupRun", run.record.Name)
+	}
+}
+
+// pruneRunHistory deletes the oldest finished CleanupRuns of the policy beyond its
+// runHistoryLimit.
+func (r *PodCleanupPolicyReconciler) pruneRunHistory(ctx context.Context, policy *cleanupv1.PodCleanupPolicy) {
+	logger := log.FromContext(ctx)
+
+	limit := defaultRunHistoryLimit
+	if policy.Spec.RunHistoryLimit != nil {
+		limit = int(*policy.Spec.RunHistoryLimit)
+	}
+
+	runList := &cleanupv1.CleanupRunList{}
+	if err := r.List(ctx, runList, client.MatchingLabels{cleanupv1.LabelPolicy: policy.Name}); err != nil {
+		logger.Error(err, "Failed to list CleanupRuns for pruning")
+		return
+	}
+
+	var finished []*cleanupv1.CleanupRun
+	for i := range runList.Items {
+		if runList.Items[i].Status.Phase != cleanupv1.RunPhaseRunning {
+			finished = append(finished, &runList.Items[i])
+		}
+	}
+	if len(finished) <= limit {
+		return
+	}
+
+	// Newest first, so everything past the limit is the oldest history.
+	sort.Slice(finished, func(i, j int) bool {
+		return finished[j].CreationTimestamp.Before(&finished[i].CreationTimestamp)
+	})
+	for _, old := range finished[limit:] {
+		if err := r.Delete(ctx, old); client.IgnoreNotFound(err) != nil {
+			logger.Error(err, "Failed to prune CleanupRun", "cleanupRun", old.Name)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	cleanupv1 "github.com/aravindavvaru/pod-cleanup-operator/api/v1"
+)
+
+// CleanupSimulationReconciler evaluates CleanupSimulations.
+type CleanupSimulationReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	// Recorder emits Events on simulations.
+	Recorder record.EventRecorder
+	// Policies evaluates the simulated policies against the same competing policies,
+	// retention policies and budgets as real runs.
+	Policies *PodCleanupPolicyReconciler
+}
+
+//+kubebuilder:rbac:groups=cleanup.example.com,resources=cleanupsimulations,verbs=get;list;watch
+//+kubebuilder:rbac:groups=cleanup.example.com,resources=cleanupsimulations/status,verbs=get;update;patch
+
+// Reconcile evaluates a new CleanupSimulation exactly once, as a preview run of its
+// policy spec that deletes, annotates and records nothing. A simulation found Running
+// was interrupted by a controller restart and is marked Failed.
+func (r *CleanupSimulationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	sim := &cleanupv1.CleanupSimulation{}
+	if err := r.Get(ctx, req.NamespacedName, sim); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	switch sim.Status.Phase {
+	case cleanupv1.RunPhaseSucceeded, cleanupv1.RunPhaseFailed:
+		return ctrl.Result{}, nil
+	case cleanupv1.RunPhaseRunning:
+		return ctrl.Result{}, r.fail(ctx, sim, "Interrupted", "Simulation was interrupted before it completed")
+	}
+
+	if errs := sim.Validate(); len(errs) > 0 {
+		return ctrl.Result{}, r.fail(ctx, sim, "InvalidPolicy", errs.ToAggregate().Error())
+	}
+	// The simulated policy takes the simulation's name and UID, so it competes with
+	// every existing policy and shares no state with any of them.
+	policy := &cleanupv1.PodCleanupPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: sim.Name, UID: sim.UID},
+		Spec:       *sim.Spec.Policy.DeepCopy(),
+	}
+	if err := disabledFeatureError(policy); err != nil {
+		return ctrl.Result{}, r.fail(ctx, sim, "FeatureDisabled", fmt.Sprintf("Policy needs a disabled feature: %v", err))
+	}
+	if policy.Spec.Action == cleanupv1.ActionProtect {
+		return ctrl.Result{}, r.fail(ctx, sim, "ProtectPolicy", "Protect policies have no runs to simulate")
+	}
+
+	run, err := r.Policies.previewRun(ctx, policy)
+	if errors.IsNotFound(err) {
+		return ctrl.Result{}, r.fail(ctx, sim, "DefaultsNotFound",
+			fmt.Sprintf("ClusterCleanupDefaults %q not found", policy.Spec.DefaultsFrom))
+	}
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	now := metav1.NewTime(r.Policies.Clock.Now())
+	if err := updateStatus(ctx, r.Client, sim, func() {
+		sim.Status.Phase = cleanupv1.RunPhaseRunning
+		sim.Status.StartTime = &now
+	}); err != nil {
+		return ctrl.Result{}, err
+	}
+	logger.Info("Evaluating CleanupSimulation")
+
+	_, runErr := r.Policies.runCleanup(ctx, run)
+	var budgets []cleanupv1.BudgetImpact
+	deferred := 0
+	if runErr == nil {
+		budgets, deferred, runErr = r.Policies.simulateBudgets(ctx, run.candidates)
+	}
+
+	completed := metav1.NewTime(r.Policies.Clock.Now())
+	if runErr != nil {
+		r.Recorder.Event(sim, corev1.EventTypeWarning, "SimulationFailed", runErr.Error())
+		return ctrl.Result{}, updateStatus(ctx, r.Client, sim, func() {
+			sim.Status.Phase = cleanupv1.RunPhaseFailed
+			sim.Status.CompletionTime = &completed
+			sim.Status.Message = runErr.Error()
+		})
+	}
+	message := fmt.Sprintf("%d pod(s) would be deleted", len(run.candidates))
+	if deferred > 0 {
+		message += fmt.Sprintf(", %d of them deferred by ClusterCleanupBudgets", deferred)
+	}
+	if len(run.forbiddenNamespaces) > 0 {
+		message += fmt.Sprintf("; pods of %d namespace(s) could not be listed: %s",
+			len(run.forbiddenNamespaces), joinCapped(run.forbiddenNamespaces, maxReportedNamespaces))
+	}
+	r.Recorder.Event(sim, corev1.EventTypeNormal, "SimulationSucceeded", message)
+	samples := previewStatus(completed, run.candidates).Candidates
+	if len(samples) > cleanupv1.MaxSimulatedSamplePods {
+		samples = samples[:cleanupv1.MaxSimulatedSamplePods]
+	}
+	if err := updateStatus(ctx, r.Client, sim, func() {
+		status := &sim.Status
+		status.Phase = cleanupv1.RunPhaseSucceeded
+		status.CompletionTime = &completed
+		status.Message = message
+		status.CandidateCount = int32(len(run.candidates))
+		status.ByNamespace = candidatesByNamespace(run.candidates)
+		status.SamplePods = samples
+		status.PodsSkippedByPriority = int32(run.skippedByPriority)
+		status.PodsProtected = int32(run.protected)
+		status.PodsRetained = int32(run.retained)
+		status.PodsDeniedByDecision = int32(run.decisionDenied)
+		status.PodsDeferredByBudget = int32(deferred)
+		status.Budgets = budgets
+	}); err != nil {
+		logger.Error(err, "Failed to update CleanupSimulation status")
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// candidatesByNamespace counts candidates per namespace, largest first, capped at
+// cleanupv1.MaxSimulatedNamespaces.
+func candidatesByNamespace(candidates []Candidate) []cleanupv1.CleanupCount {
+	counts := make(map[string]int32)
+	for _, c := range candidates {
+		counts[c.Namespace]++
+	}
+	byNamespace := make([]cleanupv1.CleanupCount, 0, len(counts))
+	for namespace, count := range counts {
+		byNamespace = append(byNamespace, cleanupv1.CleanupCount{Name: namespace, Count: count})
+	}
+	sort.Slice(byNamespace, func(i, j int) bool {
+		if byNamespace[i].Count != byNamespace[j].Count {
+			return byNamespace[i].Count > byNamespace[j].Count
+		}
+		return byNamespace[i].Name < byNamespace[j].Name
+	})
+	if len(byNamespace) > cleanupv1.MaxSimulatedNamespaces {
+		byNamespace = byNamespace[:cleanupv1.MaxSimulatedNamespaces]
+	}
+	return byNamespace
+}
+
+// fail marks the simulation Failed without evaluating it.
+func (r *CleanupSimulationReconciler) fail(ctx context.Context, sim *cleanupv1.CleanupSimulation, reason, message string) error {
+	now := metav1.NewTime(r.Policies.Clock.Now())
+	r.Recorder.Event(sim, corev1.EventTypeWarning, reason, message)
+	return updateStatus(ctx, r.Client, sim, func() {
+		sim.Status.Phase = cleanupv1.RunPhaseFailed
+		sim.Status.CompletionTime = &now
+		sim.Status.Message = message
+	})
+}
+
+// SetupWithManager registers the controller with the manager.
+func (r *CleanupSimulationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		// The spec is immutable, so only creations need a reconcile.
+		For(&cleanupv1.CleanupSimulation{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
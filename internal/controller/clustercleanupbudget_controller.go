@@ -0,0 +1,122 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	cleanupv1 "github.com/aravindavvaru/pod-cleanup-operator/api/v1"
+)
+
+// budgetStatusInterval is how often the status of a budget is refreshed, as its window
+// slides without any event.
+const budgetStatusInterval = 30 * time.Second
+
+// ClusterCleanupBudgetReconciler reports the usage of ClusterCleanupBudgets. The
+// budgets are enforced by the policy runs.
+type ClusterCleanupBudgetReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	// Policies tracks the deletions counted against each budget.
+	Policies *PodCleanupPolicyReconciler
+}
+
+//+kubebuilder:rbac:groups=cleanup.example.com,resources=clustercleanupbudgets/status,verbs=get;update;patch
+
+// Reconcile refreshes the status of a budget: its deletions within the window and
+// whether it is exhausted.
+func (r *ClusterCleanupBudgetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	budget := &cleanupv1.ClusterCleanupBudget{}
+	if err := r.Get(ctx, req.NamespacedName, budget); err != nil {
+		if errors.IsNotFound(err) {
+			r.Policies.forgetBudget(req.Name)
+			budgetDeletions.DeleteLabelValues(req.Name)
+			budgetExhausted.DeleteLabelValues(req.Name)
+		}
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if errs := budget.Validate(); len(errs) > 0 {
+		return ctrl.Result{}, updateStatus(ctx, r.Client, budget, func() {
+			budget.Status.ObservedGeneration = budget.Generation
+			setBudgetCondition(budget, cleanupv1.ConditionReady, metav1.ConditionFalse, cleanupv1.ReasonInvalidSpec, errs.ToAggregate().Error())
+			meta.RemoveStatusCondition(&budget.Status.Conditions, cleanupv1.ConditionExhausted)
+		})
+	}
+
+	deletions, exhaustedNamespaces, err := r.Policies.budgetStatus(ctx, budget)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	exhausted := len(exhaustedNamespaces) > 0 ||
+		(budget.Spec.MaxDeletions != nil && deletions >= int(*budget.Spec.MaxDeletions))
+	budgetDeletions.WithLabelValues(budget.Name).Set(float64(deletions))
+	if exhausted {
+		budgetExhausted.WithLabelValues(budget.Name).Set(1)
+	} else {
+		budgetExhausted.WithLabelValues(budget.Name).Set(0)
+	}
+
+	err = updateStatus(ctx, r.Client, budget, func() {
+		status := &budget.Status
+		status.ObservedGeneration = budget.Generation
+		status.Deletions = int32(deletions)
+		status.ExhaustedNamespaces = exhaustedNamespaces
+		if len(exhaustedNamespaces) > maxReportedNamespaces {
+			status.ExhaustedNamespaces = exhaustedNamespaces[:maxReportedNamespaces]
+		}
+		setBudgetCondition(budget, cleanupv1.ConditionReady, metav1.ConditionTrue, cleanupv1.ReasonEnforced,
+			"Deletions of every policy count against the budget")
+		switch {
+		case budget.Spec.MaxDeletions != nil && deletions >= int(*budget.Spec.MaxDeletions):
+			setBudgetCondition(budget, cleanupv1.ConditionExhausted, metav1.ConditionTrue, cleanupv1.ReasonMaxDeletionsReached,
+				fmt.Sprintf("%d pods were removed within %s; further deletions are deferred", deletions, budgetWindow(budget)))
+		case len(exhaustedNamespaces) > 0:
+			setBudgetCondition(budget, cleanupv1.ConditionExhausted, metav1.ConditionTrue, cleanupv1.ReasonNamespaceMaxDeletionsReached,
+				fmt.Sprintf("Deletions are deferred in namespaces that used up their budget: %s",
+					strings.Join(status.ExhaustedNamespaces, ", ")))
+		default:
+			setBudgetCondition(budget, cleanupv1.ConditionExhausted, metav1.ConditionFalse, cleanupv1.ReasonWithinBudget,
+				fmt.Sprintf("%d pods were removed within %s", deletions, budgetWindow(budget)))
+		}
+	})
+	return ctrl.Result{RequeueAfter: budgetStatusInterval}, err
+}
+
+// setBudgetCondition sets a condition of the budget, keeping its transition time
+// unless its status changes.
+func setBudgetCondition(budget *cleanupv1.ClusterCleanupBudget, condType string, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&budget.Status.Conditions, metav1.Condition{
+		Type:               condType,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: budget.Generation,
+	})
+}
+
+// budgetWindow returns the window of a valid budget.
+func budgetWindow(budget *cleanupv1.ClusterCleanupBudget) string {
+	if budget.Spec.Window == "" {
+		return defaultBudgetWindow.String()
+	}
+	return budget.Spec.Window
+}
+
+// SetupWithManager registers the controller with the manager.
+func (r *ClusterCleanupBudgetReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		// Status updates are dropped; the status is refreshed periodically instead.
+		For(&cleanupv1.ClusterCleanupBudget{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
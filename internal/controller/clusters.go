@@ -0,0 +1,149 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	cleanupv1 "github.com/aravindavvaru/pod-cleanup-operator/api/v1"
+)
+
+//+kubebuilder:rbac:groups=cleanup.example.com,resources=clustertargets,verbs=get;list;watch
+
+// workloadClusterTimeout bounds every request to a workload cluster, so an
+// unreachable cluster cannot stall a run.
+const workloadClusterTimeout = 30 * time.Second
+
+// workloadCluster is the client of a ClusterTarget and the kubeconfig it was built
+// from.
+type workloadCluster struct {
+	kubeconfig string
+	client     client.Client
+}
+
+// clusterClient returns an uncached client of the named ClusterTarget's workload
+// cluster. Clients are kept until the kubeconfig changes.
+func (r *PodCleanupPolicyReconciler) clusterClient(ctx context.Context, name string) (client.Client, error) {
+	target := &cleanupv1.ClusterTarget{}
+	if err := r.Get(ctx, client.ObjectKey{Name: name}, target); err != nil {
+		return nil, fmt.Errorf("getting ClusterTarget %s: %w", name, err)
+	}
+	kubeconfig, err := r.secretValue(ctx, &target.Spec.KubeconfigSecretRef)
+	if err != nil {
+		return nil, fmt.Errorf("reading kubeconfig of ClusterTarget %s: %w", name, err)
+	}
+
+	r.workloadClustersMu.Lock()
+	defer r.workloadClustersMu.Unlock()
+	if cluster, ok := r.workloadClusters[name]; ok && cluster.kubeconfig == kubeconfig {
+		return cluster.client, nil
+	}
+	cfg, err := clientcmd.RESTConfigFromKubeConfig([]byte(kubeconfig))
+	if err != nil {
+		return nil, fmt.Errorf("parsing kubeconfig of ClusterTarget %s: %w", name, err)
+	}
+	cfg.Timeout = workloadClusterTimeout
+	c, err := client.New(cfg, client.Options{Scheme: r.Scheme})
+	if err != nil {
+		return nil, fmt.Errorf("creating client for ClusterTarget %s: %w", name, err)
+	}
+	if r.workloadClusters == nil {
+		r.workloadClusters = make(map[string]*workloadCluster)
+	}
+	r.workloadClusters[name] = &workloadCluster{kubeconfig: kubeconfig, client: c}
+	return c, nil
+}
+
+// inCluster points the run at the workload cluster of the named ClusterTarget,
+// read and written through c, and drops what it memoized about the previous one.
+func (run *cleanupRun) inCluster(name string, c client.Client) {
+	run.clusterName = name
+	run.cluster = c
+	run.clusterReader = c
+	run.podClient = c
+	run.podCache = nil
+	run.nodes = nil
+	run.owners = nil
+	run.companionObjects = nil
+	run.endpointSlices = nil
+}
+
+// targetsCluster reports whether the policy runs in the named cluster, "" being the
+// operator's own.
+func targetsCluster(policy *cleanupv1.PodCleanupPolicy, name string) bool {
+	if len(policy.Spec.ClusterRefs) == 0 {
+		return name == ""
+	}
+	return slices.Contains(policy.Spec.ClusterRefs, name)
+}
+
+// qualifiedNamespace identifies namespace across clusters, e.g. in
+// ClusterCleanupBudgets and condition messages: it is the namespace itself in the
+// operator's own cluster, and "<cluster>/<namespace>" in a workload cluster.
+func (run *cleanupRun) qualifiedNamespace(namespace string) string {
+	if run.clusterName == "" {
+		return namespace
+	}
+	return run.clusterName + "/" + namespace
+}
+
+// cleanupClusters cleans up the workload clusters of the policy's clusterRefs in
+// order, starting at the cluster a resumed run was interrupted in, and returns the
+// pods deleted in all of them. A cluster that cannot be reached or cleaned up is
+// reported in the run's cluster results and does not stop the run.
+func (r *PodCleanupPolicyReconciler) cleanupClusters(ctx context.Context, run *cleanupRun, total int) (int, error) {
+	logger := log.FromContext(ctx)
+	refs := run.policy.Spec.ClusterRefs
+	start := 0
+	if run.resume != nil {
+		for i, name := range refs {
+			if name == run.resume.Cluster {
+				start = i
+			}
+		}
+	}
+	for _, name := range refs[start:] {
+		result := cleanupv1.ClusterRunStatus{Name: name}
+		before, deferredBefore := total, run.deferredByQuota+run.deferredByBudget
+		c, err := r.clusterClient(ctx, name)
+		if err == nil {
+			run.inCluster(name, c)
+			var processed int
+			total, processed, err = r.cleanupCluster(ctx, run, total)
+			result.NamespacesProcessed = int32(processed)
+		}
+		result.PodsDeleted = int32(total - before)
+		result.PodsDeferred = int32(run.deferredByQuota + run.deferredByBudget - deferredBefore)
+		if ctx.Err() != nil {
+			run.clusterResults = append(run.clusterResults, result)
+			return total, err
+		}
+		if err != nil {
+			logger.Error(err, "Error cleaning up workload cluster", "cluster", name)
+			result.Error = err.Error()
+			if isTransient(err) {
+				run.transientFailures++
+			}
+		}
+		run.clusterResults = append(run.clusterResults, result)
+		// Only the first cluster of a resumed run starts past its beginning.
+		run.resume = nil
+	}
+	return total, nil
+}
+
+// failedClusters returns the workload clusters the run could not clean up.
+func (run *cleanupRun) failedClusters() []string {
+	var failed []string
+	for _, result := range run.clusterResults {
+		if result.Error != "" {
+			failed = append(failed, result.Name)
+		}
+	}
+	return failed
+}
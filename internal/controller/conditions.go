@@ -0,0 +1,70 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	cleanupv1 "github.com/aravindavvaru/pod-cleanup-operator/api/v1"
+)
+
+// ensureCondition sets a condition of the policy, writing the status only if the
+// condition's status or reason changes.
+func (r *PodCleanupPolicyReconciler) ensureCondition(ctx context.Context, policy *cleanupv1.PodCleanupPolicy, condType string, status metav1.ConditionStatus, reason, message string) error {
+	if current := meta.FindStatusCondition(policy.Status.Conditions, condType); current != nil &&
+		current.Status == status && current.Reason == reason {
+		return nil
+	}
+	return updateStatus(ctx, r.Client, policy, func() {
+		r.setCondition(policy, condType, status, reason, message)
+	})
+}
+
+// syncScheduleValid sets the ScheduleValid condition of a policy whose schedule
+// parsed. A schedule without runs outside its blackout windows is flagged invalid
+// when its next run is computed.
+func (r *PodCleanupPolicyReconciler) syncScheduleValid(ctx context.Context, policy *cleanupv1.PodCleanupPolicy, schedule cron.Schedule, scheduleKey string) error {
+	if schedule == nil {
+		return r.ensureCondition(ctx, policy, cleanupv1.ConditionScheduleValid, metav1.ConditionTrue,
+			cleanupv1.ReasonUnscheduled, "Policy has no schedule; it runs when reconciled")
+	}
+	if schedule.Next(r.Clock.Now()).IsZero() {
+		return nil
+	}
+	return r.ensureCondition(ctx, policy, cleanupv1.ConditionScheduleValid, metav1.ConditionTrue,
+		cleanupv1.ReasonScheduleParsed, fmt.Sprintf("Schedule %q is valid", scheduleKey))
+}
+
+// startProgressing sets the Progressing condition of the run's policy when the run
+// starts. Failures are logged; the status update at the end of the run clears the
+// condition either way.
+func (r *PodCleanupPolicyReconciler) startProgressing(ctx context.Context, run *cleanupRun, trigger cleanupv1.RunTrigger) {
+	policy := run.policy
+	if err := updateStatus(ctx, r.Client, policy, func() {
+		r.setCondition(policy, cleanupv1.ConditionProgressing, metav1.ConditionTrue, cleanupv1.ReasonRunInProgress,
+			fmt.Sprintf("Run %s (trigger %s) started at %s", run.id, trigger, run.started.UTC().Format(time.RFC3339)))
+	}); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to set Progressing condition")
+	}
+}
+
+// setBudgetExceeded sets the BudgetExceeded condition from the pods the run deferred.
+// Failed runs leave it as it was unless they deferred pods.
+func (r *PodCleanupPolicyReconciler) setBudgetExceeded(policy *cleanupv1.PodCleanupPolicy, run *cleanupRun, err error) {
+	switch {
+	case run.deferredByBudget > 0:
+		r.setCondition(policy, cleanupv1.ConditionBudgetExceeded, metav1.ConditionTrue, cleanupv1.ReasonBudgetExhausted,
+			fmt.Sprintf("The last run deferred %d pod(s) because a ClusterCleanupBudget is exhausted", run.deferredByBudget))
+	case run.deferredByQuota > 0:
+		r.setCondition(policy, cleanupv1.ConditionBudgetExceeded, metav1.ConditionTrue, cleanupv1.ReasonTenantQuotaExhausted,
+			fmt.Sprintf("The last run deferred %d pod(s) because their tenant exhausted its daily quota", run.deferredByQuota))
+	case err == nil:
+		r.setCondition(policy, cleanupv1.ConditionBudgetExceeded, metav1.ConditionFalse, cleanupv1.ReasonWithinBudget,
+			"The last run deferred no pods")
+	}
+}
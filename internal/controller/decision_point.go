@@ -0,0 +1,100 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	cleanupv1 "github.com/aravindavvaru/pod-cleanup-operator/api/v1"
+	"github.com/aravindavvaru/pod-cleanup-operator/internal/opa"
+)
+
+// decisionClient returns the client of the OperatorConfig's decision point, or nil if
+// none is configured. It is set up once per run.
+func (r *PodCleanupPolicyReconciler) decisionClient(ctx context.Context, run *cleanupRun) (*opa.Client, error) {
+	point := run.config.DecisionPoint
+	if point == nil {
+		return nil, nil
+	}
+	if run.opa != nil || run.opaErr != nil {
+		return run.opa, run.opaErr
+	}
+	timeout := opa.DefaultTimeout
+	if point.Timeout != "" {
+		// Validated with the OperatorConfig.
+		if d, err := cleanupv1.ParseDuration(point.Timeout); err == nil {
+			timeout = d
+		}
+	}
+	c := opa.New(point.URL, point.Path, timeout)
+	if point.SecretRef != nil {
+		token, err := r.secretValue(ctx, point.SecretRef)
+		if err != nil {
+			run.opaErr = fmt.Errorf("reading decision point credentials: %w", err)
+			return nil, run.opaErr
+		}
+		c.Token = token
+	}
+	run.opa = c
+	return c, nil
+}
+
+// skipDenied asks the decision point whether the run may act on the pod, and if it
+// may not, records the pod as denied and returns true. The query is read-only, so dry
+// runs and previews consult it as well and only list the pods a run would act on.
+func (r *PodCleanupPolicyReconciler) skipDenied(ctx context.Context, logger logr.Logger, run *cleanupRun, ns *corev1.Namespace, pod *corev1.Pod, podAge time.Duration, action, rule, explanation string) bool {
+	denial := r.decisionDenies(ctx, run, ns, pod, podAge, action, rule)
+	if denial == "" {
+		return false
+	}
+	logger.V(1).Info("Skipping pod denied by the decision point",
+		"namespace", pod.Namespace, "pod", pod.Name, "denial", denial)
+	run.explain(ctx, pod.Namespace, pod.Name, false, ReasonDecisionDenied, "%s, but %s", explanation, denial)
+	r.clearCandidateAnnotation(ctx, run, pod)
+	run.recordPod(pod, podAge, outcomeDecisionDenied)
+	run.decisionDenied++
+	return true
+}
+
+// decisionDenies asks the decision point whether the run may take action on the pod:
+// Delete, Evict or QuitSidecar. It returns why not if it may not. Pods the decision
+// point cannot decide on follow its failurePolicy; kept pods count as transient
+// failures, so the run is retried.
+func (r *PodCleanupPolicyReconciler) decisionDenies(ctx context.Context, run *cleanupRun, ns *corev1.Namespace, pod *corev1.Pod, podAge time.Duration, action, rule string) string {
+	c, err := r.decisionClient(ctx, run)
+	if c == nil && err == nil {
+		return ""
+	}
+	var decision opa.Decision
+	if err == nil {
+		decision, err = c.Decide(ctx, opa.Input{
+			Policy:     run.policy.Name,
+			Cluster:    run.clusterName,
+			Action:     action,
+			Rule:       rule,
+			AgeSeconds: int64(podAge.Seconds()),
+			Namespace:  opa.Namespace{Name: ns.Name, Labels: ns.Labels},
+			Pod:        pod,
+		})
+	}
+	if err != nil {
+		log.FromContext(ctx).Error(err, "Decision point could not decide on pod",
+			"namespace", pod.Namespace, "pod", pod.Name)
+		if run.config.DecisionPoint.FailurePolicy == cleanupv1.DecisionFailureAllow {
+			return ""
+		}
+		run.transientFailures++
+		return fmt.Sprintf("the decision point could not decide: %v", err)
+	}
+	if decision.Allow {
+		return ""
+	}
+	if decision.Reason != "" {
+		return "the decision point denies it: " + decision.Reason
+	}
+	return "the decision point denies it"
+}
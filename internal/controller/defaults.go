@@ -0,0 +1,61 @@
+package controller
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	cleanupv1 "github.com/aravindavvaru/pod-cleanup-operator/api/v1"
+)
+
+// defaultsFromIndex indexes policies by the ClusterCleanupDefaults they reference.
+const defaultsFromIndex = ".spec.defaultsFrom"
+
+// resolveSpec returns a copy of the policy spec with the referenced
+// ClusterCleanupDefaults merged in. The policy itself is never modified.
+func (r *PodCleanupPolicyReconciler) resolveSpec(ctx context.Context, policy *cleanupv1.PodCleanupPolicy) (*cleanupv1.PodCleanupPolicySpec, error) {
+	spec := policy.Spec.DeepCopy()
+	if spec.DefaultsFrom == "" {
+		return spec, nil
+	}
+
+	defaults := &cleanupv1.ClusterCleanupDefaults{}
+	if err := r.Get(ctx, client.ObjectKey{Name: spec.DefaultsFrom}, defaults); err != nil {
+		return nil, err
+	}
+	mergeDefaults(spec, &defaults.Spec)
+	return spec, nil
+}
+
+// mergeDefaults fills every inheritable field left unset in spec from defaults.
+func mergeDefaults(spec *cleanupv1.PodCleanupPolicySpec, defaults *cleanupv1.ClusterCleanupDefaultsSpec) {
+	if spec.GracePeriodSeconds == nil && defaults.GracePeriodSeconds != nil {
+		gracePeriod := *defaults.GracePeriodSeconds
+		spec.GracePeriodSeconds = &gracePeriod
+	}
+	if spec.RateLimit == nil && defaults.RateLimit != nil {
+		rateLimit := *defaults.RateLimit
+		spec.RateLimit = &rateLimit
+	}
+	if len(spec.Notifications) == 0 {
+		spec.Notifications = append(spec.Notifications, defaults.Notifications...)
+	}
+	if spec.Archive == nil && defaults.Archive != nil {
+		spec.Archive = defaults.Archive.DeepCopy()
+	}
+}
+
+// policiesForDefaults maps a ClusterCleanupDefaults to the policies referencing it.
+func (r *PodCleanupPolicyReconciler) policiesForDefaults(ctx context.Context, obj client.Object) []reconcile.Request {
+	policyList := &cleanupv1.PodCleanupPolicyList{}
+	if err := r.List(ctx, policyList, client.MatchingFields{defaultsFromIndex: obj.GetName()}); err != nil {
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(policyList.Items))
+	for _, policy := range policyList.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: policy.Name}})
+	}
+	return requests
+}
@@ -0,0 +1,77 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	cleanupv1 "github.com/aravindavvaru/pod-cleanup-operator/api/v1"
+)
+
+// warnBeforeDeletion reports whether a pod the run would delete is still within its
+// spec.warnBefore notice, and the time the notice runs out. A pod without a warning
+// from this policy, or with an expired one, is annotated with a new one; it is not
+// deleted by this run even if the annotation cannot be written. A warning expires
+// warnBefore after its deletion time: runs list every pod their selectors match and
+// clear the warnings of those that stop matching, but not of pods that leave the
+// selectors, so such a warning may be long past when the pod matches again.
+func (r *PodCleanupPolicyReconciler) warnBeforeDeletion(ctx context.Context, run *cleanupRun, pod *corev1.Pod) (bool, time.Time) {
+	warnBefore := run.policy.Spec.WarnBefore
+	if warnBefore == nil || warnBefore.Duration <= 0 || run.dryRun || run.maintenanceNodes != nil {
+		return false, time.Time{}
+	}
+
+	now := r.Clock.Now()
+	if pod.Annotations[cleanupv1.AnnotationCandidateOf] == run.policy.Name {
+		deletionAt, err := time.Parse(time.RFC3339, pod.Annotations[cleanupv1.AnnotationDeletionAt])
+		if err == nil && !now.After(deletionAt.Add(warnBefore.Duration)) {
+			if !deletionAt.After(now) {
+				return false, deletionAt
+			}
+			run.noteWarnedUntil(deletionAt)
+			return true, deletionAt
+		}
+	}
+
+	deletionAt := run.warningDeletionTime(now, warnBefore.Duration)
+	patch := client.MergeFrom(pod.DeepCopy())
+	if pod.Annotations == nil {
+		pod.Annotations = map[string]string{}
+	}
+	pod.Annotations[cleanupv1.AnnotationCandidateOf] = run.policy.Name
+	pod.Annotations[cleanupv1.AnnotationDeletionAt] = deletionAt.UTC().Format(time.RFC3339)
+	if err := run.podClient.Patch(ctx, pod, patch); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to annotate pod with its deletion warning",
+			"namespace", pod.Namespace, "pod", pod.Name)
+	}
+	run.noteWarnedUntil(deletionAt)
+	return true, deletionAt
+}
+
+// warningDeletionTime is when a pod warned now may be deleted: the first scheduled
+// run at least warnBefore from now, or that time itself for unscheduled policies.
+func (run *cleanupRun) warningDeletionTime(now time.Time, warnBefore time.Duration) time.Time {
+	// Annotations keep whole seconds; rounding up keeps the notice at least warnBefore.
+	deletionAt := now.Add(warnBefore).Truncate(time.Second)
+	if deletionAt.Before(now.Add(warnBefore)) {
+		deletionAt = deletionAt.Add(time.Second)
+	}
+	if run.schedule == nil {
+		return deletionAt
+	}
+	// Next returns the first activation strictly after its argument.
+	if next := run.schedule.Next(deletionAt.Add(-time.Second)); !next.IsZero() {
+		return next
+	}
+	return deletionAt
+}
+
+// noteWarnedUntil records that a warned pod may be deleted from deletionAt on.
+func (run *cleanupRun) noteWarnedUntil(deletionAt time.Time) {
+	if run.warnedUntil.IsZero() || deletionAt.Before(run.warnedUntil) {
+		run.warnedUntil = deletionAt
+	}
+}
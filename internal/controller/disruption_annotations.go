@@ -0,0 +1,34 @@
+package controller
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	cleanupv1 "github.com/aravindavvaru/pod-cleanup-operator/api/v1"
+)
+
+// defaultDisruptionAnnotations are the annotations with which Karpenter and the
+// cluster-autoscaler mark pods that must not be disrupted.
+var defaultDisruptionAnnotations = []cleanupv1.AnnotationMatch{
+	{Key: "karpenter.sh/do-not-disrupt", Value: "true"},
+	{Key: "cluster-autoscaler.kubernetes.io/safe-to-evict", Value: "false"},
+}
+
+// disruptionAnnotation returns the disruption annotation, as key=value, that protects
+// the pod from removal, or "" if it carries none.
+func disruptionAnnotation(config *cleanupv1.OperatorConfigSpec, pod *corev1.Pod) string {
+	matches := defaultDisruptionAnnotations
+	if da := config.DisruptionAnnotations; da != nil {
+		if da.Ignore {
+			return ""
+		}
+		if len(da.Annotations) > 0 {
+			matches = da.Annotations
+		}
+	}
+	for _, match := range matches {
+		if value, ok := pod.Annotations[match.Key]; ok && value == match.Value {
+			return match.Key + "=" + match.Value
+		}
+	}
+	return ""
+}
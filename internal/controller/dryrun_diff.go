@@ -0,0 +1,55 @@
+package controller
+
+import (
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cleanupv1 "github.com/aravindavvaru/pod-cleanup-operator/api/v1"
+)
+
+// diffCandidates compares the candidates of a dry run of the policy with those of
+// its previous dry run, and remembers them for the next one. It returns nil when
+// there is no previous dry run to compare with, e.g. after the operator restarted.
+func (r *PodCleanupPolicyReconciler) diffCandidates(policyName string, candidates []Candidate, now metav1.Time) *cleanupv1.CandidateDiff {
+	current := make(map[string]bool, len(candidates))
+	for _, c := range candidates {
+		current[c.Namespace+"/"+c.Name] = true
+	}
+
+	r.candidateSetsMu.Lock()
+	previous, ok := r.candidateSets[policyName]
+	if r.candidateSets == nil {
+		r.candidateSets = make(map[string]map[string]bool)
+	}
+	r.candidateSets[policyName] = current
+	r.candidateSetsMu.Unlock()
+	if !ok {
+		return nil
+	}
+
+	added, resolved := setDifference(current, previous), setDifference(previous, current)
+	diff := &cleanupv1.CandidateDiff{Time: now, Added: int32(len(added)), Resolved: int32(len(resolved))}
+	diff.AddedPods = added[:min(len(added), cleanupv1.MaxDiffPods)]
+	diff.ResolvedPods = resolved[:min(len(resolved), cleanupv1.MaxDiffPods)]
+	return diff
+}
+
+// forgetCandidates drops the remembered dry-run candidates of the policy.
+func (r *PodCleanupPolicyReconciler) forgetCandidates(policyName string) {
+	r.candidateSetsMu.Lock()
+	defer r.candidateSetsMu.Unlock()
+	delete(r.candidateSets, policyName)
+}
+
+// setDifference returns the sorted keys of a that are not in b.
+func setDifference(a, b map[string]bool) []string {
+	var keys []string
+	for key := range a {
+		if !b[key] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,84 @@
+package controller
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// readyEndpointPodIndex indexes EndpointSlices by the pods that are ready endpoints
+// in them.
+const readyEndpointPodIndex = ".endpoints.readyPods"
+
+//+kubebuilder:rbac:groups=discovery.k8s.io,resources=endpointslices,verbs=get;list;watch
+
+// readyEndpointPods returns the names of the pods that are ready endpoints of an
+// EndpointSlice.
+func readyEndpointPods(obj client.Object) []string {
+	slice := obj.(*discoveryv1.EndpointSlice)
+	var pods []string
+	for _, endpoint := range slice.Endpoints {
+		if ref := endpoint.TargetRef; ref != nil && ref.Kind == "Pod" && endpointReady(endpoint) {
+			pods = append(pods, ref.Name)
+		}
+	}
+	return pods
+}
+
+// endpointReady reports whether the endpoint receives traffic. An unknown readiness
+// is treated as ready, as Services do.
+func endpointReady(endpoint discoveryv1.Endpoint) bool {
+	return endpoint.Conditions.Ready == nil || *endpoint.Conditions.Ready
+}
+
+// servingService returns the name of a Service the pod is a ready endpoint of, or ""
+// if it serves none.
+func (r *PodCleanupPolicyReconciler) servingService(ctx context.Context, run *cleanupRun, pod *corev1.Pod) (string, error) {
+	slices, err := r.endpointSlicesOf(ctx, run, pod)
+	if err != nil {
+		return "", err
+	}
+	for _, slice := range slices {
+		for _, endpoint := range slice.Endpoints {
+			// The index matches by name only; a recreated pod with the same name is
+			// a different endpoint.
+			ref := endpoint.TargetRef
+			if ref != nil && ref.Name == pod.Name && (ref.UID == "" || ref.UID == pod.UID) && endpointReady(endpoint) {
+				if service := slice.Labels[discoveryv1.LabelServiceName]; service != "" {
+					return service, nil
+				}
+				return slice.Name, nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// endpointSlicesOf returns the EndpointSlices that may list the pod as a ready
+// endpoint. In the operator's own cluster they are looked up in the cache by the
+// pod's name; the EndpointSlices of a workload cluster are listed once per namespace
+// and run.
+func (r *PodCleanupPolicyReconciler) endpointSlicesOf(ctx context.Context, run *cleanupRun, pod *corev1.Pod) ([]discoveryv1.EndpointSlice, error) {
+	if run.clusterName == "" {
+		slices := &discoveryv1.EndpointSliceList{}
+		if err := r.List(ctx, slices, client.InNamespace(pod.Namespace),
+			client.MatchingFields{readyEndpointPodIndex: pod.Name}); err != nil {
+			return nil, err
+		}
+		return slices.Items, nil
+	}
+	if slices, ok := run.endpointSlices[pod.Namespace]; ok {
+		return slices, nil
+	}
+	slices := &discoveryv1.EndpointSliceList{}
+	if err := run.cluster.List(ctx, slices, client.InNamespace(pod.Namespace)); err != nil {
+		return nil, err
+	}
+	if run.endpointSlices == nil {
+		run.endpointSlices = make(map[string][]discoveryv1.EndpointSlice)
+	}
+	run.endpointSlices[pod.Namespace] = slices.Items
+	return slices.Items, nil
+}
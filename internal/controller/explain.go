@@ -0,0 +1,78 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	cleanupv1 "github.com/aravindavvaru/pod-cleanup-operator/api/v1"
+)
+
+// Reasons recorded in explained decisions.
+const (
+	ReasonSelected             = "Selected"
+	ReasonPhaseNotSelected     = "PhaseNotSelected"
+	ReasonConditionNotMatched  = "ConditionNotMatched"
+	ReasonCriteriaNotMatched   = "CriteriaNotMatched"
+	ReasonTooYoung             = "TooYoung"
+	ReasonNodeNotMatched       = "NodeNotMatched"
+	ReasonVolumeClaimsHealthy  = "VolumeClaimsHealthy"
+	ReasonSidecarNotStuck      = "SidecarNotStuck"
+	ReasonOwnerExists          = "OwnerExists"
+	ReasonNotMatchedExternally = "NotMatchedExternally"
+	ReasonPodReady             = "PodReady"
+	ReasonProtected            = "Protected"
+	ReasonRetained             = "Retained"
+	ReasonKeptNewest           = "KeptNewest"
+	ReasonHigherPriority       = "HigherPriorityPolicy"
+	ReasonServingTraffic       = "ServingTraffic"
+	ReasonDeferredByQuota      = "DeferredByQuota"
+	ReasonDeferredByBudget     = "DeferredByBudget"
+	ReasonDeletionWarned       = "DeletionWarned"
+	ReasonDecisionDenied       = "DecisionDenied"
+	ReasonDisruptionProtected  = "DisruptionProtected"
+	ReasonMeshExcluded         = "MeshExcluded"
+	ReasonNamespaceOptedOut    = "NamespaceOptedOut"
+	ReasonNamespaceExpired     = "NamespaceExpired"
+	ReasonNamespaceShielded    = "NamespaceShielded"
+	ReasonNamespaceForbidden   = "NamespaceForbidden"
+	ReasonNamespaceError       = "NamespaceError"
+)
+
+// explain records why the run selected or skipped a pod (or, with an empty pod name,
+// a whole namespace). Pods skipped by runs other than previews are counted in the
+// skipped-pods metric; the decision itself is only recorded if the run explains its
+// decisions.
+func (run *cleanupRun) explain(ctx context.Context, namespace, pod string, selected bool, reason, format string, args ...any) {
+	if !selected && pod != "" && !run.preview {
+		run.countSkipped(reason)
+	}
+	if !run.explaining {
+		return
+	}
+	decision := cleanupv1.PodDecision{
+		Namespace: namespace,
+		Pod:       pod,
+		Selected:  selected,
+		Reason:    reason,
+		Message:   fmt.Sprintf(format, args...),
+	}
+	log.FromContext(ctx).Info("Explain",
+		"namespace", namespace, "pod", pod, "selected", selected, "reason", reason, "message", decision.Message)
+	run.decisions = append(run.decisions, decision)
+}
+
+// Explain evaluates the policy like Preview and returns the decision taken for every
+// evaluated pod and skipped namespace.
+func (r *PodCleanupPolicyReconciler) Explain(ctx context.Context, policy *cleanupv1.PodCleanupPolicy) ([]cleanupv1.PodDecision, error) {
+	run, err := r.previewRun(ctx, policy)
+	if err != nil {
+		return nil, err
+	}
+	run.explaining = true
+	if _, err := r.runCleanup(ctx, run); err != nil {
+		return nil, err
+	}
+	return run.decisions, nil
+}
@@ -0,0 +1,105 @@
+package controller
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	cleanupv1 "github.com/aravindavvaru/pod-cleanup-operator/api/v1"
+	"github.com/aravindavvaru/pod-cleanup-operator/internal/matcher"
+)
+
+// externalMatcher returns the run's external matcher, or nil if the policy has none.
+// It is set up on first use and closed with the run.
+func (r *PodCleanupPolicyReconciler) externalMatcher(ctx context.Context, run *cleanupRun) (matcher.Matcher, error) {
+	spec := run.spec.ExternalMatcher
+	if spec == nil {
+		return nil, nil
+	}
+	if run.externalMatcher != nil || run.externalMatcherErr != nil {
+		return run.externalMatcher, run.externalMatcherErr
+	}
+	timeout := matcher.DefaultTimeout
+	if spec.Timeout != "" {
+		// Validated with the policy spec.
+		if d, err := cleanupv1.ParseDuration(spec.Timeout); err == nil {
+			timeout = d
+		}
+	}
+	var tlsConfig *tls.Config
+	if !spec.Insecure {
+		tlsConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+		if spec.CASecretRef != nil {
+			ca, err := r.secretValue(ctx, spec.CASecretRef)
+			if err != nil {
+				run.externalMatcherErr = fmt.Errorf("reading external matcher CA bundle: %w", err)
+				return nil, run.externalMatcherErr
+			}
+			tlsConfig.RootCAs = x509.NewCertPool()
+			if !tlsConfig.RootCAs.AppendCertsFromPEM([]byte(ca)) {
+				run.externalMatcherErr = errors.New("external matcher CA bundle contains no PEM certificates")
+				return nil, run.externalMatcherErr
+			}
+		}
+	}
+	m, err := matcher.Dial(spec.Endpoint, tlsConfig, timeout)
+	if err != nil {
+		run.externalMatcherErr = err
+		return nil, err
+	}
+	run.externalMatcher = m
+	return m, nil
+}
+
+// closeMatcher closes the connection of the run's external matcher, if any.
+func (run *cleanupRun) closeMatcher() {
+	if closer, ok := run.externalMatcher.(io.Closer); ok {
+		closer.Close()
+	}
+	run.externalMatcher = nil
+}
+
+// podMatchesExternally reports whether the policy's external matcher matches the
+// pod, with an explanation. Pods the matcher fails on follow its failurePolicy; with
+// Deny they count as transient failures, so the run is retried.
+func (r *PodCleanupPolicyReconciler) podMatchesExternally(ctx context.Context, run *cleanupRun, pod *corev1.Pod, podAge time.Duration, rule string) (bool, string) {
+	m, err := r.externalMatcher(ctx, run)
+	if m == nil && err == nil {
+		return true, ""
+	}
+	var decision matcher.Decision
+	if err == nil {
+		decision, err = m.Match(ctx, matcher.Request{
+			Policy:  run.policy.Name,
+			RunID:   string(run.id),
+			Cluster: run.clusterName,
+			Rule:    rule,
+			DryRun:  run.dryRun,
+			Pod:     pod,
+			Age:     podAge,
+		})
+	}
+	if err != nil {
+		log.FromContext(ctx).Error(err, "External matcher could not match pod",
+			"namespace", pod.Namespace, "pod", pod.Name)
+		if run.spec.ExternalMatcher.FailurePolicy == cleanupv1.DecisionFailureAllow {
+			return true, ""
+		}
+		run.transientFailures++
+		return false, fmt.Sprintf("the external matcher could not match it: %v", err)
+	}
+	switch {
+	case decision.Reason != "":
+		return decision.Match, "external matcher: " + decision.Reason
+	case decision.Match:
+		return true, ""
+	}
+	return false, "the external matcher does not match it"
+}
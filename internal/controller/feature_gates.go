@@ -0,0 +1,68 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+
+	cleanupv1 "github.com/aravindavvaru/pod-cleanup-operator/api/v1"
+	"github.com/aravindavvaru/pod-cleanup-operator/internal/features"
+)
+
+// disabledFeatureError returns an error naming a disabled feature gate the policy
+// depends on, or nil if every gate it needs is enabled.
+func disabledFeatureError(policy *cleanupv1.PodCleanupPolicy) error {
+	if policy.Spec.Match != nil && !features.Enabled(features.Eviction) {
+		for i, rule := range policy.Spec.Match.AnyOf {
+			if rule.Action == cleanupv1.RuleActionEvict {
+				return fmt.Errorf("match.anyOf[%d] uses action Evict, which requires the %s feature gate", i, features.Eviction)
+			}
+		}
+	}
+	if policy.Spec.Archive != nil && !features.Enabled(features.Archive) {
+		return fmt.Errorf("archive requires the %s feature gate", features.Archive)
+	}
+	if policy.Spec.Orphaned != nil && !features.Enabled(features.GenericResourceCleanup) {
+		return fmt.Errorf("orphaned requires the %s feature gate", features.GenericResourceCleanup)
+	}
+	if policy.Spec.NamespaceTTL != nil && !features.Enabled(features.NamespaceTTL) {
+		return fmt.Errorf("namespaceTTL requires the %s feature gate", features.NamespaceTTL)
+	}
+	if len(policy.Spec.ClusterRefs) > 0 && !features.Enabled(features.MultiCluster) {
+		return fmt.Errorf("clusterRefs requires the %s feature gate", features.MultiCluster)
+	}
+	return nil
+}
+
+// featureDisabledError is returned when a run needs a disabled feature gate through
+// its ClusterCleanupDefaults or the OperatorConfig rather than its own spec.
+type featureDisabledError struct {
+	message string
+}
+
+func (e *featureDisabledError) Error() string {
+	return e.message
+}
+
+// isFeatureDisabled reports whether err is a featureDisabledError.
+func isFeatureDisabled(err error) bool {
+	var disabled *featureDisabledError
+	return errors.As(err, &disabled)
+}
+
+// disabledRunFeatureError returns an error naming a disabled feature gate a run with
+// the resolved spec and the OperatorConfig depends on, or nil if every gate it needs
+// is enabled. Mesh pods are not silently deleted when serviceMesh.evict asks for
+// them to be evicted.
+func disabledRunFeatureError(spec *cleanupv1.PodCleanupPolicySpec, config *cleanupv1.OperatorConfigSpec) error {
+	if spec.Archive != nil && !features.Enabled(features.Archive) {
+		// disabledFeatureError rejects policies archiving themselves; this one
+		// inherits its archive.
+		return &featureDisabledError{fmt.Sprintf("archive of ClusterCleanupDefaults %q requires the %s feature gate",
+			spec.DefaultsFrom, features.Archive)}
+	}
+	if mesh := config.ServiceMesh; mesh != nil && mesh.Evict && !features.Enabled(features.Eviction) {
+		return &featureDisabledError{fmt.Sprintf("serviceMesh.evict of the OperatorConfig requires the %s feature gate",
+			features.Eviction)}
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	cleanupv1 "github.com/aravindavvaru/pod-cleanup-operator/api/v1"
+)
+
+// finalizerPollInterval is how often a deleted policy checks whether its runs have
+// stopped.
+const finalizerPollInterval = 2 * time.Second
+
+// ensureFinalizer adds the policy finalizer if it is missing.
+func (r *PodCleanupPolicyReconciler) ensureFinalizer(ctx context.Context, policy *cleanupv1.PodCleanupPolicy) error {
+	if controllerutil.ContainsFinalizer(policy, cleanupv1.PolicyFinalizer) {
+		return nil
+	}
+	patch := client.MergeFrom(policy.DeepCopy())
+	controllerutil.AddFinalizer(policy, cleanupv1.PolicyFinalizer)
+	return r.Patch(ctx, policy, patch)
+}
+
+// finalizePolicy completes the deletion of a policy: it waits for its runs in
+// progress to stop, marks run records left Running as failed, prunes the run
+// history, emits a final summary Event and removes the finalizer.
+func (r *PodCleanupPolicyReconciler) finalizePolicy(ctx context.Context, policy *cleanupv1.PodCleanupPolicy) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	if !controllerutil.ContainsFinalizer(policy, cleanupv1.PolicyFinalizer) {
+		return ctrl.Result{}, nil
+	}
+
+	// Runs in progress were canceled when the deletion was observed; wait for them to
+	// record their partial results.
+	if n := r.activeRunCount(policy.Name); n > 0 {
+		logger.Info("Waiting for runs to stop before deleting the policy", "runs", n)
+		return ctrl.Result{RequeueAfter: finalizerPollInterval}, nil
+	}
+
+	runList := &cleanupv1.CleanupRunList{}
+	if err := r.List(ctx, runList, client.MatchingLabels{cleanupv1.LabelPolicy: policy.Name}); err != nil {
+		return ctrl.Result{}, err
+	}
+	now := metav1.NewTime(r.Clock.Now())
+	for i := range runList.Items {
+		record := &runList.Items[i]
+		if record.Status.Phase != cleanupv1.RunPhaseRunning {
+			continue
+		}
+		// No run of this policy is in progress, so the record belongs to a run
+		// interrupted by a controller restart.
+		if err := updateStatus(ctx, r.Client, record, func() {
+			record.Status.Phase = cleanupv1.RunPhaseFailed
+			record.Status.CompletionTime = &now
+			record.Status.Message = "Run was interrupted before it completed"
+		}); client.IgnoreNotFound(err) != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	r.pruneRunHistory(ctx, policy)
+
+	summary := "Policy deleted before its first run"
+	if last := policy.Status.LastRunTime; last != nil {
+		summary = fmt.Sprintf("Policy deleted; %d pod(s) deleted in total, last run at %s",
+			policy.Status.PodsDeleted, last.UTC().Format(time.RFC3339))
+	}
+	r.Recorder.Event(policy, corev1.EventTypeNormal, "Finalized", summary)
+	logger.Info("Finalizing policy", "summary", summary)
+
+	r.forgetPolicyLimiter(policy.Name)
+	r.forgetCandidates(policy.Name)
+	forgetPolicyMetrics(policy.Name)
+	r.takeMaintenanceNodes(policy.Name)
+
+	patch := client.MergeFrom(policy.DeepCopy())
+	controllerutil.RemoveFinalizer(policy, cleanupv1.PolicyFinalizer)
+	return ctrl.Result{}, client.IgnoreNotFound(r.Patch(ctx, policy, patch))
+}
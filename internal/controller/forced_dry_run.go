@@ -0,0 +1,84 @@
+package controller
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	cleanupv1 "github.com/aravindavvaru/pod-cleanup-operator/api/v1"
+)
+
+// dryRunForcedBy returns the reason every policy is forced into dry run, or "" if
+// none is: the manager's --force-dry-run flag takes precedence over the OperatorConfig.
+func (r *PodCleanupPolicyReconciler) dryRunForcedBy(config *cleanupv1.OperatorConfigSpec) string {
+	switch {
+	case r.ForceDryRun:
+		return cleanupv1.ReasonForceDryRunFlag
+	case config.DryRun:
+		return cleanupv1.ReasonOperatorConfig
+	}
+	return ""
+}
+
+// syncDryRunForced sets the DryRunForced condition of the policy while dry runs are
+// forced, and removes it once they no longer are.
+func (r *PodCleanupPolicyReconciler) syncDryRunForced(ctx context.Context, policy *cleanupv1.PodCleanupPolicy) error {
+	config, err := r.getOperatorConfig(ctx)
+	if err != nil {
+		return err
+	}
+	reason := r.dryRunForcedBy(config)
+	current := meta.FindStatusCondition(policy.Status.Conditions, cleanupv1.ConditionDryRunForced)
+	switch {
+	case reason == "" && current == nil:
+		return nil
+	case reason == "":
+		return updateStatus(ctx, r.Client, policy, func() {
+			meta.RemoveStatusCondition(&policy.Status.Conditions, cleanupv1.ConditionDryRunForced)
+		})
+	case current != nil && current.Reason == reason:
+		return nil
+	}
+	msg := "The --force-dry-run flag of the operator forces every policy into dry-run mode; no pods are deleted"
+	if reason == cleanupv1.ReasonOperatorConfig {
+		msg = "OperatorConfig spec.dryRun forces every policy into dry-run mode; no pods are deleted"
+	}
+	return updateStatus(ctx, r.Client, policy, func() {
+		r.setCondition(policy, cleanupv1.ConditionDryRunForced, metav1.ConditionTrue, reason, msg)
+	})
+}
+
+// policiesForOperatorConfig maps the OperatorConfig to every policy, so their
+// DryRunForced conditions follow spec.dryRun.
+func (r *PodCleanupPolicyReconciler) policiesForOperatorConfig(ctx context.Context, obj client.Object) []reconcile.Request {
+	policyList := &cleanupv1.PodCleanupPolicyList{}
+	if err := r.List(ctx, policyList); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list policies for OperatorConfig")
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(policyList.Items))
+	for _, policy := range policyList.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: policy.Name}})
+	}
+	return requests
+}
+
+// forcedDryRunCancelHandler cancels every run that deletes pods as soon as the
+// OperatorConfig forces dry runs.
+func (r *PodCleanupPolicyReconciler) forcedDryRunCancelHandler() toolscache.ResourceEventHandler {
+	cancel := func(obj interface{}) {
+		if config, ok := obj.(*cleanupv1.OperatorConfig); ok && config.Name == cleanupv1.OperatorConfigName && config.Spec.DryRun {
+			r.cancelDeletingRuns("dry run was forced by the OperatorConfig")
+		}
+	}
+	return toolscache.ResourceEventHandlerFuncs{
+		AddFunc:    cancel,
+		UpdateFunc: func(_, obj interface{}) { cancel(obj) },
+	}
+}
@@ -0,0 +1,191 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	cleanupv1 "github.com/aravindavvaru/pod-cleanup-operator/api/v1"
+)
+
+const (
+	hookPreRun  = "preRun"
+	hookPostRun = "postRun"
+
+	// defaultHookTimeout bounds a hook Job without a timeout.
+	defaultHookTimeout = 10 * time.Minute
+	// hookPollInterval is how often a hook Job is checked for completion.
+	hookPollInterval = 5 * time.Second
+	// hookJobTTL removes finished hook Jobs that set no ttlSecondsAfterFinished.
+	hookJobTTL = int32(3600)
+)
+
+// runWithHooks runs the policy's preRun hook, the run, and its postRun hook. A
+// failing preRun hook keeps the run from starting, unless its failurePolicy is
+// Ignore. Dry runs and previews run no hooks, as they remove no pods.
+func (r *PodCleanupPolicyReconciler) runWithHooks(ctx context.Context, run *cleanupRun) (int, error) {
+	hooks := run.spec.Hooks
+	if hooks == nil || run.dryRun || run.preview {
+		return r.runCleanup(ctx, run)
+	}
+	if err := r.runHook(ctx, run, hookPreRun, hooks.PreRun, nil); err != nil {
+		return 0, err
+	}
+	deleted, runErr := r.runCleanup(ctx, run)
+	if ctx.Err() != nil {
+		return deleted, runErr
+	}
+	env := []corev1.EnvVar{{Name: "CLEANUP_PODS_DELETED", Value: strconv.Itoa(deleted)}}
+	if runErr != nil {
+		env = append(env, corev1.EnvVar{Name: "CLEANUP_RUN_ERROR", Value: runErr.Error()})
+	}
+	if err := r.runHook(ctx, run, hookPostRun, hooks.PostRun, env); err != nil && runErr == nil {
+		runErr = err
+	}
+	return deleted, runErr
+}
+
+// runHook creates the hook's Job and waits for it to finish. Failures are returned
+// unless the hook's failurePolicy is Ignore, in which case they are only reported
+// in a Warning Event.
+func (r *PodCleanupPolicyReconciler) runHook(ctx context.Context, run *cleanupRun, name string, hook *cleanupv1.HookJob, env []corev1.EnvVar) error {
+	if hook == nil {
+		return nil
+	}
+	err := r.runHookJob(ctx, run, name, hook, env)
+	if err == nil {
+		return nil
+	}
+	err = fmt.Errorf("%s hook: %w", name, err)
+	if hook.FailurePolicy == cleanupv1.HookFailureIgnore {
+		log.FromContext(ctx).Error(err, "Hook failed; continuing as its failurePolicy is Ignore")
+		r.runEventf(run, corev1.EventTypeWarning, "HookFailed", "%v", err)
+		return nil
+	}
+	return err
+}
+
+// runHookJob creates the Job of a hook and waits until it completes, fails or times
+// out. Jobs that time out are deleted. Jobs are created and deleted as the policy's
+// ServiceAccount, in its namespace, so hooks can run nothing the account could not
+// run itself.
+func (r *PodCleanupPolicyReconciler) runHookJob(ctx context.Context, run *cleanupRun, name string, hook *cleanupv1.HookJob, env []corev1.EnvVar) error {
+	logger := log.FromContext(ctx)
+
+	if run.policy.Spec.ServiceAccountName == "" {
+		return fmt.Errorf("hooks require serviceAccountName")
+	}
+	namespace := run.policy.Spec.ServiceAccountNamespace
+	// Hooks run in the operator's own cluster, whichever cluster the run cleans up.
+	c, err := r.podClientFor(run.policy)
+	if err != nil {
+		return err
+	}
+	timeout := defaultHookTimeout
+	if hook.Timeout != "" {
+		// Validated with the policy spec.
+		if d, err := cleanupv1.ParseDuration(hook.Timeout); err == nil {
+			timeout = d
+		}
+	}
+
+	job := r.hookJob(run, name, namespace, hook, env)
+	if run.policy.UID != "" {
+		// Not a controller reference: blocking the policy's deletion would require the
+		// ServiceAccount to update its finalizers.
+		if err := controllerutil.SetOwnerReference(run.policy, job, r.Scheme); err != nil {
+			return err
+		}
+	}
+	if err := c.Create(ctx, job); err != nil {
+		return fmt.Errorf("creating Job: %w", err)
+	}
+	logger.Info("Waiting for hook Job", "hook", name, "namespace", job.Namespace, "job", job.Name)
+
+	key := types.NamespacedName{Namespace: job.Namespace, Name: job.Name}
+	// failed is the message of the Job's Failed condition.
+	failed := ""
+	err = wait.PollUntilContextTimeout(ctx, hookPollInterval, timeout, true, func(ctx context.Context) (bool, error) {
+		if err := r.APIReader.Get(ctx, key, job); err != nil {
+			if isTransient(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		for _, cond := range job.Status.Conditions {
+			if cond.Status != corev1.ConditionTrue {
+				continue
+			}
+			switch cond.Type {
+			case batchv1.JobComplete:
+				return true, nil
+			case batchv1.JobFailed:
+				failed = cond.Message
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+	if err != nil {
+		if ctx.Err() == nil {
+			if err := c.Delete(ctx, job, client.PropagationPolicy("Background")); client.IgnoreNotFound(err) != nil {
+				logger.Error(err, "Failed to delete hook Job", "namespace", job.Namespace, "job", job.Name)
+			}
+			return fmt.Errorf("job %s/%s did not finish within %s", job.Namespace, job.Name, timeout)
+		}
+		return fmt.Errorf("waiting for Job %s/%s: %w", job.Namespace, job.Name, err)
+	}
+	if failed != "" {
+		return fmt.Errorf("job %s/%s failed: %s", job.Namespace, job.Name, failed)
+	}
+	return nil
+}
+
+// hookJob builds the Job of a hook from its template.
+func (r *PodCleanupPolicyReconciler) hookJob(run *cleanupRun, name, namespace string, hook *cleanupv1.HookJob, env []corev1.EnvVar) *batchv1.Job {
+	template := hook.JobTemplate.DeepCopy()
+	job := &batchv1.Job{
+		ObjectMeta: template.ObjectMeta,
+		Spec:       template.Spec,
+	}
+	job.Name = ""
+	job.GenerateName = fmt.Sprintf("%s-%s-", run.policy.Name, strings.ToLower(name))
+	if len(job.GenerateName) > 52 {
+		job.GenerateName = job.GenerateName[:52]
+	}
+	job.Namespace = namespace
+	if job.Labels == nil {
+		job.Labels = map[string]string{}
+	}
+	job.Labels[cleanupv1.LabelPolicy] = run.policy.Name
+	job.Labels[cleanupv1.LabelRunID] = string(run.id)
+	job.Labels[cleanupv1.LabelHook] = name
+	if job.Spec.TTLSecondsAfterFinished == nil {
+		ttl := hookJobTTL
+		job.Spec.TTLSecondsAfterFinished = &ttl
+	}
+	if job.Spec.Template.Spec.RestartPolicy == "" {
+		job.Spec.Template.Spec.RestartPolicy = corev1.RestartPolicyNever
+	}
+
+	env = append([]corev1.EnvVar{
+		{Name: "CLEANUP_POLICY", Value: run.policy.Name},
+		{Name: "CLEANUP_RUN_ID", Value: string(run.id)},
+		{Name: "CLEANUP_HOOK", Value: name},
+	}, env...)
+	for i := range job.Spec.Template.Spec.Containers {
+		container := &job.Spec.Template.Spec.Containers[i]
+		container.Env = append(container.Env, env...)
+	}
+	return job
+}
@@ -0,0 +1,44 @@
+package controller
+
+import (
+	"fmt"
+
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	cleanupv1 "github.com/aravindavvaru/pod-cleanup-operator/api/v1"
+)
+
+// serviceAccountUsername returns the username the API server assigns to a ServiceAccount.
+func serviceAccountUsername(namespace, name string) string {
+	return fmt.Sprintf("system:serviceaccount:%s:%s", namespace, name)
+}
+
+// podClientFor returns the client used for pod list and delete calls of a policy.
+// Policies that name a ServiceAccount get an uncached client impersonating it;
+// all other policies use the operator's own client.
+func (r *PodCleanupPolicyReconciler) podClientFor(policy *cleanupv1.PodCleanupPolicy) (client.Client, error) {
+	if policy.Spec.ServiceAccountName == "" {
+		return r.Client, nil
+	}
+	username := serviceAccountUsername(policy.Spec.ServiceAccountNamespace, policy.Spec.ServiceAccountName)
+
+	r.impersonationMu.Lock()
+	defer r.impersonationMu.Unlock()
+
+	if c, ok := r.impersonatedClients[username]; ok {
+		return c, nil
+	}
+
+	cfg := rest.CopyConfig(r.RestConfig)
+	cfg.Impersonate = rest.ImpersonationConfig{UserName: username}
+	c, err := client.New(cfg, client.Options{Scheme: r.Scheme, Mapper: r.RESTMapper()})
+	if err != nil {
+		return nil, fmt.Errorf("creating client impersonating %s: %w", username, err)
+	}
+	if r.impersonatedClients == nil {
+		r.impersonatedClients = make(map[string]client.Client)
+	}
+	r.impersonatedClients[username] = c
+	return c, nil
+}
@@ -0,0 +1,68 @@
+package controller
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// rankedPod is a pod ranked by keepNewest.
+type rankedPod struct {
+	uid     types.UID
+	name    string
+	created time.Time
+}
+
+// newestPods returns the UIDs of the pods of namespace the policy's keepNewest keeps,
+// or nil if it keeps none. Pods matching opts in the phases of podStatuses are
+// listed once before the namespace is cleaned up, so the ranking does not depend on
+// the pages the run works through; only their UID, name and creation time are kept.
+func (r *PodCleanupPolicyReconciler) newestPods(ctx context.Context, run *cleanupRun, namespace string, opts []client.ListOption) (map[types.UID]bool, error) {
+	keep := run.policy.Spec.KeepNewest
+	if keep == nil {
+		return nil, nil
+	}
+	groups := make(map[string][]rankedPod)
+	collect := func(pod *corev1.Pod) error {
+		group := ""
+		if keep.GroupBy != "" {
+			group = pod.Labels[keep.GroupBy]
+		}
+		groups[group] = append(groups[group], rankedPod{uid: pod.UID, name: pod.Name, created: pod.CreationTimestamp.Time})
+		return nil
+	}
+	noPages := func(string) {}
+	phases := slices.Clone(run.policy.Spec.PodStatuses)
+	slices.Sort(phases)
+	phases = slices.Compact(phases)
+	if len(phases) == 0 {
+		if err := r.forEachListedPod(ctx, run, namespace, opts, "", noPages, collect); err != nil {
+			return nil, err
+		}
+	}
+	for _, phase := range phases {
+		phaseOpts := append(slices.Clip(opts), client.MatchingFields{podPhaseField: string(phase)})
+		if err := r.forEachListedPod(ctx, run, namespace, phaseOpts, "", noPages, collect); err != nil {
+			return nil, err
+		}
+	}
+
+	kept := make(map[types.UID]bool)
+	for _, pods := range groups {
+		slices.SortFunc(pods, func(a, b rankedPod) int {
+			if c := b.created.Compare(a.created); c != 0 {
+				return c
+			}
+			return strings.Compare(a.name, b.name)
+		})
+		for _, pod := range pods[:min(len(pods), int(keep.Count))] {
+			kept[pod.uid] = true
+		}
+	}
+	return kept, nil
+}
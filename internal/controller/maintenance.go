@@ -0,0 +1,70 @@
+package controller
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	cleanupv1 "github.com/aravindavvaru/pod-cleanup-operator/api/v1"
+)
+
+// policiesForMaintenanceNode maps a node to the policies whose
+// maintenanceNodeSelector it matches, and marks the node as pending a maintenance
+// run of each of them.
+func (r *PodCleanupPolicyReconciler) policiesForMaintenanceNode(ctx context.Context, obj client.Object) []reconcile.Request {
+	policyList := &cleanupv1.PodCleanupPolicyList{}
+	if err := r.List(ctx, policyList); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list policies for maintenance node", "node", obj.GetName())
+		return nil
+	}
+
+	r.maintenanceMu.Lock()
+	defer r.maintenanceMu.Unlock()
+	var requests []reconcile.Request
+	for i := range policyList.Items {
+		policy := &policyList.Items[i]
+		if policy.Spec.MaintenanceNodeSelector == nil || policy.Spec.Action == cleanupv1.ActionProtect ||
+			!selectorMatches(policy.Spec.MaintenanceNodeSelector, obj.GetLabels()) {
+			continue
+		}
+		if r.maintenanceNodes == nil {
+			r.maintenanceNodes = make(map[string]map[string]bool)
+		}
+		if r.maintenanceNodes[policy.Name] == nil {
+			r.maintenanceNodes[policy.Name] = make(map[string]bool)
+		}
+		r.maintenanceNodes[policy.Name][obj.GetName()] = true
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: policy.Name}})
+	}
+	return requests
+}
+
+// hasMaintenanceNodes reports whether nodes are pending a maintenance run of the policy.
+func (r *PodCleanupPolicyReconciler) hasMaintenanceNodes(policy *cleanupv1.PodCleanupPolicy) bool {
+	r.maintenanceMu.Lock()
+	defer r.maintenanceMu.Unlock()
+	return policy.Spec.MaintenanceNodeSelector != nil && len(r.maintenanceNodes[policy.Name]) > 0
+}
+
+// takeMaintenanceNodes returns the nodes pending a maintenance run of the policy and
+// clears them.
+func (r *PodCleanupPolicyReconciler) takeMaintenanceNodes(policyName string) map[string]bool {
+	r.maintenanceMu.Lock()
+	defer r.maintenanceMu.Unlock()
+	nodes := r.maintenanceNodes[policyName]
+	delete(r.maintenanceNodes, policyName)
+	return nodes
+}
+
+// inMaintenanceSweep reports whether a maintenance run cleans up the pod: it must be
+// Succeeded or Failed and sit on one of the run's maintenance nodes.
+func inMaintenanceSweep(run *cleanupRun, pod *corev1.Pod) bool {
+	if pod.Status.Phase != corev1.PodSucceeded && pod.Status.Phase != corev1.PodFailed {
+		return false
+	}
+	return run.maintenanceNodes[pod.Spec.NodeName]
+}
@@ -0,0 +1,117 @@
+package controller
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	// podsSkippedTotal counts the pods that matched a policy's selectors but were not
+	// cleaned up, by the reason of the decision.
+	podsSkippedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "podcleanup_pods_skipped_total",
+		Help: "Pods that matched a policy's namespace and pod selectors but were skipped, by reason.",
+	}, []string{"policy", "reason"})
+
+	// policyCandidates is the number of pods that matched a policy in its last full run.
+	policyCandidates = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "podcleanup_policy_candidates",
+		Help: "Pods that matched all criteria of a policy in its last completed run.",
+	}, []string{"policy"})
+
+	// podAgeAtDeletion is the age of the pods a policy deleted or evicted, to tune maxAge.
+	podAgeAtDeletion = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "podcleanup_pod_age_at_deletion_seconds",
+		Help: "Age of pods when a policy deleted or evicted them, measured as set by maxAgeFrom.",
+		// From one minute to about half a year.
+		Buckets: prometheus.ExponentialBuckets(60, 4, 10),
+	}, []string{"policy"})
+
+	// policyLastRunTimestamp is the heartbeat of a policy: when its last run finished.
+	policyLastRunTimestamp = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "podcleanup_policy_last_run_timestamp_seconds",
+		Help: "Unix time at which the last run of a policy finished, whatever its outcome.",
+	}, []string{"policy"})
+
+	// policyScheduleHealthy mirrors the ScheduleHealthy condition of scheduled policies.
+	policyScheduleHealthy = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "podcleanup_policy_schedule_healthy",
+		Help: "1 if the runs of a scheduled policy start on time, 0 if one is overdue.",
+	}, []string{"policy"})
+
+	// apiThrottledTotal counts the responses in which the API server throttled the
+	// operator.
+	apiThrottledTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "podcleanup_api_throttled_total",
+		Help: "API responses throttling the operator (HTTP 429 with Retry-After), e.g. by API Priority and Fairness.",
+	})
+
+	// backpressureRate is the deletion rate imposed by backpressure.
+	backpressureRate = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "podcleanup_backpressure_deletions_per_second",
+		Help: "Deletion rate imposed after the API server throttled the operator, or 0 if deletions are not slowed down.",
+	})
+
+	// budgetDeletions is the number of deletions counted against a ClusterCleanupBudget.
+	budgetDeletions = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "podcleanup_budget_deletions",
+		Help: "Pods deleted or evicted by all policies within the window of a ClusterCleanupBudget.",
+	}, []string{"budget"})
+
+	// budgetExhausted mirrors the Exhausted condition of a ClusterCleanupBudget.
+	budgetExhausted = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "podcleanup_budget_exhausted",
+		Help: "1 while a ClusterCleanupBudget is exhausted, in total or for a namespace, 0 otherwise.",
+	}, []string{"budget"})
+
+	// budgetDeferredPods counts the deletions deferred because a budget was exhausted.
+	budgetDeferredPods = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "podcleanup_budget_deferred_pods_total",
+		Help: "Pod deletions deferred because a ClusterCleanupBudget was exhausted.",
+	}, []string{"budget"})
+
+	// estimatedSavings sums the estimated monthly cost of the pods a policy removed.
+	estimatedSavings = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "podcleanup_estimated_monthly_savings_total",
+		Help: "Estimated monthly cost of the pods a policy deleted or evicted, priced by the OperatorConfig's costModel.",
+	}, []string{"policy"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(podsSkippedTotal, policyCandidates, podAgeAtDeletion, policyLastRunTimestamp,
+		policyScheduleHealthy, apiThrottledTotal, backpressureRate, budgetDeletions, budgetExhausted, budgetDeferredPods,
+		estimatedSavings)
+}
+
+// exemplar labels the samples a run contributes with its ID, so they link to the run's
+// CleanupRun, report, Events and archive.
+func (run *cleanupRun) exemplar() prometheus.Labels {
+	return prometheus.Labels{"run_id": string(run.id)}
+}
+
+// countSkipped counts a pod the run skipped for reason.
+func (run *cleanupRun) countSkipped(reason string) {
+	podsSkippedTotal.WithLabelValues(run.policy.Name, reason).(prometheus.ExemplarAdder).AddWithExemplar(1, run.exemplar())
+}
+
+// observeAgeAtDeletion records the age of a pod the run deleted or evicted.
+func (run *cleanupRun) observeAgeAtDeletion(age time.Duration) {
+	podAgeAtDeletion.WithLabelValues(run.policy.Name).(prometheus.ExemplarObserver).ObserveWithExemplar(age.Seconds(), run.exemplar())
+}
+
+// countSavings adds the estimated monthly cost of a pod the run removed.
+func (run *cleanupRun) countSavings(monthlyCost float64) {
+	estimatedSavings.WithLabelValues(run.policy.Name).(prometheus.ExemplarAdder).AddWithExemplar(monthlyCost, run.exemplar())
+}
+
+// forgetPolicyMetrics removes the metrics of a deleted policy.
+func forgetPolicyMetrics(policyName string) {
+	podsSkippedTotal.DeletePartialMatch(prometheus.Labels{"policy": policyName})
+	policyCandidates.DeleteLabelValues(policyName)
+	podAgeAtDeletion.DeleteLabelValues(policyName)
+	policyLastRunTimestamp.DeleteLabelValues(policyName)
+	policyScheduleHealthy.DeleteLabelValues(policyName)
+	estimatedSavings.DeleteLabelValues(policyName)
+}
@@ -0,0 +1,61 @@
+package controller
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	cleanupv1 "github.com/aravindavvaru/pod-cleanup-operator/api/v1"
+)
+
+// namespaceOptedOut reports whether the namespace has opted out of cleanup.
+func namespaceOptedOut(ns *corev1.Namespace) bool {
+	return ns.Annotations[cleanupv1.AnnotationOptOut] == "true"
+}
+
+// maxAges holds the minimum ages pods in a namespace must reach before they are
+// eligible for deletion: one for each phase in maxAgeByPhase and a default for the
+// others. Zero means no age requirement.
+type maxAges struct {
+	defaultAge time.Duration
+	byPhase    map[corev1.PodPhase]time.Duration
+}
+
+// forPhase returns the minimum age of pods in the given phase.
+func (m maxAges) forPhase(phase corev1.PodPhase) time.Duration {
+	if age, ok := m.byPhase[phase]; ok {
+		return age
+	}
+	return m.defaultAge
+}
+
+// effectiveMaxAges returns the minimum ages of pods in ns: the policy's maxAge and
+// maxAgeByPhase, each lengthened (but never shortened) by the namespace's
+// ttl-override annotation.
+func effectiveMaxAges(policy *cleanupv1.PodCleanupPolicy, ns *corev1.Namespace) (maxAges, error) {
+	var ages maxAges
+	if policy.Spec.MaxAge != nil {
+		ages.defaultAge = policy.Spec.MaxAge.Duration
+	}
+	for _, phaseAge := range policy.Spec.MaxAgeByPhase {
+		if ages.byPhase == nil {
+			ages.byPhase = make(map[corev1.PodPhase]time.Duration)
+		}
+		ages.byPhase[phaseAge.Phase] = phaseAge.MaxAge.Duration
+	}
+
+	if value, ok := ns.Annotations[cleanupv1.AnnotationTTLOverride]; ok {
+		// An unparseable override must not shorten retention, so the namespace
+		// is skipped until the annotation is fixed.
+		override, err := cleanupv1.ParseDuration(value)
+		if err != nil {
+			return maxAges{}, fmt.Errorf("invalid %s annotation %q: %w", cleanupv1.AnnotationTTLOverride, value, err)
+		}
+		ages.defaultAge = max(ages.defaultAge, override)
+		for phase, age := range ages.byPhase {
+			ages.byPhase[phase] = max(age, override)
+		}
+	}
+	return ages, nil
+}
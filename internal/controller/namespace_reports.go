@@ -0,0 +1,207 @@
+package controller
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	cleanupv1 "github.com/aravindavvaru/pod-cleanup-operator/api/v1"
+)
+
+//+kubebuilder:rbac:groups=cleanup.example.com,resources=cleanupreports,verbs=get;list;watch;create
+//+kubebuilder:rbac:groups=cleanup.example.com,resources=cleanupreports/status,verbs=get;update;patch
+
+// namespaceReportPeriod is how long a CleanupReport accumulates counts before a new
+// period starts.
+const namespaceReportPeriod = 24 * time.Hour
+
+// namespaceReports collects the pods runs remove and adds them to the CleanupReport
+// of their namespace once per interval. Removals not yet written are kept in memory.
+type namespaceReports struct {
+	client   client.Client
+	clock    clock.PassiveClock
+	interval time.Duration
+
+	mu      sync.Mutex
+	pending map[string]*namespaceActivity
+}
+
+// namespaceActivity counts the pods removed in a namespace since the last write of
+// its CleanupReport.
+type namespaceActivity struct {
+	removed  int32
+	byPolicy map[string]int32
+	byPhase  map[string]int32
+	byOwner  map[ownerKey]int32
+}
+
+type ownerKey struct {
+	kind, name string
+}
+
+func newNamespaceReports(c client.Client, clock clock.PassiveClock, interval time.Duration) *namespaceReports {
+	return &namespaceReports{client: c, clock: clock, interval: interval, pending: make(map[string]*namespaceActivity)}
+}
+
+// record counts a pod the policy removed.
+func (n *namespaceReports) record(policy string, pod *corev1.Pod) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	activity := n.activityOf(pod.Namespace)
+	activity.removed++
+	activity.byPolicy[policy]++
+	activity.byPhase[string(pod.Status.Phase)]++
+	if owner := metav1.GetControllerOf(pod); owner != nil {
+		activity.byOwner[ownerKey{kind: owner.Kind, name: owner.Name}]++
+	}
+}
+
+// activityOf returns the pending activity of the namespace. n.mu must be held.
+func (n *namespaceReports) activityOf(namespace string) *namespaceActivity {
+	activity, ok := n.pending[namespace]
+	if !ok {
+		activity = &namespaceActivity{
+			byPolicy: make(map[string]int32),
+			byPhase:  make(map[string]int32),
+			byOwner:  make(map[ownerKey]int32),
+		}
+		n.pending[namespace] = activity
+	}
+	return activity
+}
+
+// Start writes the pending activity every interval until ctx is done, then writes
+// what is left. It implements the controller-runtime Runnable interface.
+func (n *namespaceReports) Start(ctx context.Context) error {
+	ticker := time.NewTicker(n.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			n.flush(ctx)
+		case <-ctx.Done():
+			flushCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			n.flush(flushCtx)
+			return nil
+		}
+	}
+}
+
+// flush adds the pending activity to the CleanupReports. Activity that could not be
+// written is kept for the next flush.
+func (n *namespaceReports) flush(ctx context.Context) {
+	n.mu.Lock()
+	pending := n.pending
+	n.pending = make(map[string]*namespaceActivity)
+	n.mu.Unlock()
+
+	for namespace, activity := range pending {
+		if err := n.write(ctx, namespace, activity); err != nil {
+			log.FromContext(ctx).Error(err, "Failed to update CleanupReport", "namespace", namespace)
+			n.restore(namespace, activity)
+		}
+	}
+}
+
+// restore returns activity that could not be written to the pending activity.
+func (n *namespaceReports) restore(namespace string, activity *namespaceActivity) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	pending := n.activityOf(namespace)
+	pending.removed += activity.removed
+	for policy, count := range activity.byPolicy {
+		pending.byPolicy[policy] += count
+	}
+	for phase, count := range activity.byPhase {
+		pending.byPhase[phase] += count
+	}
+	for owner, count := range activity.byOwner {
+		pending.byOwner[owner] += count
+	}
+}
+
+// write adds the activity to the namespace's CleanupReport, creating it if needed.
+func (n *namespaceReports) write(ctx context.Context, namespace string, activity *namespaceActivity) error {
+	report := &cleanupv1.CleanupReport{}
+	err := n.client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: cleanupv1.CleanupReportName}, report)
+	if errors.IsNotFound(err) {
+		report = &cleanupv1.CleanupReport{ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      cleanupv1.CleanupReportName,
+			Labels:    map[string]string{"app.kubernetes.io/managed-by": "pod-cleanup-operator"},
+		}}
+		err = n.client.Create(ctx, report)
+	}
+	if err != nil {
+		return err
+	}
+
+	return updateStatus(ctx, n.client, report, func() {
+		now := metav1.NewTime(n.clock.Now())
+		status := &report.Status
+		if status.PeriodStart == nil || !now.Before(&metav1.Time{Time: status.PeriodStart.Add(namespaceReportPeriod)}) {
+			*status = cleanupv1.CleanupReportStatus{PeriodStart: &now}
+		}
+		status.LastUpdateTime = &now
+		status.PodsRemoved += activity.removed
+		status.ByPolicy = addCleanupCounts(status.ByPolicy, activity.byPolicy)
+		status.ByPhase = addCleanupCounts(status.ByPhase, activity.byPhase)
+		status.TopOwners = addOwnerCounts(status.TopOwners, activity.byOwner)
+	})
+}
+
+// addCleanupCounts adds counts to the named counts, sorted by name.
+func addCleanupCounts(counts []cleanupv1.CleanupCount, add map[string]int32) []cleanupv1.CleanupCount {
+	merged := make(map[string]int32, len(counts)+len(add))
+	for _, c := range counts {
+		merged[c.Name] = c.Count
+	}
+	for name, count := range add {
+		merged[name] += count
+	}
+	result := make([]cleanupv1.CleanupCount, 0, len(merged))
+	for name, count := range merged {
+		result = append(result, cleanupv1.CleanupCount{Name: name, Count: count})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+// addOwnerCounts adds counts to the owner counts and keeps the owners with the most
+// removed pods. Owners that dropped out of the list start over from the added
+// count, so the counts of owners near the cutoff are approximate.
+func addOwnerCounts(counts []cleanupv1.OwnerCleanupCount, add map[ownerKey]int32) []cleanupv1.OwnerCleanupCount {
+	merged := make(map[ownerKey]int32, len(counts)+len(add))
+	for _, c := range counts {
+		merged[ownerKey{kind: c.Kind, name: c.Name}] = c.Count
+	}
+	for owner, count := range add {
+		merged[owner] += count
+	}
+	result := make([]cleanupv1.OwnerCleanupCount, 0, len(merged))
+	for owner, count := range merged {
+		result = append(result, cleanupv1.OwnerCleanupCount{Kind: owner.kind, Name: owner.name, Count: count})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		if result[i].Kind != result[j].Kind {
+			return result[i].Kind < result[j].Kind
+		}
+		return result[i].Name < result[j].Name
+	})
+	if len(result) > cleanupv1.MaxReportedOwners {
+		result = result[:cleanupv1.MaxReportedOwners]
+	}
+	return result
+}
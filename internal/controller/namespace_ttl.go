@@ -0,0 +1,114 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	cleanupv1 "github.com/aravindavvaru/pod-cleanup-operator/api/v1"
+)
+
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=delete
+
+// namespaceExpired reports whether the namespace outlived the policy's namespaceTTL,
+// or the TTL set by its namespace-ttl annotation. It is false for policies without
+// a namespaceTTL.
+func namespaceExpired(policy *cleanupv1.PodCleanupPolicy, ns *corev1.Namespace, now time.Time) (bool, error) {
+	if policy.Spec.NamespaceTTL == nil {
+		return false, nil
+	}
+	value := policy.Spec.NamespaceTTL.TTL
+	if override, ok := ns.Annotations[cleanupv1.AnnotationNamespaceTTL]; ok {
+		value = override
+	}
+	ttl, err := cleanupv1.ParseDuration(value)
+	if err != nil {
+		// A namespace must never expire early because of a typo, so it is skipped
+		// until the annotation is fixed.
+		return false, fmt.Errorf("invalid namespace TTL %q: %w", value, err)
+	}
+	return !now.Before(ns.CreationTimestamp.Add(ttl)), nil
+}
+
+// shieldedPod describes a pod of the namespace that a Protect policy or a
+// PodRetentionPolicy keeps, or returns "" if there is none.
+func (r *PodCleanupPolicyReconciler) shieldedPod(ctx context.Context, run *cleanupRun, ns *corev1.Namespace) (string, error) {
+	now := r.Clock.Now()
+	shielded := ""
+	err := r.forEachListedPod(ctx, run, ns.Name, []client.ListOption{client.InNamespace(ns.Name)}, "", func(string) {},
+		func(pod *corev1.Pod) error {
+			if shielded != "" {
+				return nil
+			}
+			if protector := protectingPolicy(run, ns, pod); protector != "" {
+				shielded = fmt.Sprintf("Protect policy %s matches pod %s", protector, pod.Name)
+			} else if retention, until := retainingPolicy(run, ns, pod, now); retention != nil {
+				shielded = fmt.Sprintf("PodRetentionPolicy %s retains pod %s until %s", retention.name, pod.Name,
+					until.UTC().Format(time.RFC3339))
+			}
+			return nil
+		})
+	return shielded, err
+}
+
+// deleteExpiredNamespace deletes a namespace whose TTL expired, or only reports it
+// in dry runs. Namespaces holding pods shielded by a Protect policy or a
+// PodRetentionPolicy are kept until the pods are no longer shielded. Each deletion
+// takes one deletion from every ClusterCleanupBudget; an exhausted budget defers it
+// to a later run.
+func (r *PodCleanupPolicyReconciler) deleteExpiredNamespace(ctx context.Context, run *cleanupRun, ns *corev1.Namespace) error {
+	logger := log.FromContext(ctx)
+	age := r.Clock.Since(ns.CreationTimestamp.Time).Round(time.Second)
+	shielded, err := r.shieldedPod(ctx, run, ns)
+	if err != nil {
+		return err
+	}
+	if shielded != "" {
+		logger.V(1).Info("Keeping expired namespace with shielded pods", "namespace", ns.Name, "reason", shielded)
+		run.explain(ctx, ns.Name, "", false, ReasonNamespaceShielded, "namespace is %s old and expired, but %s", age, shielded)
+		return nil
+	}
+	if run.dryRun {
+		if !run.preview {
+			logger.Info("DryRun: would delete expired namespace", "namespace", ns.Name, "age", age)
+		}
+		run.explain(ctx, ns.Name, "", true, ReasonNamespaceExpired, "namespace is %s old and expired", age)
+		run.namespacesDeleted++
+		return nil
+	}
+
+	reservation, exhaustedBudget, err := r.reserveBudget(ctx, run.qualifiedNamespace(ns.Name))
+	if err != nil {
+		return fmt.Errorf("reserving cleanup budget: %w", err)
+	}
+	if exhaustedBudget != "" {
+		logger.Info("Deferring expired namespace deletion; cleanup budget exhausted",
+			"namespace", ns.Name, "budget", exhaustedBudget)
+		run.explain(ctx, ns.Name, "", false, ReasonDeferredByBudget,
+			"namespace is %s old and expired, but ClusterCleanupBudget %s is exhausted", age, exhaustedBudget)
+		return nil
+	}
+	// Namespaces are deleted as the policy's ServiceAccount, like its pods.
+	err = run.podClient.Delete(ctx, ns, client.Preconditions{UID: &ns.UID})
+	if errors.IsNotFound(err) || errors.IsConflict(err) {
+		// Deleted, or replaced by a namespace of the same name, in the meantime.
+		r.releaseBudget(reservation)
+		return nil
+	}
+	if err != nil {
+		r.releaseBudget(reservation)
+		return fmt.Errorf("deleting expired namespace: %w", err)
+	}
+	logger.Info("Deleted expired namespace", "namespace", ns.Name, "age", age)
+	r.Recorder.Eventf(run.policy, corev1.EventTypeNormal, "NamespaceDeleted",
+		"Deleted namespace %s, which expired at age %s", ns.Name, age)
+	r.auditNamespaceDeleted(ctx, run, ns, age)
+	run.explain(ctx, ns.Name, "", true, ReasonNamespaceExpired, "namespace is %s old and expired", age)
+	run.namespacesDeleted++
+	return nil
+}
@@ -0,0 +1,49 @@
+package controller
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cleanupv1 "github.com/aravindavvaru/pod-cleanup-operator/api/v1"
+)
+
+// noMatchesRunThreshold is the number of consecutive runs matching nothing after
+// which a policy is flagged NoMatches.
+const noMatchesRunThreshold = 3
+
+// matchedNothing returns why the run matched nothing, NoNamespaces or NoPods, or ""
+// if its selectors matched namespaces and pods.
+func (run *cleanupRun) matchedNothing() string {
+	switch {
+	case run.namespacesTargeted == 0:
+		return cleanupv1.ReasonNoNamespaces
+	case run.podsListed == 0:
+		return cleanupv1.ReasonNoPods
+	}
+	return ""
+}
+
+// recordMatches counts a complete run in status.runsWithoutMatches and sets the
+// NoMatches condition once the count reaches noMatchesRunThreshold. It runs in the
+// status update of the run, and reports whether the count just reached the
+// threshold.
+func (r *PodCleanupPolicyReconciler) recordMatches(policy *cleanupv1.PodCleanupPolicy, run *cleanupRun) bool {
+	reason := run.matchedNothing()
+	if reason == "" {
+		policy.Status.RunsWithoutMatches = 0
+		r.setCondition(policy, cleanupv1.ConditionNoMatches, metav1.ConditionFalse, cleanupv1.ReasonMatched, "The last run matched pods")
+		return false
+	}
+	policy.Status.RunsWithoutMatches++
+	if policy.Status.RunsWithoutMatches < noMatchesRunThreshold {
+		return false
+	}
+	msg := fmt.Sprintf("The last %d runs matched no namespaces; check namespaceSelector", policy.Status.RunsWithoutMatches)
+	if reason == cleanupv1.ReasonNoPods {
+		msg = fmt.Sprintf("The last %d runs matched no pods in %d namespace(s); check podSelector and podStatuses",
+			policy.Status.RunsWithoutMatches, run.namespacesTargeted)
+	}
+	r.setCondition(policy, cleanupv1.ConditionNoMatches, metav1.ConditionTrue, reason, msg)
+	return policy.Status.RunsWithoutMatches == noMatchesRunThreshold
+}
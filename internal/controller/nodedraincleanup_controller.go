@@ -0,0 +1,376 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	cleanupv1 "github.com/aravindavvaru/pod-cleanup-operator/api/v1"
+	"github.com/aravindavvaru/pod-cleanup-operator/internal/audit"
+	"github.com/aravindavvaru/pod-cleanup-operator/internal/features"
+)
+
+// drainPollInterval is how often the drain of a node advances while pods remain on it.
+const drainPollInterval = 10 * time.Second
+
+// NodeDrainCleanupReconciler drains the nodes NodeDrainCleanups select once they are
+// cordoned and annotated for drain.
+type NodeDrainCleanupReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	// APIReader lists the pods of drained nodes, which may not be cached.
+	APIReader client.Reader
+	// Recorder emits Events on NodeDrainCleanups.
+	Recorder record.EventRecorder
+	// Clock supplies the current time for status timestamps. SetupWithManager
+	// defaults it to the system clock.
+	Clock clock.PassiveClock
+	// Policies holds the limits every pod removal is subject to: the OperatorConfig
+	// rate limit, backpressure, the API budget, ClusterCleanupBudgets and the audit
+	// outputs.
+	Policies *PodCleanupPolicyReconciler
+}
+
+//+kubebuilder:rbac:groups=cleanup.example.com,resources=nodedraincleanups,verbs=get;list;watch
+//+kubebuilder:rbac:groups=cleanup.example.com,resources=nodedraincleanups/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups="",resources=nodes,verbs=patch
+
+// Reconcile advances the drain of every node the NodeDrainCleanup selects and
+// records their progress.
+func (r *NodeDrainCleanupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	drain := &cleanupv1.NodeDrainCleanup{}
+	if err := r.Get(ctx, req.NamespacedName, drain); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if errs := drain.Validate(); len(errs) > 0 {
+		return ctrl.Result{}, updateStatus(ctx, r.Client, drain, func() {
+			drain.Status.ObservedGeneration = drain.Generation
+			setDrainCondition(drain, cleanupv1.ConditionReady, metav1.ConditionFalse, cleanupv1.ReasonInvalidSpec,
+				errs.ToAggregate().Error())
+		})
+	}
+	if drain.Spec.Action != cleanupv1.DrainActionDelete && !features.Enabled(features.Eviction) {
+		return ctrl.Result{}, updateStatus(ctx, r.Client, drain, func() {
+			drain.Status.ObservedGeneration = drain.Generation
+			setDrainCondition(drain, cleanupv1.ConditionReady, metav1.ConditionFalse, cleanupv1.ReasonFeatureDisabled,
+				fmt.Sprintf("action Evict requires the %s feature gate", features.Eviction))
+		})
+	}
+
+	config, err := r.Policies.getOperatorConfig(ctx)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	r.Policies.applyRateLimit(config)
+	r.Policies.applyAPIBudget(config)
+
+	nodes, err := r.drainedNodes(ctx, drain)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	previous := make(map[string]cleanupv1.NodeDrainStatus, len(drain.Status.Nodes))
+	for _, status := range drain.Status.Nodes {
+		previous[status.Name] = status
+	}
+	statuses := make([]cleanupv1.NodeDrainStatus, 0, len(nodes))
+	draining := 0
+	for i := range nodes {
+		node := &nodes[i]
+		status, ok := previous[node.Name]
+		if !ok {
+			now := metav1.NewTime(r.Clock.Now())
+			status = cleanupv1.NodeDrainStatus{Name: node.Name, Phase: cleanupv1.NodeDrainDraining, StartTime: &now}
+		}
+		if status.Phase != cleanupv1.NodeDrainCompleted {
+			if err := r.drainNode(ctx, drain, config, node, &status); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+		if status.Phase == cleanupv1.NodeDrainDraining {
+			draining++
+		}
+		statuses = append(statuses, status)
+	}
+
+	err = updateStatus(ctx, r.Client, drain, func() {
+		drain.Status.ObservedGeneration = drain.Generation
+		drain.Status.Nodes = statuses
+		setDrainCondition(drain, cleanupv1.ConditionReady, metav1.ConditionTrue, cleanupv1.ReasonWatching,
+			fmt.Sprintf("%d node(s) draining, %d drained", draining, len(statuses)-draining))
+	})
+	if err != nil || draining == 0 {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: drainPollInterval}, nil
+}
+
+// drainedNodes returns the nodes the NodeDrainCleanup selects that are cordoned and
+// annotated for drain.
+func (r *NodeDrainCleanupReconciler) drainedNodes(ctx context.Context, drain *cleanupv1.NodeDrainCleanup) ([]corev1.Node, error) {
+	nodeList := &corev1.NodeList{}
+	if err := r.List(ctx, nodeList); err != nil {
+		return nil, fmt.Errorf("listing nodes: %w", err)
+	}
+	var nodes []corev1.Node
+	for _, node := range nodeList.Items {
+		if nodeDrainRequested(&node) && selectorMatches(drain.Spec.NodeSelector, node.Labels) {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes, nil
+}
+
+// nodeDrainRequested reports whether the node is cordoned and annotated for drain.
+func nodeDrainRequested(node *corev1.Node) bool {
+	return node.Spec.Unschedulable && node.Annotations[cleanupv1.AnnotationDrain] == "true"
+}
+
+// drainNode removes the next pods from the node and updates its status. Finished
+// pods are removed right away; running pods in ascending order of priority, each
+// priority only once the pods of lower priorities have left. Once no selected pod is
+// left, the node is annotated with the drain's completion time.
+func (r *NodeDrainCleanupReconciler) drainNode(ctx context.Context, drain *cleanupv1.NodeDrainCleanup, config *cleanupv1.OperatorConfigSpec,
+	node *corev1.Node, status *cleanupv1.NodeDrainStatus) error {
+	logger := log.FromContext(ctx).WithValues("node", node.Name)
+	pods, err := r.podsToDrain(ctx, drain, node)
+	if err != nil {
+		return err
+	}
+	if len(pods) == 0 {
+		now := metav1.NewTime(r.Clock.Now())
+		patch := client.MergeFrom(node.DeepCopy())
+		if node.Annotations == nil {
+			node.Annotations = make(map[string]string)
+		}
+		node.Annotations[cleanupv1.AnnotationDrainedAt] = now.UTC().Format(time.RFC3339)
+		if err := r.Patch(ctx, node, patch); err != nil {
+			return fmt.Errorf("marking node %s drained: %w", node.Name, err)
+		}
+		logger.Info("Node drained", "podsRemoved", status.PodsRemoved)
+		r.Recorder.Eventf(drain, corev1.EventTypeNormal, "NodeDrained",
+			"Node %s drained; %d pod(s) removed", node.Name, status.PodsRemoved)
+		status.Phase, status.CompletionTime = cleanupv1.NodeDrainCompleted, &now
+		status.PodsRemaining, status.PodsBlocked, status.CurrentPriority, status.Message = 0, 0, nil, ""
+		return nil
+	}
+
+	var current *int32
+	for _, pod := range pods {
+		if podFinished(pod) {
+			continue
+		}
+		if priority := podPriority(pod); current == nil || priority < *current {
+			current = &priority
+		}
+	}
+	removed, blocked, deferred, exhaustedBudget, message := 0, 0, 0, "", ""
+	for _, pod := range pods {
+		if pod.DeletionTimestamp != nil || (!podFinished(pod) && podPriority(pod) != *current) {
+			continue
+		}
+		budget, err := r.removeDrainedPod(ctx, drain, config, pod)
+		switch {
+		case err != nil && ctx.Err() != nil:
+			// The controller stopped while waiting for the limiters or the API budget.
+			return err
+		case budget != "":
+			logger.V(1).Info("Deferring pod removal; cleanup budget exhausted",
+				"namespace", pod.Namespace, "pod", pod.Name, "budget", budget)
+			budgetDeferredPods.WithLabelValues(budget).Inc()
+			deferred++
+			exhaustedBudget = budget
+		case err == nil:
+			logger.V(1).Info("Removed pod from drained node", "namespace", pod.Namespace, "pod", pod.Name)
+			removed++
+		case errors.IsNotFound(err):
+		case errors.IsTooManyRequests(err):
+			// A PodDisruptionBudget does not allow the eviction yet.
+			blocked++
+		default:
+			logger.Error(err, "Failed to remove pod from drained node", "namespace", pod.Namespace, "pod", pod.Name)
+			message = fmt.Sprintf("Removing pod %s/%s: %v", pod.Namespace, pod.Name, err)
+		}
+	}
+	switch {
+	case message != "":
+	case blocked > 0:
+		message = fmt.Sprintf("%d pod(s) wait for their PodDisruptionBudget to allow eviction", blocked)
+	case deferred > 0:
+		message = fmt.Sprintf("%d pod(s) wait for ClusterCleanupBudget %s", deferred, exhaustedBudget)
+	}
+	status.PodsRemoved += int32(removed)
+	status.PodsRemaining = int32(len(pods))
+	status.PodsBlocked = int32(blocked)
+	status.CurrentPriority = current
+	status.Message = message
+	return nil
+}
+
+// podsToDrain returns the pods on the node the NodeDrainCleanup removes: those
+// matching its podSelector, other than DaemonSet pods, which would be recreated on the
+// node, and static pods, which the API server cannot remove.
+func (r *NodeDrainCleanupReconciler) podsToDrain(ctx context.Context, drain *cleanupv1.NodeDrainCleanup, node *corev1.Node) ([]*corev1.Pod, error) {
+	opts := []client.ListOption{client.MatchingFields{"spec.nodeName": node.Name}}
+	if drain.Spec.PodSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(drain.Spec.PodSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid podSelector: %w", err)
+		}
+		opts = append(opts, client.MatchingLabelsSelector{Selector: selector})
+	}
+	podList := &corev1.PodList{}
+	if err := r.APIReader.List(ctx, podList, opts...); err != nil {
+		return nil, fmt.Errorf("listing pods on node %s: %w", node.Name, err)
+	}
+	var pods []*corev1.Pod
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if _, mirror := pod.Annotations[corev1.MirrorPodAnnotationKey]; mirror {
+			continue
+		}
+		if owner := metav1.GetControllerOf(pod); owner != nil && owner.Kind == "DaemonSet" {
+			continue
+		}
+		pods = append(pods, pod)
+	}
+	return pods, nil
+}
+
+// removeDrainedPod evicts or deletes a pod of a drained node. Like the deletions of
+// policies, the removal reserves the ClusterCleanupBudgets, waits for the
+// OperatorConfig rate limit, backpressure and the API budget, and is audited. It
+// returns the name of the budget that deferred the pod, if one is exhausted.
+func (r *NodeDrainCleanupReconciler) removeDrainedPod(ctx context.Context, drain *cleanupv1.NodeDrainCleanup,
+	config *cleanupv1.OperatorConfigSpec, pod *corev1.Pod) (string, error) {
+	action := cleanupv1.RuleActionDelete
+	if drain.Spec.Action != cleanupv1.DrainActionDelete && !podFinished(pod) {
+		action = cleanupv1.RuleActionEvict
+		if !features.Enabled(features.Eviction) {
+			return "", &featureDisabledError{fmt.Sprintf("action Evict requires the %s feature gate", features.Eviction)}
+		}
+	}
+
+	policies := r.Policies
+	reservation, exhaustedBudget, err := policies.reserveBudget(ctx, pod.Namespace)
+	if err != nil {
+		return "", fmt.Errorf("reserving cleanup budget: %w", err)
+	}
+	if exhaustedBudget != "" {
+		return exhaustedBudget, nil
+	}
+	removed := false
+	defer func() {
+		if !removed {
+			policies.releaseBudget(reservation)
+		}
+	}()
+	if err := policies.deleteLimiter.Wait(ctx); err != nil {
+		return "", err
+	}
+	if err := policies.Backpressure.Wait(ctx); err != nil {
+		return "", err
+	}
+	err = policies.withAPIBudget(ctx, func() error {
+		if action == cleanupv1.RuleActionDelete {
+			var opts []client.DeleteOption
+			if drain.Spec.GracePeriodSeconds != nil {
+				opts = append(opts, client.GracePeriodSeconds(*drain.Spec.GracePeriodSeconds))
+			}
+			return r.Delete(ctx, pod, opts...)
+		}
+		eviction := &policyv1.Eviction{
+			ObjectMeta: metav1.ObjectMeta{Name: pod.Name, Namespace: pod.Namespace},
+		}
+		if drain.Spec.GracePeriodSeconds != nil {
+			eviction.DeleteOptions = &metav1.DeleteOptions{GracePeriodSeconds: drain.Spec.GracePeriodSeconds}
+		}
+		return r.SubResource("eviction").Create(ctx, pod, eviction)
+	})
+	if err != nil {
+		return "", err
+	}
+	removed = true
+	policies.writeAuditRecord(ctx, config, audit.Record{
+		Type:       audit.PodRemoved,
+		Drain:      drain.Name,
+		Namespace:  pod.Namespace,
+		Pod:        pod.Name,
+		UID:        string(pod.UID),
+		Phase:      string(pod.Status.Phase),
+		Node:       pod.Spec.NodeName,
+		AgeSeconds: int64(r.Clock.Since(pod.CreationTimestamp.Time) / time.Second),
+		Action:     string(action),
+	})
+	return "", nil
+}
+
+// podFinished reports whether the pod's containers have all terminated for good.
+func podFinished(pod *corev1.Pod) bool {
+	return pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed
+}
+
+// podPriority returns the pod's scheduling priority.
+func podPriority(pod *corev1.Pod) int32 {
+	if pod.Spec.Priority == nil {
+		return 0
+	}
+	return *pod.Spec.Priority
+}
+
+// setDrainCondition sets a condition of the NodeDrainCleanup, keeping its transition
+// time unless its status changes.
+func setDrainCondition(drain *cleanupv1.NodeDrainCleanup, condType string, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&drain.Status.Conditions, metav1.Condition{
+		Type:               condType,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: drain.Generation,
+	})
+}
+
+// drainsForNode maps a node to the NodeDrainCleanups that select it.
+func (r *NodeDrainCleanupReconciler) drainsForNode(ctx context.Context, obj client.Object) []reconcile.Request {
+	drainList := &cleanupv1.NodeDrainCleanupList{}
+	if err := r.List(ctx, drainList); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list NodeDrainCleanups for node", "node", obj.GetName())
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, drain := range drainList.Items {
+		if selectorMatches(drain.Spec.NodeSelector, obj.GetLabels()) {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: drain.Name}})
+		}
+	}
+	return requests
+}
+
+// SetupWithManager registers the controller with the manager.
+func (r *NodeDrainCleanupReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if r.Clock == nil {
+		r.Clock = clock.RealClock{}
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&cleanupv1.NodeDrainCleanup{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&corev1.Node{}, handler.EnqueueRequestsFromMapFunc(r.drainsForNode),
+			builder.WithPredicates(nodeDrainChanged())).
+		Complete(r)
+}
@@ -0,0 +1,95 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	cleanupv1 "github.com/aravindavvaru/pod-cleanup-operator/api/v1"
+)
+
+//+kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch
+
+// hasNodeCriteria reports whether the policy restricts candidates by their node.
+func hasNodeCriteria(policy *cleanupv1.PodCleanupPolicy) bool {
+	return policy.Spec.NodeLabelSelector != nil || len(policy.Spec.NodeConditions) > 0 || len(policy.Spec.NodeTaints) > 0
+}
+
+// nodeOf returns the node the pod is bound to, or nil if it is not bound or the node
+// no longer exists. Nodes are read from the run's cluster and memoized for the run.
+func (r *PodCleanupPolicyReconciler) nodeOf(ctx context.Context, run *cleanupRun, pod *corev1.Pod) (*corev1.Node, error) {
+	if pod.Spec.NodeName == "" {
+		return nil, nil
+	}
+	if node, ok := run.nodes[pod.Spec.NodeName]; ok {
+		return node, nil
+	}
+	node := &corev1.Node{}
+	if err := run.cluster.Get(ctx, client.ObjectKey{Name: pod.Spec.NodeName}, node); err != nil {
+		if !errors.IsNotFound(err) {
+			return nil, err
+		}
+		node = nil
+	}
+	if run.nodes == nil {
+		run.nodes = make(map[string]*corev1.Node)
+	}
+	run.nodes[pod.Spec.NodeName] = node
+	return node, nil
+}
+
+// podNodeMatches reports whether the pod's node satisfies the policy's node
+// criteria, with an explanation when it does not.
+func (r *PodCleanupPolicyReconciler) podNodeMatches(ctx context.Context, run *cleanupRun, pod *corev1.Pod) (bool, string) {
+	policy := run.policy
+	if !hasNodeCriteria(policy) {
+		return true, ""
+	}
+	node, err := r.nodeOf(ctx, run, pod)
+	if err != nil {
+		return false, fmt.Sprintf("node %s could not be read: %v", pod.Spec.NodeName, err)
+	}
+	if node == nil {
+		return false, "pod is not on an existing node"
+	}
+
+	if !selectorMatches(policy.Spec.NodeLabelSelector, node.Labels) {
+		return false, fmt.Sprintf("node %s does not match nodeLabelSelector", node.Name)
+	}
+	for _, match := range policy.Spec.NodeConditions {
+		if !nodeHasCondition(node, match) {
+			return false, fmt.Sprintf("node %s does not have condition %s=%s", node.Name, match.Type, match.Status)
+		}
+	}
+	if len(policy.Spec.NodeTaints) > 0 && !nodeHasAnyTaint(node, policy.Spec.NodeTaints) {
+		return false, fmt.Sprintf("node %s carries none of the nodeTaints", node.Name)
+	}
+	return true, ""
+}
+
+// nodeHasCondition reports whether the node has the condition with the given status.
+func nodeHasCondition(node *corev1.Node, match cleanupv1.NodeConditionMatch) bool {
+	for _, cond := range node.Status.Conditions {
+		if cond.Type == match.Type {
+			return cond.Status == match.Status
+		}
+	}
+	return false
+}
+
+// nodeHasAnyTaint reports whether the node carries a taint matching one of matches.
+func nodeHasAnyTaint(node *corev1.Node, matches []cleanupv1.TaintMatch) bool {
+	for _, taint := range node.Spec.Taints {
+		for _, match := range matches {
+			if taint.Key == match.Key &&
+				(match.Value == "" || taint.Value == match.Value) &&
+				(match.Effect == "" || taint.Effect == match.Effect) {
+				return true
+			}
+		}
+	}
+	return false
+}
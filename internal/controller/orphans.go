@@ -0,0 +1,166 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+//+kubebuilder:rbac:groups=apps,resources=replicasets;statefulsets;daemonsets,verbs=get
+//+kubebuilder:rbac:groups=batch,resources=jobs,verbs=get
+//+kubebuilder:rbac:groups="",resources=replicationcontrollers,verbs=get
+//+kubebuilder:rbac:groups="",resources=configmaps;secrets,verbs=list;delete
+
+// companionKinds are the kinds of the objects removed along with orphaned pods.
+var companionKinds = []string{"ConfigMap", "Secret"}
+
+// ownerExists reports whether the object a pod's owner reference points at still
+// exists with the referenced UID. An owner whose kind is no longer served, e.g.
+// because its CRD was removed, does not exist. Lookups are memoized for the run.
+func (r *PodCleanupPolicyReconciler) ownerExists(ctx context.Context, run *cleanupRun, namespace string, ref metav1.OwnerReference) (bool, error) {
+	if exists, ok := run.owners[ref.UID]; ok {
+		return exists, nil
+	}
+	gv, err := schema.ParseGroupVersion(ref.APIVersion)
+	if err != nil {
+		return false, err
+	}
+	gvk := gv.WithKind(ref.Kind)
+	exists := false
+	mapping, err := run.cluster.RESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version)
+	switch {
+	case meta.IsNoMatchError(err):
+	case err != nil:
+		return false, err
+	default:
+		owner := &metav1.PartialObjectMetadata{}
+		owner.SetGroupVersionKind(gvk)
+		key := client.ObjectKey{Name: ref.Name}
+		if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+			key.Namespace = namespace
+		}
+		err := run.clusterReader.Get(ctx, key, owner)
+		if err != nil && !errors.IsNotFound(err) {
+			return false, err
+		}
+		exists = err == nil && owner.UID == ref.UID
+	}
+	if run.owners == nil {
+		run.owners = make(map[types.UID]bool)
+	}
+	run.owners[ref.UID] = exists
+	return exists, nil
+}
+
+// podOrphaned reports whether the policy's orphaned criterion holds for the pod:
+// it has owners, and none of them exists any more. The explanation describes the
+// outcome.
+func (r *PodCleanupPolicyReconciler) podOrphaned(ctx context.Context, run *cleanupRun, pod *corev1.Pod) (bool, string) {
+	if run.policy.Spec.Orphaned == nil {
+		return true, ""
+	}
+	if len(pod.OwnerReferences) == 0 {
+		return false, "pod has no owners"
+	}
+	owners := make([]string, 0, len(pod.OwnerReferences))
+	for _, ref := range pod.OwnerReferences {
+		exists, err := r.ownerExists(ctx, run, pod.Namespace, ref)
+		if err != nil {
+			return false, fmt.Sprintf("owner %s %s could not be read: %v", ref.Kind, ref.Name, err)
+		}
+		if exists {
+			return false, fmt.Sprintf("owner %s %s exists", ref.Kind, ref.Name)
+		}
+		owners = append(owners, ref.Kind+" "+ref.Name)
+	}
+	return true, "owners no longer exist: " + strings.Join(owners, ", ")
+}
+
+// companionsOf returns the ConfigMaps and Secrets in the pod's namespace owned only
+// by the pod or its owners. The objects of each namespace are listed once per run.
+func (r *PodCleanupPolicyReconciler) companionsOf(ctx context.Context, run *cleanupRun, pod *corev1.Pod) ([]metav1.PartialObjectMetadata, error) {
+	objects, ok := run.companionObjects[pod.Namespace]
+	if !ok {
+		for _, kind := range companionKinds {
+			list := &metav1.PartialObjectMetadataList{}
+			list.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind(kind + "List"))
+			if err := run.clusterReader.List(ctx, list, client.InNamespace(pod.Namespace)); err != nil {
+				return nil, err
+			}
+			for _, obj := range list.Items {
+				if len(obj.OwnerReferences) > 0 {
+					obj.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind(kind))
+					objects = append(objects, obj)
+				}
+			}
+		}
+		if run.companionObjects == nil {
+			run.companionObjects = make(map[string][]metav1.PartialObjectMetadata)
+		}
+		run.companionObjects[pod.Namespace] = objects
+	}
+
+	owners := []types.UID{pod.UID}
+	for _, ref := range pod.OwnerReferences {
+		owners = append(owners, ref.UID)
+	}
+	var companions []metav1.PartialObjectMetadata
+	for _, obj := range objects {
+		if !slices.ContainsFunc(obj.OwnerReferences, func(ref metav1.OwnerReference) bool {
+			return !slices.Contains(owners, ref.UID)
+		}) {
+			companions = append(companions, obj)
+		}
+	}
+	return companions, nil
+}
+
+// removeCompanions deletes the companions of an orphaned pod the run removed, or
+// only logs them in dry runs. Failures are logged and do not fail the run.
+func (r *PodCleanupPolicyReconciler) removeCompanions(ctx context.Context, run *cleanupRun, pod *corev1.Pod) {
+	if orphaned := run.policy.Spec.Orphaned; orphaned == nil || !orphaned.Companions {
+		return
+	}
+	logger := log.FromContext(ctx)
+	companions, err := r.companionsOf(ctx, run, pod)
+	if err != nil {
+		logger.Error(err, "Failed to list companions of orphaned pod", "namespace", pod.Namespace, "pod", pod.Name)
+		return
+	}
+	for i := range companions {
+		obj := &companions[i]
+		kind := obj.GetObjectKind().GroupVersionKind().Kind
+		if run.dryRun {
+			if !run.preview {
+				logger.Info("DryRun: would delete companion of orphaned pod",
+					"namespace", obj.Namespace, "kind", kind, "name", obj.Name, "pod", pod.Name)
+			}
+			run.companionsDeleted++
+			continue
+		}
+		uid := obj.UID
+		err := r.withAPIBudget(ctx, func() error {
+			return run.podClient.Delete(ctx, obj, client.Preconditions{UID: &uid})
+		})
+		if errors.IsNotFound(err) || errors.IsConflict(err) {
+			continue
+		}
+		if err != nil {
+			logger.Error(err, "Failed to delete companion of orphaned pod",
+				"namespace", obj.Namespace, "kind", kind, "name", obj.Name, "pod", pod.Name)
+			continue
+		}
+		logger.Info("Deleted companion of orphaned pod", "namespace", obj.Namespace, "kind", kind, "name", obj.Name, "pod", pod.Name)
+		run.companionsDeleted++
+	}
+}
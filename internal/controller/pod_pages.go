@@ -0,0 +1,199 @@
+package controller
+
+import (
+	"context"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// defaultPodListChunkSize is the number of pods per list call when
+	// PodListChunkSize is not set.
+	defaultPodListChunkSize = 500
+	// podPhaseField is the field runs select pod phases with. The API server
+	// supports it natively; SetupWithManager indexes it in the cache.
+	podPhaseField = "status.phase"
+)
+
+// indexPodPhase is the cache index function of podPhaseField.
+func indexPodPhase(obj client.Object) []string {
+	return []string{string(obj.(*corev1.Pod).Status.Phase)}
+}
+
+// seesEveryPod reports whether the run must see every pod its podSelector matches.
+// Explaining runs see every pod to explain why it was skipped, and policies
+// annotating candidates or warning of deletions every pod to clear stale annotations.
+func (run *cleanupRun) seesEveryPod() bool {
+	return run.explaining || run.policy.Spec.AnnotateCandidates || run.policy.Spec.WarnBefore != nil
+}
+
+// listedPhases returns the phases of the pods the run can act on, or nil if it must
+// see pods of every phase.
+func (run *cleanupRun) listedPhases() []corev1.PodPhase {
+	policy := run.policy
+	if run.seesEveryPod() {
+		return nil
+	}
+	if len(policy.Spec.PodStatuses) > 0 {
+		phases := slices.Clone(policy.Spec.PodStatuses)
+		slices.Sort(phases)
+		return slices.Compact(phases)
+	}
+	if run.maintenanceNodes != nil {
+		return []corev1.PodPhase{corev1.PodSucceeded, corev1.PodFailed}
+	}
+	return nil
+}
+
+// podCacheFor returns the informer cache holding the pods of namespace, or nil if
+// they are read from the API server: when Client does not cache pods, or when
+// ScopePodWatches is set and the namespace's pods are not watched or not yet synced.
+func (r *PodCleanupPolicyReconciler) podCacheFor(namespace string) client.Reader {
+	switch {
+	case r.podWatches != nil:
+		return r.podWatches.readerFor(namespace)
+	case r.CachedPods:
+		return r.Client
+	}
+	return nil
+}
+
+// cachedPods returns the informer cache holding the run's pods of namespace, or nil
+// if the run reads them from the API server.
+func (run *cleanupRun) cachedPods(namespace string) client.Reader {
+	if run.podCache == nil {
+		return nil
+	}
+	return run.podCache(namespace)
+}
+
+// forEachPod calls fn for every pod of namespace matching opts in a phase the run
+// can act on, starting at from and stopping at the first error. Pods of other
+// phases are never listed. onPage is called with the position of every further page
+// listed from the API server before it is listed.
+func (r *PodCleanupPolicyReconciler) forEachPod(ctx context.Context, run *cleanupRun, namespace string, opts []client.ListOption, from podListPosition, onPage func(podListPosition), fn func(pod *corev1.Pod) error) error {
+	if indexed, err := r.forEachCandidate(ctx, run, namespace, fn); indexed || err != nil {
+		return err
+	}
+	phases := run.listedPhases()
+	if phases == nil {
+		return r.forEachListedPod(ctx, run, namespace, opts, from.continueToken, func(token string) {
+			onPage(podListPosition{continueToken: token})
+		}, fn)
+	}
+	for _, phase := range phases {
+		if phase < from.phase {
+			continue
+		}
+		continueToken := ""
+		if phase == from.phase {
+			continueToken = from.continueToken
+		}
+		phaseOpts := append(slices.Clip(opts), client.MatchingFields{podPhaseField: string(phase)})
+		if err := r.forEachListedPod(ctx, run, namespace, phaseOpts, continueToken, func(token string) {
+			onPage(podListPosition{phase: phase, continueToken: token})
+		}, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// forEachCandidate calls fn for every candidate of the run's policy in namespace held
+// by the candidate index, in name order, stopping at the first error. It returns
+// false without calling fn if the run lists the pods instead: without the index, for
+// runs that must see every pod, and for pods not read from a pod cache.
+func (r *PodCleanupPolicyReconciler) forEachCandidate(ctx context.Context, run *cleanupRun, namespace string, fn func(pod *corev1.Pod) error) (bool, error) {
+	reader := run.cachedPods(namespace)
+	if r.candidates == nil || reader == nil || run.seesEveryPod() {
+		return false, nil
+	}
+	names, ok, err := r.candidates.candidates(ctx, reader, run.policy, namespace)
+	if !ok || err != nil {
+		return false, err
+	}
+	phases := run.listedPhases()
+	for _, name := range names {
+		pod := &corev1.Pod{}
+		if err := reader.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, pod); err != nil {
+			if errors.IsNotFound(err) {
+				// Deleted since, while its namespace was not watched.
+				continue
+			}
+			return true, err
+		}
+		if phases != nil && !slices.Contains(phases, pod.Status.Phase) {
+			continue
+		}
+		if err := fn(pod); err != nil {
+			return true, err
+		}
+	}
+	return true, nil
+}
+
+// forEachListedPod calls fn for every pod of namespace matching opts, stopping at
+// the first error, so a run holds at most one page of pods at a time. Pods read from
+// the API server are listed in pages of PodListChunkSize, each list call within the
+// API budget, starting at continueToken; onPage is called with the token of every
+// further page. Pods read from the informer cache are listed without copying them,
+// and each is copied only while fn processes it; they cannot start past the first.
+func (r *PodCleanupPolicyReconciler) forEachListedPod(ctx context.Context, run *cleanupRun, namespace string, opts []client.ListOption, continueToken string, onPage func(string), fn func(pod *corev1.Pod) error) error {
+	if reader := run.cachedPods(namespace); reader != nil {
+		podList := &corev1.PodList{}
+		if err := reader.List(ctx, podList, append(opts, client.UnsafeDisableDeepCopy)...); err != nil {
+			return err
+		}
+		for i := range podList.Items {
+			// The items share their maps and slices with the cache.
+			if err := fn(podList.Items[i].DeepCopy()); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	chunkSize := r.PodListChunkSize
+	if chunkSize <= 0 {
+		chunkSize = defaultPodListChunkSize
+	}
+	restarted := false
+	for {
+		podList := &corev1.PodList{}
+		err := r.withAPIBudget(ctx, func() error {
+			return run.podClient.List(ctx, podList, append(opts, client.Limit(chunkSize), client.Continue(continueToken))...)
+		})
+		if errors.IsResourceExpired(err) {
+			// Rate-limited runs can outlive a continue token. The API server then
+			// offers a token continuing after the last pod listed, at the current
+			// state; pods it misses are handled by the next run.
+			if status, ok := err.(errors.APIStatus); ok && status.Status().ListMeta.Continue != "" {
+				continueToken = status.Status().ListMeta.Continue
+				continue
+			}
+			// Without such a token, e.g. for a checkpointed token too old to
+			// continue from, the namespace is listed again from the start.
+			if !restarted && continueToken != "" {
+				restarted, continueToken = true, ""
+				continue
+			}
+		}
+		if err != nil {
+			return err
+		}
+		for i := range podList.Items {
+			if err := fn(&podList.Items[i]); err != nil {
+				return err
+			}
+		}
+		if podList.Continue == "" {
+			return nil
+		}
+		continueToken = podList.Continue
+		onPage(continueToken)
+	}
+}
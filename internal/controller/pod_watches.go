@@ -0,0 +1,231 @@
+package controller
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	toolscache "k8s.io/client-go/tools/cache"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	cleanupv1 "github.com/aravindavvaru/pod-cleanup-operator/api/v1"
+)
+
+const (
+	// allNamespaces keys the cluster-wide pod cache in podWatches.caches.
+	allNamespaces = ""
+	// podWatchResync is how often the watched namespaces are recomputed without an
+	// event hinting at a change.
+	podWatchResync = 5 * time.Minute
+)
+
+// podWatches caches pods of just the namespaces the policies select, instead of
+// every namespace. When every policy that cleans up pods has a namespaceSelector,
+// it keeps one pod cache per namespace in the union of their selections, starting
+// and stopping caches as namespaces gain or lose labels and policies change;
+// otherwise it keeps a single cluster-wide cache. Runs read pods of namespaces
+// without a synced cache from the API server.
+type podWatches struct {
+	mgr ctrl.Manager
+
+	mu     sync.Mutex
+	caches map[string]*podCache
+	// changed is signaled when a namespace or policy changed.
+	changed chan struct{}
+	// handler, if set, receives the events of every pod cache.
+	handler toolscache.ResourceEventHandler
+}
+
+// podCache is the pod cache of one namespace, or of all namespaces.
+type podCache struct {
+	cache  cache.Cache
+	cancel context.CancelFunc
+	synced chan struct{}
+}
+
+func newPodWatches(mgr ctrl.Manager) *podWatches {
+	return &podWatches{mgr: mgr, caches: make(map[string]*podCache), changed: make(chan struct{}, 1)}
+}
+
+// setup watches namespaces and policies for changes of the watched namespaces.
+func (w *podWatches) setup() error {
+	handler := toolscache.ResourceEventHandlerFuncs{
+		AddFunc:    func(any) { w.signal() },
+		UpdateFunc: func(any, any) { w.signal() },
+		DeleteFunc: func(any) { w.signal() },
+	}
+	for _, obj := range []client.Object{&corev1.Namespace{}, &cleanupv1.PodCleanupPolicy{}} {
+		informer, err := w.mgr.GetCache().GetInformer(context.Background(), obj)
+		if err != nil {
+			return err
+		}
+		if _, err := informer.AddEventHandler(handler); err != nil {
+			return err
+		}
+	}
+	return w.mgr.Add(w)
+}
+
+// signal requests a recomputation of the watched namespaces.
+func (w *podWatches) signal() {
+	select {
+	case w.changed <- struct{}{}:
+	default:
+	}
+}
+
+// Start keeps the pod caches in line with the policies until ctx is done.
+func (w *podWatches) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("pod-watches")
+	ticker := time.NewTicker(podWatchResync)
+	defer ticker.Stop()
+	defer w.stopAll()
+	if !w.mgr.GetCache().WaitForCacheSync(ctx) {
+		return nil
+	}
+	for {
+		if err := w.sync(ctx); err != nil {
+			logger.Error(err, "Failed to update watched namespaces")
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-w.changed:
+		case <-ticker.C:
+		}
+	}
+}
+
+// sync starts the caches of newly watched namespaces and stops the others.
+func (w *podWatches) sync(ctx context.Context) error {
+	want, err := w.watchedNamespaces(ctx)
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for namespace, c := range w.caches {
+		if !want[namespace] {
+			c.cancel()
+			delete(w.caches, namespace)
+			log.FromContext(ctx).V(1).Info("Stopped watching pods", "namespace", namespace)
+		}
+	}
+	for namespace := range want {
+		if _, ok := w.caches[namespace]; ok {
+			continue
+		}
+		c, err := w.startCache(ctx, namespace)
+		if err != nil {
+			return err
+		}
+		w.caches[namespace] = c
+		log.FromContext(ctx).V(1).Info("Watching pods", "namespace", namespace)
+	}
+	return nil
+}
+
+// watchedNamespaces returns the namespaces whose pods to watch: the union of the
+// namespaces selected by the policies that clean up pods, or only allNamespaces if
+// one of them has no namespaceSelector.
+func (w *podWatches) watchedNamespaces(ctx context.Context) (map[string]bool, error) {
+	reader := w.mgr.GetClient()
+	policies := &cleanupv1.PodCleanupPolicyList{}
+	if err := reader.List(ctx, policies); err != nil {
+		return nil, err
+	}
+	want := make(map[string]bool)
+	for _, policy := range policies.Items {
+		if policy.Spec.Action == cleanupv1.ActionProtect {
+			continue
+		}
+		if policy.Spec.NamespaceSelector == nil {
+			return map[string]bool{allNamespaces: true}, nil
+		}
+		selector, err := metav1.LabelSelectorAsSelector(policy.Spec.NamespaceSelector)
+		if err != nil {
+			// Invalid policies do not run.
+			continue
+		}
+		namespaces := &corev1.NamespaceList{}
+		if err := reader.List(ctx, namespaces, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+			return nil, err
+		}
+		for _, ns := range namespaces.Items {
+			want[ns.Name] = true
+		}
+	}
+	return want, nil
+}
+
+// startCache starts the pod cache of namespace, or of all namespaces. It is used
+// once its pods are synced.
+func (w *podWatches) startCache(ctx context.Context, namespace string) (*podCache, error) {
+	opts := cache.Options{Scheme: w.mgr.GetScheme(), Mapper: w.mgr.GetRESTMapper()}
+	if namespace != allNamespaces {
+		opts.DefaultNamespaces = map[string]cache.Config{namespace: {}}
+	}
+	c, err := cache.New(w.mgr.GetConfig(), opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.IndexField(ctx, &corev1.Pod{}, podPhaseField, indexPodPhase); err != nil {
+		return nil, err
+	}
+
+	cacheCtx, cancel := context.WithCancel(ctx)
+	pc := &podCache{cache: c, cancel: cancel, synced: make(chan struct{})}
+	go func() {
+		if err := c.Start(cacheCtx); err != nil {
+			log.FromContext(ctx).Error(err, "Pod cache stopped", "namespace", namespace)
+		}
+	}()
+	go func() {
+		// GetInformer blocks until the informer is synced or cacheCtx is done.
+		informer, err := c.GetInformer(cacheCtx, &corev1.Pod{})
+		if err != nil {
+			return
+		}
+		if w.handler != nil {
+			if _, err := informer.AddEventHandler(w.handler); err != nil {
+				log.FromContext(ctx).Error(err, "Failed to handle pod events", "namespace", namespace)
+				return
+			}
+		}
+		close(pc.synced)
+	}()
+	return pc, nil
+}
+
+// readerFor returns a synced cache holding the pods of namespace, or nil if there
+// is none.
+func (w *podWatches) readerFor(namespace string) client.Reader {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, key := range []string{namespace, allNamespaces} {
+		if c, ok := w.caches[key]; ok {
+			select {
+			case <-c.synced:
+				return c.cache
+			default:
+			}
+		}
+	}
+	return nil
+}
+
+// stopAll stops every pod cache.
+func (w *podWatches) stopAll() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for namespace, c := range w.caches {
+		c.cancel()
+		delete(w.caches, namespace)
+	}
+}
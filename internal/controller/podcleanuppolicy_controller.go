@@ -3,32 +3,338 @@ package controller
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
+	"golang.org/x/time/rate"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	"github.com/robfig/cron/v3"
 
 	cleanupv1 "github.com/aravindavvaru/pod-cleanup-operator/api/v1"
+	"github.com/aravindavvaru/pod-cleanup-operator/internal/audit"
+	"github.com/aravindavvaru/pod-cleanup-operator/internal/cost"
+	"github.com/aravindavvaru/pod-cleanup-operator/internal/features"
+	"github.com/aravindavvaru/pod-cleanup-operator/internal/match"
+	"github.com/aravindavvaru/pod-cleanup-operator/internal/matcher"
+	"github.com/aravindavvaru/pod-cleanup-operator/internal/notify"
+	"github.com/aravindavvaru/pod-cleanup-operator/internal/opa"
 )
 
 // PodCleanupPolicyReconciler reconciles a PodCleanupPolicy object
 type PodCleanupPolicyReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
+	// RestConfig is used to build clients impersonating a policy's ServiceAccount.
+	RestConfig *rest.Config
+	// Recorder emits Events on policies.
+	Recorder record.EventRecorder
+	// APIReader reads objects the operator does not cache, such as run report ConfigMaps.
+	APIReader client.Reader
+	// OperatorNamespace is the namespace run reports are written to.
+	OperatorNamespace string
+	// Clock supplies the current time for schedules, pod ages and status timestamps.
+	// SetupWithManager defaults it to the system clock.
+	Clock clock.PassiveClock
+	// ForceDryRun forces every policy into dry-run mode, like OperatorConfig spec.dryRun.
+	ForceDryRun bool
+	// Backpressure slows down deletions while the API server throttles the operator.
+	// If nil, deletions are not slowed down.
+	Backpressure *Backpressure
+	// CachedPods is true when Client reads pods from the informer cache. Pods read
+	// from the API server instead are listed in pages.
+	CachedPods bool
+	// ScopePodWatches restricts the pods cached for runs to the namespaces the
+	// policies select. Client must not cache pods.
+	ScopePodWatches bool
+	// PodListChunkSize is the number of pods per list call to the API server.
+	// Defaults to 500.
+	PodListChunkSize int64
+	// ProgressInterval is the minimum time between writes of a run's progress to
+	// its CleanupRun and checkpoint. Defaults to 5s.
+	ProgressInterval time.Duration
+	// NamespaceReportInterval is how often the pods removed in a namespace are added
+	// to its CleanupReport. Zero disables CleanupReports.
+	NamespaceReportInterval time.Duration
+
+	// deleteLimiter paces pod deletions across all policies according to the
+	// OperatorConfig rate limit.
+	deleteLimiter *rate.Limiter
+
+	// schedules caches the parsed cron schedules of policies, keyed by policy name.
+	schedulesMu sync.Mutex
+	schedules   map[string]cachedSchedule
+	// podWatches caches the pods of the selected namespaces if ScopePodWatches is set.
+	podWatches *podWatches
+	// candidates tracks the candidates of each policy from pod watch events if the
+	// EventDrivenMode feature gate is enabled and pods are cached.
+	candidates *candidateIndex
+	// namespaceReports collects removed pods for the namespaces' CleanupReports if
+	// NamespaceReportInterval is set.
+	namespaceReports *namespaceReports
+
+	// apiBudget bounds the pod API calls in flight across all runs according to the
+	// OperatorConfig.
+	apiBudget apiBudget
+
+	// policyLimiters pace pod deletions of individual policies, keyed by policy name.
+	policyLimitersMu sync.Mutex
+	policyLimiters   map[string]*rate.Limiter
+
+	// tenantDeletions counts deletions per tenant for the OperatorConfig tenant quota.
+	tenantDeletions *tenantQuotas
+	// budgets counts the deletions against each ClusterCleanupBudget, keyed by name.
+	// Their usage is stored in budgetStore, if set; storedBudgets holds the usage read
+	// from it that is not restored yet.
+	budgetsMu     sync.Mutex
+	budgets       map[string]*budgetUsage
+	budgetStore   *usageStore
+	budgetsLoaded bool
+	budgetsDirty  bool
+	storedBudgets map[string]storedBudgetUsage
+
+	// digester aggregates run summaries for notification endpoints in digest mode.
+	digester *notify.Digester
+
+	// candidateSets holds the candidates of each policy's last dry run, keyed by
+	// policy name, to report what changed in the next one.
+	candidateSetsMu sync.Mutex
+	candidateSets   map[string]map[string]bool
+
+	// activeRuns holds the runs in progress, keyed by run ID, so changes to their
+	// policy can cancel them.
+	activeRunsMu sync.Mutex
+	activeRuns   map[types.UID]activeRun
+
+	// maintenanceNodes and scaleDownNodes hold the nodes pending a maintenance or
+	// scale-down run, keyed by policy name and then node name.
+	maintenanceMu    sync.Mutex
+	maintenanceNodes map[string]map[string]bool
+	scaleDownNodes   map[string]map[string]bool
+
+	impersonationMu     sync.Mutex
+	impersonatedClients map[string]client.Client
+
+	// workloadClusters caches the clients of ClusterTargets, keyed by name.
+	workloadClustersMu sync.Mutex
+	workloadClusters   map[string]*workloadCluster
+
+	// secrets caches the data of Secrets referenced by secretRefs, keyed by name.
+	secretsMu sync.Mutex
+	secrets   map[types.NamespacedName]map[string][]byte
+
+	// syslog and splunk are the OperatorConfig's audit outputs; syslogKey and
+	// splunkKey identify the configuration they were built from.
+	auditMu   sync.Mutex
+	syslog    *audit.Syslog
+	syslogKey string
+	splunk    *audit.Splunk
+	splunkKey string
+	file      *audit.File
+	fileKey   string
+}
+
+// cleanupRun carries the state of a single cleanup run of a policy.
+type cleanupRun struct {
+	// id uniquely identifies the run, so its results are accounted only once.
+	id     types.UID
+	policy *cleanupv1.PodCleanupPolicy
+	// spec is the policy spec with its ClusterCleanupDefaults merged in.
+	spec   *cleanupv1.PodCleanupPolicySpec
+	config *cleanupv1.OperatorConfigSpec
+	// schedule is the parsed cron schedule, or nil for unscheduled policies.
+	schedule cron.Schedule
+	// limiter paces this policy's deletions according to its own rate limit.
+	limiter *rate.Limiter
+	// clusterName is the ClusterTarget being cleaned up, or "" for the operator's own
+	// cluster. cluster reads and writes the objects of that cluster other than pods,
+	// and clusterReader reads those the operator does not cache.
+	clusterName   string
+	cluster       client.Client
+	clusterReader client.Reader
+	// podClient lists and deletes pods, and deletes the expired namespaces and
+	// companions of orphaned pods, impersonating the policy's ServiceAccount if set.
+	podClient client.Client
+	// podCache returns the informer cache holding the pods of a namespace, or nil
+	// if podClient reads them from the API server. It is nil for runs impersonating
+	// a ServiceAccount.
+	podCache func(namespace string) client.Reader
+	// dryRun is true when either the policy or the OperatorConfig requests it.
+	dryRun bool
+	// preview runs evaluate the policy without any side effects and collect candidates.
+	preview bool
+	// explaining runs record why each evaluated pod was selected or skipped.
+	explaining bool
+	// acknowledged runs may delete more pods than the policy's alertThreshold.
+	acknowledged bool
+	// reporting runs write a report ConfigMap when they finish.
+	reporting bool
+	// started is when the run started.
+	started time.Time
+	// record is the CleanupRun recording this run, or nil if it could not be created.
+	record *cleanupv1.CleanupRun
+	// higherPriority lists the other Delete policies that take precedence over this one.
+	higherPriority []cleanupv1.PodCleanupPolicy
+	// protectors lists the Protect policies whose matches this policy must not touch.
+	protectors []cleanupv1.PodCleanupPolicy
+	// retentions lists the PodRetentionPolicies whose pods must be kept until they
+	// are old enough.
+	retentions []podRetention
+	// matcher evaluates the policy's spec.match, or is nil if it has none.
+	matcher *match.Matcher
+	// archive collects the manifests of removed pods, or is nil if the policy does
+	// not archive them.
+	archive *podArchive
+	// checkpoint is the progress of the run last written to status.currentRun, or
+	// nil if the run is not checkpointed.
+	checkpoint *cleanupv1.RunCheckpoint
+	// resume is the checkpoint the run resumes from until it reaches its namespace.
+	resume *cleanupv1.RunCheckpoint
+	// progressWritten and checkpointWritten are when the run last wrote its
+	// progress and its checkpoint.
+	progressWritten   time.Time
+	checkpointWritten time.Time
+
+	// skippedByPriority counts candidates left to a higher-priority policy.
+	skippedByPriority int
+	// protected counts candidates shielded by a Protect policy.
+	protected int
+	// retained counts candidates kept by a PodRetentionPolicy.
+	retained int
+	// namespacesCleaned counts the namespaces pods were deleted (or would-be deleted) in.
+	namespacesCleaned int
+	// namespacesDeleted counts the expired namespaces deleted (or would-be deleted).
+	namespacesDeleted int
+	// companionsDeleted counts the companions of orphaned pods deleted (or would-be
+	// deleted).
+	companionsDeleted int
+	// deferredByQuota counts candidates not deleted because their tenant is over quota.
+	deferredByQuota int
+	// deferredByBudget counts candidates not deleted because a ClusterCleanupBudget
+	// is exhausted.
+	deferredByBudget int
+	// decisionDenied counts candidates the OperatorConfig's decision point did not
+	// allow to be removed.
+	decisionDenied int
+	// disruptionProtected counts candidates not removed because of a disruption
+	// annotation.
+	disruptionProtected int
+	// opa is the client of the decision point, set up on first use; opaErr is why it
+	// could not be.
+	opa    *opa.Client
+	opaErr error
+	// externalMatcher is the policy's external matcher, set up on first use;
+	// externalMatcherErr is why it could not be.
+	externalMatcher    matcher.Matcher
+	externalMatcherErr error
+	// warned counts candidates not deleted because their spec.warnBefore notice has
+	// not run out; warnedUntil is the earliest time one of them may be deleted.
+	warned      int
+	warnedUntil time.Time
+	// labeled counts the pods labeled (or, in dry runs, that would be) by Label rules.
+	labeled int
+	// sidecarsQuit counts the stuck pods whose sidecars were told to quit (or, in dry
+	// runs, would be) by the stuckOnSidecar QuitSidecar action.
+	sidecarsQuit int
+	// notified counts the pods matched by Notify rules; notifiedPods lists them,
+	// capped at maxNotifiedPods.
+	notified     int
+	notifiedPods []string
+	// namespacesTargeted counts the target namespaces, and podsListed the pods in
+	// them that match the pod selector, for the NoMatches condition.
+	namespacesTargeted int
+	podsListed         int
+	// selected counts the pods that matched all criteria of the policy.
+	selected int
+	// podsLogged counts the pods evaluated, for sampling their log lines.
+	podsLogged int
+	// transientFailures counts namespaces and pods skipped because of transient errors.
+	transientFailures int
+	// removed tallies the pods removed (or would-be removed) per namespace for the
+	// RunCompleted event.
+	removed removedPods
+	// reportName is the name of the ConfigMap holding the run report, once written.
+	reportName string
+	// cost prices the removed pods; nil if the OperatorConfig has no costModel.
+	cost *cost.Model
+	// candidates collects the pods a dry-run or preview run would delete.
+	candidates []Candidate
+	// decisions collects the explained decisions of an explaining run.
+	decisions []cleanupv1.PodDecision
+	// podRecords collects the per-pod outcomes of a reporting run, capped at
+	// maxReportedPods; podRecordsOmitted counts the rest.
+	podRecords        []podRecord
+	podRecordsOmitted int
+
+	// maintenanceNodes restricts a maintenance run to the finished pods on these nodes.
+	maintenanceNodes map[string]bool
+	// scaleDownNodes restricts a scale-down run to the pods on these nodes.
+	scaleDownNodes map[string]bool
+	// nodes memoizes the nodes looked up for node criteria, keyed by name; a nil
+	// entry is a node that no longer exists.
+	nodes map[string]*corev1.Node
+	// owners memoizes whether the owners looked up for the orphaned criterion exist,
+	// keyed by UID.
+	owners map[types.UID]bool
+	// companionObjects memoizes the owned ConfigMaps and Secrets of each namespace
+	// for the companions of orphaned pods.
+	companionObjects map[string][]metav1.PartialObjectMetadata
+	// endpointSlices memoizes the EndpointSlices of each namespace of a workload
+	// cluster, whose EndpointSlices are not cached.
+	endpointSlices map[string][]discoveryv1.EndpointSlice
+	// clusterResults collects the outcome of the run in each workload cluster.
+	clusterResults []cleanupv1.ClusterRunStatus
+
+	// forbiddenNamespaces lists target namespaces whose pods could not be listed
+	// because the operator (or impersonated ServiceAccount) lacks permission.
+	forbiddenNamespaces []string
 }
 
+// Candidate is a pod that a run of a policy would delete.
+type Candidate struct {
+	Namespace string
+	Name      string
+	Phase     corev1.PodPhase
+	Age       time.Duration
+	// Rule is the anyOf group of spec.match the pod matched, if any.
+	Rule string
+}
+
+// maxReportedNamespaces caps the namespace names included in condition messages.
+const maxReportedNamespaces = 10
+
 //+kubebuilder:rbac:groups=cleanup.example.com,resources=podcleanuppolicies,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=cleanup.example.com,resources=podcleanuppolicies/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=cleanup.example.com,resources=podcleanuppolicies/finalizers,verbs=update
-//+kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;delete
+//+kubebuilder:rbac:groups=cleanup.example.com,resources=cleanupruns,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=cleanup.example.com,resources=cleanupruns/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=cleanup.example.com,resources=operatorconfigs,verbs=get;list;watch
+//+kubebuilder:rbac:groups=cleanup.example.com,resources=clustercleanupdefaults,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;patch;delete
 //+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=impersonate
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+//+kubebuilder:rbac:groups=batch,resources=jobs,verbs=get
 
 // Reconcile implements the main reconciliation loop for PodCleanupPolicy.
 // It evaluates the cleanup schedule, selects matching pods, and deletes them
@@ -39,106 +345,777 @@ func (r *PodCleanupPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Req
 	policy := &cleanupv1.PodCleanupPolicy{}
 	if err := r.Get(ctx, req.NamespacedName, policy); err != nil {
 		if errors.IsNotFound(err) {
+			r.forgetPolicyLimiter(req.Name)
+			r.forgetCandidates(req.Name)
+			r.forgetSchedule(req.Name)
+			forgetPolicyMetrics(req.Name)
 			return ctrl.Result{}, nil
 		}
 		return ctrl.Result{}, err
 	}
+	logger = policyLogger(logger, policy)
+	ctx = log.IntoContext(ctx, logger)
+
+	if !policy.DeletionTimestamp.IsZero() {
+		return r.finalizePolicy(ctx, policy)
+	}
+	if err := r.ensureFinalizer(ctx, policy); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if errs := policy.Validate(); len(errs) > 0 {
+		msg := errs.ToAggregate().Error()
+		logger.Info("Invalid policy spec", "errors", msg)
+		_ = updateStatus(ctx, r.Client, policy, func() {
+			r.setCondition(policy, cleanupv1.ConditionReady, metav1.ConditionFalse, cleanupv1.ReasonInvalidSpec, msg)
+		})
+		// Do not requeue; the spec needs to be fixed first.
+		return ctrl.Result{}, nil
+	}
+	if err := disabledFeatureError(policy); err != nil {
+		logger.Info("Policy needs a disabled feature", "error", err.Error())
+		_ = updateStatus(ctx, r.Client, policy, func() {
+			r.setCondition(policy, cleanupv1.ConditionReady, metav1.ConditionFalse, cleanupv1.ReasonFeatureDisabled, err.Error())
+		})
+		// Do not requeue; enabling the feature gate restarts the operator.
+		return ctrl.Result{}, nil
+	}
+
+	if policy.Spec.Action == cleanupv1.ActionProtect {
+		return ctrl.Result{}, r.reconcileProtectPolicy(ctx, policy)
+	}
+
+	if err := r.syncDryRunForced(ctx, policy); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if policy.Spec.Suspend {
+		if err := updateStatus(ctx, r.Client, policy, func() {
+			r.setCondition(policy, cleanupv1.ConditionReady, metav1.ConditionFalse, cleanupv1.ReasonSuspended, "Policy is suspended")
+			r.setCondition(policy, cleanupv1.ConditionSuspended, metav1.ConditionTrue, cleanupv1.ReasonSuspendedBySpec,
+				"spec.suspend holds the policy's runs")
+		}); err != nil {
+			return ctrl.Result{}, err
+		}
+		// Do not requeue; resuming the policy triggers a reconcile.
+		return ctrl.Result{}, nil
+	}
+	if err := r.ensureCondition(ctx, policy, cleanupv1.ConditionSuspended, metav1.ConditionFalse,
+		cleanupv1.ReasonNotSuspended, "Policy is not suspended"); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// A run-now annotation triggers an immediate run regardless of schedule.
+	trigger := cleanupv1.TriggerSchedule
+	if policy.Annotations[cleanupv1.AnnotationRunNow] == "true" {
+		trigger = cleanupv1.TriggerManual
+	}
+	// Nodes newly labeled for maintenance trigger an immediate run limited to them,
+	// unless a full run is due anyway.
+	maintenance := trigger == cleanupv1.TriggerSchedule && r.hasMaintenanceNodes(policy)
+	// So do nodes the cluster-autoscaler picked for scale down.
+	scaleDown := trigger == cleanupv1.TriggerSchedule && !maintenance && r.hasScaleDownNodes(policy)
+
+	// A run interrupted by a restart of the operator resumes regardless of schedule.
+	config, err := r.getOperatorConfig(ctx)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	dryRun := policy.Spec.DryRun || policy.Spec.Preview || r.dryRunForcedBy(config) != ""
+	resume, err := r.interruptedRun(ctx, policy, dryRun)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if resume != nil {
+		trigger = resume.Trigger
+		maintenance = false
+		scaleDown = false
+	}
 
 	// If a cron schedule is configured, check whether it is time to run.
-	if policy.Spec.Schedule != "" {
-		parser := cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
-		schedule, err := parser.Parse(policy.Spec.Schedule)
-		if err != nil {
-			logger.Error(err, "Invalid cron schedule", "schedule", policy.Spec.Schedule)
-			r.setCondition(policy, "Ready", metav1.ConditionFalse, "InvalidSchedule",
-				fmt.Sprintf("Cannot parse cron schedule %q: %v", policy.Spec.Schedule, err))
-			_ = r.Status().Update(ctx, policy)
-			// Do not requeue; the spec needs to be fixed first.
+	schedule, scheduleKey, err := r.scheduleOf(ctx, policy)
+	if errors.IsNotFound(err) {
+		msg := fmt.Sprintf("CleanupSchedule %q not found", policy.Spec.ScheduleRef)
+		_ = updateStatus(ctx, r.Client, policy, func() {
+			r.setCondition(policy, cleanupv1.ConditionReady, metav1.ConditionFalse, cleanupv1.ReasonScheduleNotFound, msg)
+			r.setCondition(policy, cleanupv1.ConditionScheduleValid, metav1.ConditionFalse, cleanupv1.ReasonScheduleNotFound, msg)
+		})
+		// Do not requeue; creating the schedule triggers a reconcile.
+		return ctrl.Result{}, nil
+	}
+	if err != nil {
+		logger.Error(err, "Invalid cron schedule", "schedule", policy.Spec.Schedule, "scheduleRef", policy.Spec.ScheduleRef)
+		msg := fmt.Sprintf("Cannot parse cron schedule %q: %v", policy.Spec.Schedule, err)
+		if policy.Spec.ScheduleRef != "" {
+			msg = err.Error()
+		}
+		_ = updateStatus(ctx, r.Client, policy, func() {
+			r.setCondition(policy, cleanupv1.ConditionReady, metav1.ConditionFalse, cleanupv1.ReasonInvalidSchedule, msg)
+			r.setCondition(policy, cleanupv1.ConditionScheduleValid, metav1.ConditionFalse, cleanupv1.ReasonInvalidSchedule, msg)
+		})
+		// Do not requeue; the spec needs to be fixed first.
+		return ctrl.Result{}, nil
+	}
+	if err := r.syncScheduleValid(ctx, policy, schedule, scheduleKey); err != nil {
+		return ctrl.Result{}, err
+	}
+	if schedule != nil && resume == nil {
+		// The next run is computed from the last schedule time rather than the last
+		// completion, so slow or interrupted runs do not shift the schedule. Policies
+		// written before lastScheduleTime existed fall back to lastRunTime.
+		var lastScheduled time.Time
+		switch {
+		case policy.Status.LastScheduleTime != nil:
+			lastScheduled = policy.Status.LastScheduleTime.Time
+		case policy.Status.LastRunTime != nil:
+			lastScheduled = policy.Status.LastRunTime.Time
+		}
+
+		now := r.Clock.Now()
+		// A changed schedule applies from now on: its slots are counted from the
+		// change, and slots it would have had before the change are not caught up.
+		scheduleChanged := policy.Status.ObservedSchedule != "" && policy.Status.ObservedSchedule != scheduleKey
+		if scheduleChanged {
+			lastScheduled = now
+		}
+		nextRun := schedule.Next(lastScheduled)
+		if nextRun.IsZero() {
+			msg := fmt.Sprintf("Schedule %q has no runs outside its blackout windows", scheduleKey)
+			_ = updateStatus(ctx, r.Client, policy, func() {
+				r.setCondition(policy, cleanupv1.ConditionReady, metav1.ConditionFalse, cleanupv1.ReasonInvalidSchedule, msg)
+				r.setCondition(policy, cleanupv1.ConditionScheduleValid, metav1.ConditionFalse, cleanupv1.ReasonInvalidSchedule, msg)
+			})
+			// Do not requeue; the schedule needs to be fixed first.
 			return ctrl.Result{}, nil
 		}
+		if policy.Status.ObservedSchedule != scheduleKey ||
+			policy.Status.NextRunTime == nil || !policy.Status.NextRunTime.Time.Equal(nextRun) {
+			if err := updateStatus(ctx, r.Client, policy, func() {
+				if scheduleChanged {
+					changed := metav1.NewTime(now)
+					policy.Status.LastScheduleTime = &changed
+				}
+				next := metav1.NewTime(nextRun)
+				policy.Status.ObservedSchedule = scheduleKey
+				policy.Status.NextRunTime = &next
+			}); err != nil {
+				return ctrl.Result{}, err
+			}
+			if scheduleChanged {
+				r.Recorder.Eventf(policy, corev1.EventTypeNormal, "ScheduleChanged",
+					"Schedule changed to %q; next run at %s", scheduleKey, nextRun.UTC().Format(time.RFC3339))
+			}
+		}
+		retry := policy.Status.NextRetryTime
+		if nextRun.After(now) {
+			switch {
+			case trigger == cleanupv1.TriggerManual:
+			case retry != nil && !retry.After(now):
+				trigger = cleanupv1.TriggerRetry
+			case maintenance:
+				trigger = cleanupv1.TriggerMaintenance
+			case scaleDown:
+				trigger = cleanupv1.TriggerScaleDown
+			case retry != nil && retry.Time.Before(nextRun):
+				requeueAfter := retry.Sub(now)
+				logger.Info("Cleanup retry scheduled", "retryTime", retry.Time, "requeueAfter", requeueAfter)
+				return ctrl.Result{RequeueAfter: requeueAfter}, nil
+			default:
+				requeueAfter := nextRun.Sub(now)
+				logger.Info("Next cleanup scheduled", "nextRun", nextRun, "requeueAfter", requeueAfter)
+				return ctrl.Result{RequeueAfter: requeueAfter}, nil
+			}
+		} else if end, blocked := blackedOutUntil(schedule, now); blocked && trigger == cleanupv1.TriggerSchedule {
+			// A missed run is caught up once the blackout window closes.
+			logger.Info("Cleanup held back by a blackout window", "until", end)
+			return ctrl.Result{RequeueAfter: end.Sub(now)}, nil
+		} else {
+			// Claim the schedule slot before running, so a failed status update or
+			// restart after the run cannot run the same slot again.
+			latest, missed := mostRecentScheduleTime(schedule, lastScheduled, policy.CreationTimestamp.Time, now)
+			slot, next := metav1.NewTime(latest), metav1.NewTime(schedule.Next(now))
+			if err := updateStatus(ctx, r.Client, policy, func() {
+				policy.Status.LastScheduleTime = &slot
+				policy.Status.NextRunTime = &next
+			}); err != nil {
+				return ctrl.Result{}, err
+			}
 
-		var lastRun time.Time
-		if policy.Status.LastRunTime != nil {
-			lastRun = policy.Status.LastRunTime.Time
+			// Slots missed while the controller was down are caught up with a single
+			// run for the latest slot, unless it is past the starting deadline.
+			if deadline := policy.Spec.StartingDeadlineSeconds; deadline != nil && trigger != cleanupv1.TriggerManual &&
+				now.Sub(latest) > time.Duration(*deadline)*time.Second {
+				r.Recorder.Eventf(policy, corev1.EventTypeWarning, "MissedSchedule",
+					"Skipped run scheduled at %s; it is past the starting deadline of %ds",
+					latest.UTC().Format(time.RFC3339), *deadline)
+				nextRun := schedule.Next(now)
+				logger.Info("Skipping missed cleanup", "scheduleTime", latest, "nextRun", nextRun)
+				return ctrl.Result{RequeueAfter: nextRun.Sub(now)}, nil
+			}
+			if missed > 1 {
+				r.Recorder.Eventf(policy, corev1.EventTypeNormal, "MissedSchedule",
+					"Missed %d scheduled run(s); catching up with one run for %s",
+					missed, latest.UTC().Format(time.RFC3339))
+			}
 		}
+	}
 
-		nextRun := schedule.Next(lastRun)
-		now := time.Now()
-		if nextRun.After(now) {
-			requeueAfter := nextRun.Sub(now)
-			logger.Info("Next cleanup scheduled", "nextRun", nextRun, "requeueAfter", requeueAfter)
-			return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	if schedule == nil && maintenance {
+		trigger = cleanupv1.TriggerMaintenance
+	} else if schedule == nil && scaleDown {
+		trigger = cleanupv1.TriggerScaleDown
+	}
+
+	run, err := r.newRun(ctx, policy, schedule)
+	if errors.IsNotFound(err) {
+		msg := fmt.Sprintf("ClusterCleanupDefaults %q not found", policy.Spec.DefaultsFrom)
+		_ = updateStatus(ctx, r.Client, policy, func() {
+			r.setCondition(policy, cleanupv1.ConditionReady, metav1.ConditionFalse, cleanupv1.ReasonDefaultsNotFound, msg)
+		})
+		// Do not requeue; creating the defaults triggers a reconcile.
+		return ctrl.Result{}, nil
+	}
+	if isFeatureDisabled(err) {
+		logger.Info("Run needs a disabled feature", "error", err.Error())
+		_ = updateStatus(ctx, r.Client, policy, func() {
+			r.setCondition(policy, cleanupv1.ConditionReady, metav1.ConditionFalse, cleanupv1.ReasonFeatureDisabled, err.Error())
+		})
+		// Do not requeue; enabling the feature gate restarts the operator.
+		return ctrl.Result{}, nil
+	}
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	run.limiter = r.policyLimiter(policy.Name, run.spec.RateLimit)
+	run.explaining = policy.Spec.Explain && run.dryRun
+	if trigger == cleanupv1.TriggerMaintenance {
+		run.maintenanceNodes = r.takeMaintenanceNodes(policy.Name)
+		logger.Info("Maintenance cleanup triggered", "nodes", len(run.maintenanceNodes))
+	}
+	if trigger == cleanupv1.TriggerScaleDown {
+		run.scaleDownNodes = r.takeScaleDownNodes(policy.Name)
+		logger.Info("Scale-down cleanup triggered", "nodes", len(run.scaleDownNodes))
+	}
+
+	if policy.Annotations[cleanupv1.AnnotationAcknowledgeAlert] == "true" && !run.dryRun && resume == nil {
+		// The acknowledgment covers this run only.
+		if err := r.clearAnnotation(ctx, policy, cleanupv1.AnnotationAcknowledgeAlert); err != nil {
+			return ctrl.Result{}, err
+		}
+		run.acknowledged = true
+	}
+	if trigger == cleanupv1.TriggerManual && resume == nil {
+		if err := r.clearAnnotation(ctx, policy, cleanupv1.AnnotationRunNow); err != nil {
+			return ctrl.Result{}, err
 		}
+		logger.Info("Manual cleanup run triggered")
+		r.runEventf(run, corev1.EventTypeNormal, "ManualRun",
+			"Run triggered by the %s annotation", cleanupv1.AnnotationRunNow)
 	}
 
 	// Execute the cleanup.
-	deleted, err := r.runCleanup(ctx, policy)
-	if err != nil {
-		r.setCondition(policy, "Ready", metav1.ConditionFalse, "CleanupFailed", err.Error())
+	if resume != nil {
+		r.resumeRun(ctx, run, resume)
 	} else {
-		msg := fmt.Sprintf("Cleanup completed; %d pod(s) deleted", deleted)
-		if policy.Spec.DryRun {
-			msg = fmt.Sprintf("DryRun cleanup completed; %d pod(s) would be deleted", deleted)
+		r.startRunRecord(ctx, run, trigger)
+		r.startCheckpoint(ctx, run, trigger)
+	}
+	r.startProgressing(ctx, run, trigger)
+	runCtx, done := r.trackRun(ctx, run)
+	deleted, err := r.runWithHooks(runCtx, run)
+	done()
+	r.closeArchive(ctx, run)
+	r.finishRunRecord(ctx, run, deleted, err)
+	r.pruneRunHistory(ctx, policy)
+	r.writeRunReport(ctx, run, trigger, deleted, err)
+
+	canceled := isRunCanceled(err)
+	if canceled {
+		r.runEventf(run, corev1.EventTypeWarning, "RunCanceled",
+			"%v after %d pod(s) deleted", err, deleted)
+	}
+	alerted := isAlertThresholdExceeded(err)
+	if alerted {
+		r.runEventf(run, corev1.EventTypeWarning, "AlertThresholdExceeded",
+			"%v with the %s=true annotation", err, cleanupv1.AnnotationAcknowledgeAlert)
+	}
+	if err == nil && deleted > 0 {
+		r.runEventf(run, corev1.EventTypeNormal, "RunCompleted", "%s", runCompletedMessage(run, deleted))
+	}
+
+	policyLastRunTimestamp.WithLabelValues(policy.Name).Set(float64(r.Clock.Now().Unix()))
+	// Maintenance and scale-down runs only evaluate some pods, so they do not refresh
+	// the backlog.
+	if err == nil && !nodeSweep(trigger) {
+		policyCandidates.WithLabelValues(policy.Name).Set(float64(run.selected))
+	}
+
+	now := metav1.NewTime(r.Clock.Now())
+	var diff *cleanupv1.CandidateDiff
+	if !run.dryRun {
+		r.forgetCandidates(policy.Name)
+	} else if err == nil {
+		diff = r.diffCandidates(policy.Name, run.candidates, now)
+		if diff != nil && (diff.Added > 0 || diff.Resolved > 0) {
+			r.runEventf(run, corev1.EventTypeNormal, "CandidatesChanged",
+				"%d new candidate(s), %d resolved since the previous dry run", diff.Added, diff.Resolved)
 		}
-		r.setCondition(policy, "Ready", metav1.ConditionTrue, "CleanupSucceeded", msg)
 	}
 
-	now := metav1.Now()
-	policy.Status.LastRunTime = &now
-	policy.Status.LastRunPodsDeleted = int32(deleted)
-	if !policy.Spec.DryRun {
-		policy.Status.PodsDeleted += int64(deleted)
+	// Retry runs that hit transient errors with backoff, unless the next scheduled
+	// run comes first.
+	var retryAfter time.Duration
+	retryAttempt := policy.Status.RetryAttempts + 1
+	if !canceled && (run.transientFailures > 0 || (err != nil && isTransient(err))) {
+		retryAfter = retryDelay(retryAttempt, schedule, now.Time)
+		if retryAfter > 0 {
+			r.runEventf(run, corev1.EventTypeWarning, "RetryScheduled",
+				"Run hit transient errors; retry %d of %d in %s", retryAttempt, maxRunRetries, retryAfter)
+		}
 	}
 
-	if statusErr := r.Status().Update(ctx, policy); statusErr != nil {
+	noMatches := false
+	statusErr := updateStatus(ctx, r.Client, policy, func() {
+		if canceled {
+			r.setCondition(policy, cleanupv1.ConditionReady, metav1.ConditionFalse, cleanupv1.ReasonRunCanceled, err.Error())
+		} else if alerted {
+			r.setCondition(policy, cleanupv1.ConditionReady, metav1.ConditionFalse, cleanupv1.ReasonAlertThresholdExceeded, err.Error())
+		} else if err != nil {
+			r.setCondition(policy, cleanupv1.ConditionReady, metav1.ConditionFalse, cleanupv1.ReasonCleanupFailed, err.Error())
+		} else {
+			msg := fmt.Sprintf("Cleanup completed; %d pod(s) deleted", deleted)
+			switch {
+			case run.preview:
+				msg = fmt.Sprintf("Preview completed; %d pod(s) would be deleted", deleted)
+			case run.dryRun:
+				msg = fmt.Sprintf("DryRun cleanup completed; %d pod(s) would be deleted", deleted)
+			}
+			r.setCondition(policy, cleanupv1.ConditionReady, metav1.ConditionTrue, cleanupv1.ReasonCleanupSucceeded, msg)
+		}
+		r.setCondition(policy, cleanupv1.ConditionProgressing, metav1.ConditionFalse, cleanupv1.ReasonRunFinished,
+			fmt.Sprintf("No run in progress; the last run finished at %s", now.UTC().Format(time.RFC3339)))
+		r.setBudgetExceeded(policy, run, err)
+		failedClusters := run.failedClusters()
+		if len(run.forbiddenNamespaces) > 0 {
+			r.setCondition(policy, cleanupv1.ConditionDegraded, metav1.ConditionTrue, cleanupv1.ReasonForbidden,
+				fmt.Sprintf("Missing pod permissions in %d namespace(s): %s",
+					len(run.forbiddenNamespaces), joinCapped(run.forbiddenNamespaces, maxReportedNamespaces)))
+		} else if len(failedClusters) > 0 {
+			r.setCondition(policy, cleanupv1.ConditionDegraded, metav1.ConditionTrue, cleanupv1.ReasonClustersUnavailable,
+				fmt.Sprintf("Could not clean up %d workload cluster(s): %s",
+					len(failedClusters), joinCapped(failedClusters, maxReportedNamespaces)))
+		} else if err == nil {
+			r.setCondition(policy, cleanupv1.ConditionDegraded, metav1.ConditionFalse, cleanupv1.ReasonNamespacesAccessible,
+				"Pods in all target namespaces are accessible")
+		}
+		// Only complete runs over every target namespace tell whether the selectors
+		// match anything.
+		if err == nil && resume == nil && !nodeSweep(trigger) && run.transientFailures == 0 &&
+			len(run.forbiddenNamespaces) == 0 && len(failedClusters) == 0 && policy.Status.LastRunID != run.id {
+			noMatches = r.recordMatches(policy, run)
+		}
+
+		// A retried update of a run already accounted must not count its deletions twice.
+		if !run.dryRun && policy.Status.LastRunID != run.id {
+			policy.Status.PodsDeleted += int64(deleted)
+		}
+		policy.Status.LastRunID = run.id
+		policy.Status.CurrentRun = nil
+		policy.Status.LastRunTime = &now
+		policy.Status.LastRunTrigger = trigger
+		if trigger == cleanupv1.TriggerManual {
+			policy.Status.LastManualRunTime = &now
+		}
+		policy.Status.LastRunPodsDeleted = int32(deleted)
+		policy.Status.LastRunNamespacesCleaned = int32(run.namespacesCleaned)
+		policy.Status.LastRunDryRun = run.dryRun
+		policy.Status.LastRunPodsSkippedByPriority = int32(run.skippedByPriority)
+		policy.Status.LastRunPodsProtected = int32(run.protected)
+		policy.Status.LastRunPodsRetained = int32(run.retained)
+		policy.Status.LastRunPodsDeferredByQuota = int32(run.deferredByQuota)
+		policy.Status.LastRunPodsDeferredByBudget = int32(run.deferredByBudget)
+		policy.Status.LastRunPodsWarned = int32(run.warned)
+		policy.Status.LastRunPodsDeniedByDecision = int32(run.decisionDenied)
+		policy.Status.LastRunPodsDisruptionProtected = int32(run.disruptionProtected)
+		policy.Status.LastRunPodsLabeled = int32(run.labeled)
+		policy.Status.LastRunPodsNotified = int32(run.notified)
+		policy.Status.LastRunNamespacesDeleted = int32(run.namespacesDeleted)
+		policy.Status.LastRunCompanionsDeleted = int32(run.companionsDeleted)
+		policy.Status.Clusters = run.clusterResults
+		if retryAfter > 0 {
+			retryTime := metav1.NewTime(now.Add(retryAfter))
+			policy.Status.RetryAttempts = retryAttempt
+			policy.Status.NextRetryTime = &retryTime
+		} else {
+			policy.Status.RetryAttempts = 0
+			policy.Status.NextRetryTime = nil
+		}
+		if run.preview && err == nil {
+			policy.Status.LastPreview = previewStatus(now, run.candidates)
+		}
+		if diff != nil {
+			policy.Status.LastDryRunDiff = diff
+		}
+	})
+	if canceled && errors.IsNotFound(statusErr) {
+		// The policy was deleted; its partial results are in the run record and report.
+		statusErr = nil
+	}
+	if statusErr != nil {
 		logger.Error(statusErr, "Failed to update PodCleanupPolicy status")
 		return ctrl.Result{}, statusErr
 	}
 
+	if noMatches {
+		if cond := meta.FindStatusCondition(policy.Status.Conditions, cleanupv1.ConditionNoMatches); cond != nil {
+			r.runEventf(run, corev1.EventTypeWarning, "NoMatches", "%s", cond.Message)
+		}
+	}
+	r.sendNotifications(ctx, run, deleted, err)
+	r.fileChangeRecord(ctx, run, trigger, deleted, err)
+	r.auditRunFinished(ctx, run, deleted, err)
+
+	if canceled {
+		// The change that canceled the run triggers a new reconcile.
+		return ctrl.Result{}, nil
+	}
+
+	if retryAfter > 0 {
+		logger.Info("Retrying cleanup after transient errors", "attempt", retryAttempt, "requeueAfter", retryAfter)
+		return ctrl.Result{RequeueAfter: retryAfter}, nil
+	}
 	if err != nil {
 		return ctrl.Result{}, err
 	}
 
 	// Schedule the next run when a cron schedule is configured.
-	if policy.Spec.Schedule != "" {
-		parser := cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
-		schedule, _ := parser.Parse(policy.Spec.Schedule)
-		nextRun := schedule.Next(time.Now())
-		return ctrl.Result{RequeueAfter: time.Until(nextRun)}, nil
+	if schedule != nil {
+		now := r.Clock.Now()
+		return ctrl.Result{RequeueAfter: schedule.Next(now).Sub(now)}, nil
+	}
+	// Unscheduled policies come back for the pods they warned.
+	if !run.warnedUntil.IsZero() {
+		return ctrl.Result{RequeueAfter: run.warnedUntil.Sub(r.Clock.Now())}, nil
 	}
 
 	return ctrl.Result{}, nil
 }
 
-// runCleanup iterates over all target namespaces and deletes matching pods.
-func (r *PodCleanupPolicyReconciler) runCleanup(ctx context.Context, policy *cleanupv1.PodCleanupPolicy) (int, error) {
-	logger := log.FromContext(ctx)
+// mostRecentScheduleTime returns the latest time the schedule fired after since and
+// no later than now, and how many times it fired. When since is unset the search
+// starts at the policy's creation; if the schedule has not fired since then, now is
+// returned so a new policy runs immediately.
+func mostRecentScheduleTime(schedule cron.Schedule, since, created, now time.Time) (time.Time, int) {
+	if since.IsZero() {
+		since = created
+	}
+	latest, fired := now, 0
+	for t := schedule.Next(since); !t.IsZero() && !t.After(now); t = schedule.Next(t) {
+		latest = t
+		fired++
+	}
+	return latest, fired
+}
+
+// newRun assembles the state of a run of policy: its spec with defaults merged in,
+// the OperatorConfig, competing policies, and the client used for pods. Runs that
+// delete pods must also take the policy's limiter. The returned error is a NotFound
+// error when the policy's ClusterCleanupDefaults does not exist, and a
+// featureDisabledError when the run needs a disabled feature gate.
+func (r *PodCleanupPolicyReconciler) newRun(ctx context.Context, policy *cleanupv1.PodCleanupPolicy, schedule cron.Schedule) (*cleanupRun, error) {
+	spec, err := r.resolveSpec(ctx, policy)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := r.getOperatorConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := disabledRunFeatureError(spec, config); err != nil {
+		return nil, err
+	}
+	r.applyRateLimit(config)
+	r.applyAPIBudget(config)
+	run := &cleanupRun{
+		id:        uuid.NewUUID(),
+		policy:    policy,
+		spec:      spec,
+		config:    config,
+		schedule:  schedule,
+		dryRun:    policy.Spec.DryRun || policy.Spec.Preview || r.dryRunForcedBy(config) != "",
+		preview:   policy.Spec.Preview,
+		reporting: config.RunReports != nil,
+		started:   r.Clock.Now(),
+		cluster:   r.Client,
+	}
+	run.clusterReader = r.APIReader
+
+	if run.spec.Archive != nil {
+		run.archive = &podArchive{spec: run.spec.Archive}
+	}
+	run.matcher, err = match.Compile(policy.Spec.Match)
+	if err != nil {
+		return nil, fmt.Errorf("invalid match: %w", err)
+	}
+	// An invalid cost model only costs the run its estimates.
+	if run.cost, err = cost.New(config.CostModel); err != nil {
+		log.FromContext(ctx).Error(err, "Ignoring invalid costModel of the OperatorConfig")
+	}
+	run.higherPriority, run.protectors, err = r.competingPolicies(ctx, policy)
+	if err != nil {
+		return nil, err
+	}
+	run.retentions, err = r.retentionPolicies(ctx)
+	if err != nil {
+		return nil, err
+	}
+	run.podClient, err = r.podClientFor(policy)
+	if err != nil {
+		return nil, err
+	}
+	if policy.Spec.ServiceAccountName == "" {
+		run.podCache = r.podCacheFor
+	}
+	return run, nil
+}
+
+// Preview evaluates the policy like a dry run, but without annotating pods or
+// recording the run, and returns the pods a run would delete.
+func (r *PodCleanupPolicyReconciler) Preview(ctx context.Context, policy *cleanupv1.PodCleanupPolicy) ([]Candidate, error) {
+	run, err := r.previewRun(ctx, policy)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := r.runCleanup(ctx, run); err != nil {
+		return nil, err
+	}
+	return run.candidates, nil
+}
+
+// RunOnce runs the policy once outside the manager, as the one-shot pod-cleanup run
+// command does. Pods are removed unless the policy or dryRun asks for a dry run, but
+// the run is recorded nowhere: not in a CleanupRun, a run report nor the policy
+// status. It returns the number of pods removed, or that would have been.
+func (r *PodCleanupPolicyReconciler) RunOnce(ctx context.Context, policy *cleanupv1.PodCleanupPolicy, dryRun bool) (int, error) {
+	r.setupStandalone()
+	run, err := r.newRun(ctx, policy, nil)
+	if err != nil {
+		return 0, err
+	}
+	run.limiter = r.policyLimiter(policy.Name, run.spec.RateLimit)
+	run.dryRun = run.dryRun || dryRun
+	run.reporting = false
+	deleted, err := r.runWithHooks(ctx, run)
+	r.closeArchive(ctx, run)
+	r.auditRunFinished(ctx, run, deleted, err)
+
+	policyLastRunTimestamp.WithLabelValues(policy.Name).Set(float64(r.Clock.Now().Unix()))
+	if err == nil {
+		policyCandidates.WithLabelValues(policy.Name).Set(float64(run.selected))
+	}
+	return deleted, err
+}
+
+// setupStandalone defaults what SetupWithManager would set up, for runs outside the
+// manager. Their Events are dropped.
+func (r *PodCleanupPolicyReconciler) setupStandalone() {
+	if r.Clock == nil {
+		r.Clock = clock.RealClock{}
+	}
+	if r.Recorder == nil {
+		r.Recorder = &record.FakeRecorder{}
+	}
+	if r.deleteLimiter == nil {
+		r.deleteLimiter = rate.NewLimiter(rate.Inf, 0)
+	}
+	if r.tenantDeletions == nil {
+		r.tenantDeletions = newTenantQuotas(r.APIReader, r.Client, "", r.Clock)
+	}
+}
 
-	namespaces, err := r.getTargetNamespaces(ctx, policy)
+// previewRun assembles a run of policy that has no side effects. It may be used
+// outside the manager, e.g. by the kubectl plugin.
+func (r *PodCleanupPolicyReconciler) previewRun(ctx context.Context, policy *cleanupv1.PodCleanupPolicy) (*cleanupRun, error) {
+	r.setupStandalone()
+	run, err := r.newRun(ctx, policy, nil)
 	if err != nil {
-		return 0, fmt.Errorf("listing target namespaces: %w", err)
+		return nil, err
+	}
+	run.dryRun = true
+	run.preview = true
+	run.reporting = false
+	run.archive = nil
+	return run, nil
+}
+
+// previewStatus records the candidates of a preview run, capped at
+// cleanupv1.MaxPreviewCandidates.
+func previewStatus(now metav1.Time, candidates []Candidate) *cleanupv1.PolicyPreview {
+	return &cleanupv1.PolicyPreview{
+		Time:           now,
+		CandidateCount: int32(len(candidates)),
+		Candidates:     previewCandidates(candidates, cleanupv1.MaxPreviewCandidates),
+	}
+}
+
+// previewCandidates returns the first limit candidates for a status.
+func previewCandidates(candidates []Candidate, limit int) []cleanupv1.PreviewCandidate {
+	var recorded []cleanupv1.PreviewCandidate
+	for _, c := range candidates {
+		if len(recorded) == limit {
+			break
+		}
+		recorded = append(recorded, cleanupv1.PreviewCandidate{
+			Namespace: c.Namespace,
+			Name:      c.Name,
+			Phase:     c.Phase,
+			Age:       metav1.Duration{Duration: c.Age},
+			Rule:      c.Rule,
+		})
+	}
+	return recorded
+}
+
+// clearAnnotation removes a one-shot annotation, such as run-now, from the policy so
+// it takes effect only once.
+func (r *PodCleanupPolicyReconciler) clearAnnotation(ctx context.Context, policy *cleanupv1.PodCleanupPolicy, key string) error {
+	patch := client.MergeFrom(policy.DeepCopy())
+	delete(policy.Annotations, key)
+	return r.Patch(ctx, policy, patch)
+}
+
+// reconcileProtectPolicy marks a Protect policy as ready. Protect policies never run
+// on their own; Delete policies consult them during each of their runs.
+func (r *PodCleanupPolicyReconciler) reconcileProtectPolicy(ctx context.Context, policy *cleanupv1.PodCleanupPolicy) error {
+	original := policy.Status.DeepCopy()
+	mutate := func() {
+		r.setCondition(policy, cleanupv1.ConditionReady, metav1.ConditionTrue, cleanupv1.ReasonProtecting,
+			"Matching pods are excluded from every other policy")
+	}
+	mutate()
+	if equality.Semantic.DeepEqual(original, &policy.Status) {
+		return nil
+	}
+	return updateStatus(ctx, r.Client, policy, mutate)
+}
+
+// runCleanup iterates over all target namespaces, in the operator's own cluster or
+// the policy's workload clusters, and deletes matching pods.
+func (r *PodCleanupPolicyReconciler) runCleanup(ctx context.Context, run *cleanupRun) (int, error) {
+	logger := log.FromContext(ctx)
+
+	defer run.closeMatcher()
+	if err := r.checkAlertThreshold(ctx, run); err != nil {
+		return 0, err
 	}
 
 	total := 0
-	for _, ns := range namespaces {
-		count, err := r.cleanupPodsInNamespace(ctx, policy, ns)
+	if run.resume != nil {
+		total = int(run.resume.PodsDeleted)
+	}
+	var err error
+	if len(run.policy.Spec.ClusterRefs) > 0 {
+		total, err = r.cleanupClusters(ctx, run, total)
+	} else {
+		total, _, err = r.cleanupCluster(ctx, run, total)
+	}
+	if err != nil {
+		if ctx.Err() != nil {
+			logger.Info("Cleanup run stopped", "podsAffected", total, "reason", context.Cause(ctx))
+		}
+		return total, err
+	}
+
+	logger.Info("Cleanup run finished", "podsAffected", total, "dryRun", run.dryRun)
+	return total, nil
+}
+
+// cleanupCluster deletes matching pods in the target namespaces of the run's
+// cluster, adding them to total. It returns the new total and the number of
+// namespaces processed.
+func (r *PodCleanupPolicyReconciler) cleanupCluster(ctx context.Context, run *cleanupRun, total int) (int, int, error) {
+	logger := log.FromContext(ctx)
+
+	namespaces, err := r.getTargetNamespaces(ctx, run)
+	if err != nil {
+		return total, 0, fmt.Errorf("listing target namespaces: %w", err)
+	}
+	run.namespacesTargeted += len(namespaces)
+
+	start := 0
+	if checkpoint := run.resume; checkpoint != nil {
+		start, _ = slices.BinarySearchFunc(namespaces, checkpoint.Namespace, func(ns corev1.Namespace, name string) int {
+			return strings.Compare(ns.Name, name)
+		})
+	}
+	for i := start; i < len(namespaces); i++ {
+		r.reportProgress(ctx, run, i, len(namespaces), total)
+		ns := &namespaces[i]
+		if run.resume == nil || run.resume.Namespace != ns.Name {
+			run.resume = nil
+			r.checkpoint(ctx, run, ns.Name, podListPosition{}, i, total)
+		}
+		if namespaceOptedOut(ns) {
+			logger.V(1).Info("Skipping namespace that opted out of cleanup", "namespace", ns.Name)
+			run.explain(ctx, ns.Name, "", false, ReasonNamespaceOptedOut,
+				"namespace is annotated %s=true", cleanupv1.AnnotationOptOut)
+			continue
+		}
+		if ns.DeletionTimestamp != nil && run.policy.Spec.NamespaceTTL != nil {
+			// The namespace is already going away with all its pods.
+			continue
+		}
+		expired, err := namespaceExpired(run.policy, ns, r.Clock.Now())
+		if err == nil && expired && run.policy.Spec.NamespaceTTL.DeleteNamespace {
+			err = r.deleteExpiredNamespace(ctx, run, ns)
+			if err == nil {
+				continue
+			}
+		}
+		if err != nil {
+			logger.Error(err, "Error expiring namespace", "namespace", ns.Name)
+			run.explain(ctx, ns.Name, "", false, ReasonNamespaceError, "%v", err)
+			if isTransient(err) {
+				run.transientFailures++
+			}
+			continue
+		}
+		count, err := r.cleanupPodsInNamespace(ctx, run, ns)
+		if count > 0 {
+			run.namespacesCleaned++
+		}
+		if ctx.Err() != nil {
+			// The run was canceled; stop with the deletions made so far.
+			return total + count, i - start, context.Cause(ctx)
+		}
+		if errors.IsForbidden(err) {
+			logger.Info("Skipping namespace without pod permissions", "namespace", ns.Name)
+			run.forbiddenNamespaces = append(run.forbiddenNamespaces, run.qualifiedNamespace(ns.Name))
+			run.explain(ctx, ns.Name, "", false, ReasonNamespaceForbidden, "pods cannot be listed: %v", err)
+			continue
+		}
 		if err != nil {
-			logger.Error(err, "Error cleaning pods in namespace", "namespace", ns)
+			logger.Error(err, "Error cleaning pods in namespace", "namespace", ns.Name)
+			run.explain(ctx, ns.Name, "", false, ReasonNamespaceError, "%v", err)
+			if isTransient(err) {
+				run.transientFailures++
+			}
 			continue
 		}
 		total += count
 	}
-
-	logger.Info("Cleanup run finished", "podsAffected", total, "dryRun", policy.Spec.DryRun)
-	return total, nil
+	return total, len(namespaces) - start, nil
 }
 
-// getTargetNamespaces returns the list of namespace names that the policy applies to.
-func (r *PodCleanupPolicyReconciler) getTargetNamespaces(ctx context.Context, policy *cleanupv1.PodCleanupPolicy) ([]string, error) {
+// getTargetNamespaces returns the namespaces that the policy applies to, excluding
+// namespaces protected by the OperatorConfig, in name order.
+func (r *PodCleanupPolicyReconciler) getTargetNamespaces(ctx context.Context, run *cleanupRun) ([]corev1.Namespace, error) {
+	policy := run.policy
 	nsList := &corev1.NamespaceList{}
 
 	if policy.Spec.NamespaceSelector == nil {
-		if err := r.List(ctx, nsList); err != nil {
+		if err := run.cluster.List(ctx, nsList); err != nil {
 			return nil, err
 		}
 	} else {
@@ -146,24 +1123,43 @@ func (r *PodCleanupPolicyReconciler) getTargetNamespaces(ctx context.Context, po
 		if err != nil {
 			return nil, fmt.Errorf("invalid namespaceSelector: %w", err)
 		}
-		if err := r.List(ctx, nsList, &client.ListOptions{LabelSelector: selector}); err != nil {
+		if err := run.cluster.List(ctx, nsList, &client.ListOptions{LabelSelector: selector}); err != nil {
 			return nil, err
 		}
 	}
 
-	names := make([]string, 0, len(nsList.Items))
+	protected := make(map[string]bool, len(run.config.ProtectedNamespaces))
+	for _, ns := range run.config.ProtectedNamespaces {
+		protected[ns] = true
+	}
+
+	namespaces := make([]corev1.Namespace, 0, len(nsList.Items))
 	for _, ns := range nsList.Items {
-		names = append(names, ns.Name)
+		if protected[ns.Name] {
+			continue
+		}
+		namespaces = append(namespaces, ns)
 	}
-	return names, nil
+	slices.SortFunc(namespaces, func(a, b corev1.Namespace) int { return strings.Compare(a.Name, b.Name) })
+	return namespaces, nil
 }
 
 // cleanupPodsInNamespace lists pods in the given namespace and deletes those that
 // match the policy criteria.
-func (r *PodCleanupPolicyReconciler) cleanupPodsInNamespace(ctx context.Context, policy *cleanupv1.PodCleanupPolicy, namespace string) (int, error) {
+func (r *PodCleanupPolicyReconciler) cleanupPodsInNamespace(ctx context.Context, run *cleanupRun, ns *corev1.Namespace) (int, error) {
 	logger := log.FromContext(ctx)
+	policy := run.policy
 
-	listOpts := []client.ListOption{client.InNamespace(namespace)}
+	ages, err := effectiveMaxAges(policy, ns)
+	if err != nil {
+		return 0, err
+	}
+	if expired, _ := namespaceExpired(policy, ns, r.Clock.Now()); expired {
+		// Pods of an expired ephemeral namespace are cleaned up regardless of age.
+		ages = maxAges{}
+	}
+
+	listOpts := []client.ListOption{client.InNamespace(ns.Name)}
 	if policy.Spec.PodSelector != nil {
 		selector, err := metav1.LabelSelectorAsSelector(policy.Spec.PodSelector)
 		if err != nil {
@@ -171,49 +1167,387 @@ func (r *PodCleanupPolicyReconciler) cleanupPodsInNamespace(ctx context.Context,
 		}
 		listOpts = append(listOpts, client.MatchingLabelsSelector{Selector: selector})
 	}
-
-	podList := &corev1.PodList{}
-	if err := r.List(ctx, podList, listOpts...); err != nil {
-		return 0, err
+	kept, err := r.newestPods(ctx, run, ns.Name, listOpts)
+	if err != nil {
+		return 0, fmt.Errorf("ranking the newest pods: %w", err)
 	}
 
+	// Pages of pods listed from the API server are checkpointed, so a resumed run
+	// continues with the next page.
+	var processed, deletedBefore int
+	if run.checkpoint != nil {
+		processed, deletedBefore = int(run.checkpoint.NamespacesProcessed), int(run.checkpoint.PodsDeleted)
+	}
 	deleted := 0
-	for i := range podList.Items {
-		pod := &podList.Items[i]
-		if !r.shouldDeletePod(policy, pod) {
-			continue
+	onPage := func(next podListPosition) {
+		r.checkpoint(ctx, run, ns.Name, next, processed, deletedBefore+deleted)
+	}
+	err = r.forEachPod(ctx, run, ns.Name, listOpts, run.resumePosition(ns.Name), onPage, func(pod *corev1.Pod) error {
+		if ctx.Err() != nil {
+			return context.Cause(ctx)
+		}
+		run.podsListed++
+		logger := run.podLogger(logger)
+		ctx := log.IntoContext(ctx, logger)
+		podAge := r.podAge(policy, pod)
+		podMaxAge := ages.forPhase(pod.Status.Phase)
+		if run.maintenanceNodes != nil {
+			// Maintenance runs leave every other pod to the scheduled runs, including
+			// their candidate annotations, and ignore maxAge.
+			if !inMaintenanceSweep(run, pod) {
+				return nil
+			}
+			podMaxAge = 0
 		}
+		if run.scaleDownNodes != nil && !run.scaleDownNodes[pod.Spec.NodeName] {
+			return nil
+		}
+		matched, rule, reason, explanation := r.shouldDeletePod(run, pod, podMaxAge)
+		if !matched {
+			run.explain(ctx, pod.Namespace, pod.Name, false, reason, "%s", explanation)
+			r.clearCandidateAnnotation(ctx, run, pod)
+			return nil
+		}
+		if ok, nodeExplanation := r.podNodeMatches(ctx, run, pod); !ok {
+			run.explain(ctx, pod.Namespace, pod.Name, false, ReasonNodeNotMatched, "%s", nodeExplanation)
+			r.clearCandidateAnnotation(ctx, run, pod)
+			return nil
+		}
+		if stuck, claimExplanation := r.podStuckOnVolumeClaim(ctx, run, pod); !stuck {
+			run.explain(ctx, pod.Namespace, pod.Name, false, ReasonVolumeClaimsHealthy, "%s", claimExplanation)
+			r.clearCandidateAnnotation(ctx, run, pod)
+			return nil
+		} else if claimExplanation != "" {
+			explanation += ", " + claimExplanation
+		}
+		if stuck, sidecarExplanation := r.podStuckOnSidecar(run, pod); !stuck {
+			run.explain(ctx, pod.Namespace, pod.Name, false, ReasonSidecarNotStuck, "%s", sidecarExplanation)
+			r.clearCandidateAnnotation(ctx, run, pod)
+			return nil
+		} else if sidecarExplanation != "" {
+			explanation += ", " + sidecarExplanation
+		}
+		if orphaned, orphanExplanation := r.podOrphaned(ctx, run, pod); !orphaned {
+			run.explain(ctx, pod.Namespace, pod.Name, false, ReasonOwnerExists, "%s", orphanExplanation)
+			r.clearCandidateAnnotation(ctx, run, pod)
+			return nil
+		} else if orphanExplanation != "" {
+			explanation += ", " + orphanExplanation
+		}
+		ruleName := ""
+		if rule != nil {
+			ruleName = rule.Name
+		}
+		if match, matchExplanation := r.podMatchesExternally(ctx, run, pod, podAge, ruleName); !match {
+			run.explain(ctx, pod.Namespace, pod.Name, false, ReasonNotMatchedExternally, "%s", matchExplanation)
+			r.clearCandidateAnnotation(ctx, run, pod)
+			return nil
+		} else if matchExplanation != "" {
+			explanation += ", " + matchExplanation
+		}
+		if protector := protectingPolicy(run, ns, pod); protector != "" {
+			logger.V(1).Info("Skipping pod shielded by a Protect policy",
+				"namespace", pod.Namespace, "pod", pod.Name, "protectPolicy", protector)
+			run.explain(ctx, pod.Namespace, pod.Name, false, ReasonProtected,
+				"%s, but Protect policy %s matches the pod", explanation, protector)
+			r.clearCandidateAnnotation(ctx, run, pod)
+			run.recordPod(pod, podAge, outcomeProtected)
+			run.protected++
+			return nil
+		}
+		if retention, until := retainingPolicy(run, ns, pod, r.Clock.Now()); retention != nil {
+			logger.V(1).Info("Skipping pod kept by a PodRetentionPolicy",
+				"namespace", pod.Namespace, "pod", pod.Name, "retentionPolicy", retention.name, "until", until)
+			reason := ""
+			if retention.spec.Reason != "" {
+				reason = fmt.Sprintf(" (%s)", retention.spec.Reason)
+			}
+			run.explain(ctx, pod.Namespace, pod.Name, false, ReasonRetained,
+				"%s, but PodRetentionPolicy %s%s retains it until %s", explanation, retention.name, reason,
+				until.UTC().Format(time.RFC3339))
+			r.clearCandidateAnnotation(ctx, run, pod)
+			run.recordPod(pod, podAge, outcomeRetained)
+			run.retained++
+			return nil
+		}
+		if kept[pod.UID] {
+			keep := policy.Spec.KeepNewest
+			group := "in namespace " + pod.Namespace
+			if value, ok := pod.Labels[keep.GroupBy]; ok && keep.GroupBy != "" {
+				group = fmt.Sprintf("labeled %s=%s", keep.GroupBy, value)
+			} else if keep.GroupBy != "" {
+				group = "without label " + keep.GroupBy
+			}
+			run.explain(ctx, pod.Namespace, pod.Name, false, ReasonKeptNewest,
+				"%s, but it is one of the %d newest pods %s", explanation, keep.Count, group)
+			r.clearCandidateAnnotation(ctx, run, pod)
+			run.recordPod(pod, podAge, outcomeKeptNewest)
+			return nil
+		}
+		if policy.Spec.SkipPodsWithEndpoints {
+			service, err := r.servingService(ctx, run, pod)
+			if err != nil || service != "" {
+				// A failed lookup is treated as serving traffic; the pod is retried
+				// in the next run.
+				message := fmt.Sprintf("%s, but it is a ready endpoint of Service %s", explanation, service)
+				if err != nil {
+					message = fmt.Sprintf("%s, but its endpoints could not be checked: %v", explanation, err)
+				}
+				logger.V(1).Info("Skipping pod serving traffic",
+					"namespace", pod.Namespace, "pod", pod.Name, "service", service)
+				run.explain(ctx, pod.Namespace, pod.Name, false, ReasonServingTraffic, "%s", message)
+				r.clearCandidateAnnotation(ctx, run, pod)
+				run.recordPod(pod, podAge, outcomeServingTraffic)
+				return nil
+			}
+		}
+		if owner := higherPriorityOwner(run, ns, pod); owner != "" {
+			logger.V(1).Info("Skipping pod owned by a higher-priority policy",
+				"namespace", pod.Namespace, "pod", pod.Name, "ownerPolicy", owner)
+			run.explain(ctx, pod.Namespace, pod.Name, false, ReasonHigherPriority,
+				"%s, but higher-priority policy %s also matches the pod", explanation, owner)
+			r.clearCandidateAnnotation(ctx, run, pod)
+			run.recordPod(pod, podAge, outcomeSkippedByPriority)
+			run.skippedByPriority++
+			return nil
+		}
+		run.explain(ctx, pod.Namespace, pod.Name, true, ReasonSelected, "%s", explanation)
+		run.selected++
 
-		podAge := time.Since(pod.CreationTimestamp.Time).Round(time.Second)
-		if policy.Spec.DryRun {
-			logger.Info("DryRun: would delete pod",
-				"namespace", pod.Namespace,
-				"pod", pod.Name,
-				"phase", pod.Status.Phase,
-				"age", podAge,
-			)
+		action := cleanupv1.RuleActionDelete
+		if rule != nil {
+			action = rule.Action
+		}
+		switch action {
+		case cleanupv1.RuleActionNotify:
+			r.clearCandidateAnnotation(ctx, run, pod)
+			run.notifyPod(pod, podAge)
+			return nil
+		case cleanupv1.RuleActionLabel:
+			r.clearCandidateAnnotation(ctx, run, pod)
+			r.labelPod(ctx, run, pod, rule.Labels, podAge)
+			return nil
+		}
+		if run.quitsSidecars() {
+			if r.skipDenied(ctx, logger, run, ns, pod, podAge, string(cleanupv1.SidecarActionQuit), ruleName, explanation) {
+				return nil
+			}
+			r.clearCandidateAnnotation(ctx, run, pod)
+			return r.quitSidecars(ctx, run, pod, podAge)
+		}
+		if mesh := meshAnnotation(run.config, pod); mesh != "" {
+			if run.config.ServiceMesh.Exclude {
+				logger.V(1).Info("Skipping pod in the service mesh",
+					"namespace", pod.Namespace, "pod", pod.Name, "annotation", mesh)
+				run.explain(ctx, pod.Namespace, pod.Name, false, ReasonMeshExcluded,
+					"%s, but it is in the service mesh (annotated %s)", explanation, mesh)
+				r.clearCandidateAnnotation(ctx, run, pod)
+				run.recordPod(pod, podAge, outcomeMeshExcluded)
+				return nil
+			}
+			if run.config.ServiceMesh.Evict {
+				action = cleanupv1.RuleActionEvict
+			}
+		}
+		if annotation := disruptionAnnotation(run.config, pod); annotation != "" {
+			logger.V(1).Info("Skipping pod protected by a disruption annotation",
+				"namespace", pod.Namespace, "pod", pod.Name, "annotation", annotation)
+			run.explain(ctx, pod.Namespace, pod.Name, false, ReasonDisruptionProtected,
+				"%s, but it is annotated %s", explanation, annotation)
+			r.clearCandidateAnnotation(ctx, run, pod)
+			run.recordPod(pod, podAge, outcomeDisruptionProtected)
+			run.disruptionProtected++
+			return nil
+		}
+		verb, removing, outcome := "delete", "Deleting pod", outcomeWouldDelete
+		if action == cleanupv1.RuleActionEvict {
+			verb, removing, outcome = "evict", "Evicting pod", outcomeWouldEvict
+		}
+		if r.skipDenied(ctx, logger, run, ns, pod, podAge, string(action), ruleName, explanation) {
+			return nil
+		}
+
+		if run.dryRun {
+			candidate := Candidate{
+				Namespace: pod.Namespace,
+				Name:      pod.Name,
+				Phase:     pod.Status.Phase,
+				Age:       podAge,
+			}
+			if rule != nil {
+				candidate.Rule = rule.Name
+			}
+			run.candidates = append(run.candidates, candidate)
+			if !run.preview {
+				logger.Info("DryRun: would "+verb+" pod",
+					"namespace", pod.Namespace,
+					"pod", pod.Name,
+					"phase", pod.Status.Phase,
+					"age", podAge,
+				)
+				if policy.Spec.AnnotateCandidates {
+					r.annotateCandidate(ctx, run, pod)
+				}
+			}
+			r.removeCompanions(ctx, run, pod)
+			run.recordPod(pod, podAge, outcome)
+			run.noteRemovedPod(pod)
 			deleted++
-			continue
+			return nil
+		}
+
+		if warned, deletionAt := r.warnBeforeDeletion(ctx, run, pod); warned {
+			logger.V(1).Info("Deferring pod deletion; deletion warning pending",
+				"namespace", pod.Namespace, "pod", pod.Name, "deletionAt", deletionAt)
+			run.explain(ctx, pod.Namespace, pod.Name, false, ReasonDeletionWarned,
+				"%s, but it is annotated for deletion at %s", explanation, deletionAt.UTC().Format(time.RFC3339))
+			run.recordPod(pod, podAge, outcomeWarned)
+			run.warned++
+			return nil
 		}
 
-		logger.Info("Deleting pod",
+		// Reserved quota and budget are returned unless the pod is removed.
+		removed := false
+		tenant := tenantOf(run.config.TenantQuota, ns)
+		if tq := run.config.TenantQuota; tq != nil {
+			reserved, err := r.tenantDeletions.reserve(ctx, tenant, int(tq.MaxDeletionsPerDay))
+			if err != nil {
+				return fmt.Errorf("reserving tenant quota: %w", err)
+			}
+			if !reserved {
+				logger.V(1).Info("Deferring pod deletion; tenant quota exhausted",
+					"namespace", pod.Namespace, "pod", pod.Name, "tenant", tenant)
+				run.explain(ctx, pod.Namespace, pod.Name, false, ReasonDeferredByQuota,
+					"%s, but tenant %s exhausted its daily quota", explanation, tenant)
+				run.recordPod(pod, podAge, outcomeDeferredByQuota)
+				run.deferredByQuota++
+				return nil
+			}
+			defer func() {
+				if !removed {
+					r.tenantDeletions.release(tenant)
+				}
+			}()
+		}
+		reservation, exhaustedBudget, err := r.reserveBudget(ctx, run.qualifiedNamespace(pod.Namespace))
+		if err != nil {
+			return fmt.Errorf("reserving cleanup budget: %w", err)
+		}
+		if exhaustedBudget != "" {
+			logger.V(1).Info("Deferring pod deletion; cleanup budget exhausted",
+				"namespace", pod.Namespace, "pod", pod.Name, "budget", exhaustedBudget)
+			run.explain(ctx, pod.Namespace, pod.Name, false, ReasonDeferredByBudget,
+				"%s, but ClusterCleanupBudget %s is exhausted", explanation, exhaustedBudget)
+			run.recordPod(pod, podAge, outcomeDeferredByBudget)
+			run.deferredByBudget++
+			budgetDeferredPods.WithLabelValues(exhaustedBudget).Inc()
+			return nil
+		}
+		defer func() {
+			if !removed {
+				r.releaseBudget(reservation)
+			}
+		}()
+
+		logger.Info(removing,
 			"namespace", pod.Namespace,
 			"pod", pod.Name,
 			"phase", pod.Status.Phase,
 			"age", podAge,
 		)
-		if err := r.Delete(ctx, pod); err != nil && !errors.IsNotFound(err) {
-			logger.Error(err, "Failed to delete pod", "pod", pod.Name, "namespace", pod.Namespace)
-			continue
+		if err := run.limiter.Wait(ctx); err != nil {
+			return err
+		}
+		if err := r.deleteLimiter.Wait(ctx); err != nil {
+			return err
+		}
+		if err := r.Backpressure.Wait(ctx); err != nil {
+			return err
+		}
+		if err := r.archivePod(ctx, run, pod); err != nil {
+			logger.Error(err, "Failed to archive pod", "pod", pod.Name, "namespace", pod.Namespace)
+			run.recordPod(pod, podAge, outcomeArchiveFailed)
+			return fmt.Errorf("archiving pods: %w", err)
 		}
+		err = r.withAPIBudget(ctx, func() error {
+			if action == cleanupv1.RuleActionEvict {
+				return run.evictPod(ctx, pod)
+			}
+			var deleteOpts []client.DeleteOption
+			if gracePeriod := run.gracePeriodSeconds(pod); gracePeriod != nil {
+				deleteOpts = append(deleteOpts, client.GracePeriodSeconds(*gracePeriod))
+			}
+			return run.podClient.Delete(ctx, pod, deleteOpts...)
+		})
+		if err != nil && ctx.Err() != nil {
+			// The run was canceled while waiting for the API budget or the call.
+			return context.Cause(ctx)
+		}
+		if err != nil && !errors.IsNotFound(err) {
+			logger.Error(err, "Failed to "+verb+" pod", "pod", pod.Name, "namespace", pod.Namespace)
+			outcome := outcomeDeleteFailed
+			if action == cleanupv1.RuleActionEvict {
+				outcome = outcomeEvictFailed
+			}
+			run.recordPod(pod, podAge, outcome)
+			if isTransient(err) {
+				run.transientFailures++
+			}
+			return nil
+		}
+		removed = true
+		run.observeAgeAtDeletion(podAge)
+		r.auditPodRemoved(ctx, run, pod, podAge, action)
+		r.removeCompanions(ctx, run, pod)
+		// CleanupReports live in the namespaces of the operator's own cluster.
+		if r.namespaceReports != nil && run.clusterName == "" {
+			r.namespaceReports.record(run.policy.Name, pod)
+		}
+		if action == cleanupv1.RuleActionEvict {
+			run.recordPod(pod, podAge, outcomeEvicted)
+		} else {
+			run.recordPod(pod, podAge, outcomeDeleted)
+		}
+		run.noteRemovedPod(pod)
 		deleted++
+		return nil
+	})
+	return deleted, err
+}
+
+// podAge returns the pod's age, measured from its creation or, with maxAgeFrom
+// Start, from its start. A pod that has not started has no age in that case.
+func (r *PodCleanupPolicyReconciler) podAge(policy *cleanupv1.PodCleanupPolicy, pod *corev1.Pod) time.Duration {
+	from := pod.CreationTimestamp.Time
+	if policy.Spec.MaxAgeFrom == cleanupv1.AgeFromStart {
+		if pod.Status.StartTime == nil {
+			return 0
+		}
+		from = pod.Status.StartTime.Time
 	}
+	return r.Clock.Since(from).Round(time.Second)
+}
 
-	return deleted, nil
+// podRunningAndReady reports whether the pod is Running with its Ready condition True.
+func podRunningAndReady(pod *corev1.Pod) bool {
+	if pod.Status.Phase != corev1.PodRunning {
+		return false
+	}
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodReady {
+			return cond.Status == corev1.ConditionTrue
+		}
+	}
+	return false
 }
 
-// shouldDeletePod returns true when the pod satisfies all criteria defined in the policy.
-func (r *PodCleanupPolicyReconciler) shouldDeletePod(policy *cleanupv1.PodCleanupPolicy, pod *corev1.Pod) bool {
+// shouldDeletePod returns true when the pod satisfies all criteria defined in the policy,
+// along with the matched rule, the reason and an explanation of the decision. The rule
+// is nil when the policy has no anyOf rules.
+// maxAge is the effective minimum age for the pod's namespace and phase; zero disables
+// the age check.
+func (r *PodCleanupPolicyReconciler) shouldDeletePod(run *cleanupRun, pod *corev1.Pod, maxAge time.Duration) (bool, *match.Rule, string, string) {
+	policy := run.policy
 	// Filter by pod phase, if specified.
 	if len(policy.Spec.PodStatuses) > 0 {
 		matched := false
@@ -224,33 +1558,218 @@ func (r *PodCleanupPolicyReconciler) shouldDeletePod(policy *cleanupv1.PodCleanu
 			}
 		}
 		if !matched {
-			return false
+			return false, nil, ReasonPhaseNotSelected,
+				fmt.Sprintf("phase %s is not one of podStatuses %v", pod.Status.Phase, policy.Spec.PodStatuses)
+		}
+	}
+
+	// Filter by pod conditions, if specified.
+	for _, cond := range policy.Spec.PodConditions {
+		if ok, explanation := match.Condition(pod, cond, r.Clock.Now()); !ok {
+			return false, nil, ReasonConditionNotMatched, explanation
 		}
 	}
 
 	// Filter by age, if specified.
-	if policy.Spec.MaxAge != "" {
-		maxAge, err := time.ParseDuration(policy.Spec.MaxAge)
-		if err != nil {
-			// Invalid maxAge – skip this pod rather than panic.
-			return false
+	age := r.podAge(policy, pod)
+	if maxAge > 0 && age < maxAge {
+		return false, nil, ReasonTooYoung, fmt.Sprintf("age %s is below maxAge %s", age, cleanupv1.Duration{Duration: maxAge})
+	}
+
+	// Running pods that report Ready are presumed healthy and are only deleted by
+	// policies that deliberately target them.
+	if !policy.Spec.AllowReadyPods && podRunningAndReady(pod) {
+		return false, nil, ReasonPodReady, "pod is Running and Ready, and allowReadyPods is not set"
+	}
+
+	// Filter by the boolean criteria of spec.match, if specified.
+	matched, rule, explanation := run.matcher.Match(pod, age, r.Clock.Now())
+	if !matched {
+		return false, nil, ReasonCriteriaNotMatched, explanation
+	}
+	if rule != nil {
+		return true, rule, ReasonSelected, fmt.Sprintf("phase %s and age %s match the policy, %s", pod.Status.Phase, age, explanation)
+	}
+	return true, nil, ReasonSelected, fmt.Sprintf("phase %s and age %s match the policy", pod.Status.Phase, age)
+}
+
+// tenantOf returns the quota tenant of a namespace: the value of the tenant label
+// when configured and present, otherwise the namespace itself.
+func tenantOf(tq *cleanupv1.TenantQuota, ns *corev1.Namespace) string {
+	if tq != nil && tq.TenantLabel != "" {
+		if tenant, ok := ns.Labels[tq.TenantLabel]; ok {
+			return "tenant/" + tenant
+		}
+	}
+	return "namespace/" + ns.Name
+}
+
+// getOperatorConfig returns the spec of the singleton OperatorConfig. An empty
+// spec is returned when no OperatorConfig exists.
+func (r *PodCleanupPolicyReconciler) getOperatorConfig(ctx context.Context) (*cleanupv1.OperatorConfigSpec, error) {
+	config := &cleanupv1.OperatorConfig{}
+	if err := r.Get(ctx, client.ObjectKey{Name: cleanupv1.OperatorConfigName}, config); err != nil {
+		if errors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return &cleanupv1.OperatorConfigSpec{}, nil
+		}
+		return nil, fmt.Errorf("getting OperatorConfig: %w", err)
+	}
+	return &config.Spec, nil
+}
+
+// applyRateLimit updates the shared deletion limiter from the OperatorConfig.
+func (r *PodCleanupPolicyReconciler) applyRateLimit(config *cleanupv1.OperatorConfigSpec) {
+	setLimit(r.deleteLimiter, config.RateLimit)
+}
+
+// policyLimiter returns the deletion limiter of the named policy, updated to rateLimit.
+func (r *PodCleanupPolicyReconciler) policyLimiter(name string, rateLimit *cleanupv1.RateLimit) *rate.Limiter {
+	r.policyLimitersMu.Lock()
+	defer r.policyLimitersMu.Unlock()
+
+	limiter, ok := r.policyLimiters[name]
+	if !ok {
+		limiter = rate.NewLimiter(rate.Inf, 0)
+		if r.policyLimiters == nil {
+			r.policyLimiters = make(map[string]*rate.Limiter)
+		}
+		r.policyLimiters[name] = limiter
+	}
+	setLimit(limiter, rateLimit)
+	return limiter
+}
+
+// forgetPolicyLimiter drops the deletion limiter of a deleted policy.
+func (r *PodCleanupPolicyReconciler) forgetPolicyLimiter(name string) {
+	r.policyLimitersMu.Lock()
+	defer r.policyLimitersMu.Unlock()
+	delete(r.policyLimiters, name)
+}
+
+// setLimit applies rateLimit to limiter; a nil rateLimit removes the limit.
+func setLimit(limiter *rate.Limiter, rateLimit *cleanupv1.RateLimit) {
+	if rateLimit == nil {
+		limiter.SetLimit(rate.Inf)
+		return
+	}
+	burst := rateLimit.Burst
+	if burst == 0 {
+		burst = rateLimit.DeletionsPerSecond
+	}
+	limiter.SetLimit(rate.Limit(rateLimit.DeletionsPerSecond))
+	limiter.SetBurst(int(burst))
+}
+
+// gracePeriodSeconds returns the grace period for removing the pod: the policy's own
+// (or inherited) value, falling back to the OperatorConfig, and raised to the
+// serviceMesh grace period for mesh pods.
+func (run *cleanupRun) gracePeriodSeconds(pod *corev1.Pod) *int64 {
+	gracePeriod := run.config.GracePeriodSeconds
+	if run.spec.GracePeriodSeconds != nil {
+		gracePeriod = run.spec.GracePeriodSeconds
+	}
+	if meshAnnotation(run.config, pod) != "" {
+		if least := run.config.ServiceMesh.GracePeriodSeconds; least != nil && (gracePeriod == nil || *gracePeriod < *least) {
+			gracePeriod = least
+		}
+	}
+	return gracePeriod
+}
+
+// sendNotifications posts the run summary to every endpoint configured in the
+// OperatorConfig and the policy, or adds it to the next digest of endpoints in digest
+// mode. Delivery failures are logged and never fail the run.
+func (r *PodCleanupPolicyReconciler) sendNotifications(ctx context.Context, run *cleanupRun, deleted int, runErr error) {
+	endpoints := append(append([]cleanupv1.NotificationEndpoint{}, run.config.Notifications...), run.spec.Notifications...)
+	if len(endpoints) == 0 {
+		return
+	}
+	logger := log.FromContext(ctx)
+
+	summary := notify.Summary{
+		SchemaVersion: cleanupv1.RecordSchemaVersion,
+		RunID:         string(run.id),
+		Policy:        run.policy.Name,
+		Time:          r.Clock.Now(),
+		DryRun:        run.dryRun,
+		PodsDeleted:   deleted,
+		PodsLabeled:   run.labeled,
+		PodsNotified:  run.notified,
+		NotifiedPods:  run.notifiedPods,
+		Cluster:       run.clusterName,
+	}
+	if runErr != nil {
+		summary.Error = runErr.Error()
+	}
+	for _, endpoint := range endpoints {
+		webhook := notify.NewWebhook(endpoint.URL)
+		webhook.Format = endpoint.Format
+		webhook.Tags = endpoint.Tags
+		if endpoint.SecretRef != nil {
+			token, err := r.secretValue(ctx, endpoint.SecretRef)
+			if err != nil {
+				logger.Error(err, "Failed to read notification credentials", "endpoint", endpoint.Name)
+				continue
+			}
+			webhook.Token = token
+		}
+		if endpoint.Signing != nil {
+			key, err := r.secretValue(ctx, &endpoint.Signing.SecretRef)
+			if err != nil {
+				logger.Error(err, "Failed to read notification signing key", "endpoint", endpoint.Name)
+				continue
+			}
+			webhook.SigningKey = []byte(key)
+		}
+		if endpoint.Digest != nil && r.digester != nil {
+			// Validated with the OperatorConfig and policy specs.
+			if interval, err := cleanupv1.ParseDuration(endpoint.Digest.Interval); err == nil {
+				r.digester.Add(endpoint.Name, webhook, interval, summary)
+				continue
+			}
 		}
-		if time.Since(pod.CreationTimestamp.Time) < maxAge {
-			return false
+		if err := webhook.Notify(ctx, summary); err != nil {
+			logger.Error(err, "Failed to send notification", "endpoint", endpoint.Name)
 		}
 	}
+}
+
+// joinCapped joins at most limit items with ", ", noting how many were omitted.
+func joinCapped(items []string, limit int) string {
+	if len(items) <= limit {
+		return strings.Join(items, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(items[:limit], ", "), len(items)-limit)
+}
 
-	return true
+// truncateMessage shortens message to at most limit characters, ending it with an
+// ellipsis if it was cut.
+func truncateMessage(message string, limit int) string {
+	runes := []rune(message)
+	if len(runes) <= limit {
+		return message
+	}
+	return string(runes[:limit-1]) + "…"
 }
 
-// setCondition updates or appends a condition on the policy status.
+// setCondition updates or appends a condition on the policy status. A Ready
+// condition also sets or clears status.lastError.
 func (r *PodCleanupPolicyReconciler) setCondition(policy *cleanupv1.PodCleanupPolicy, condType string, status metav1.ConditionStatus, reason, message string) {
+	if condType == cleanupv1.ConditionReady {
+		switch {
+		case status == metav1.ConditionTrue:
+			policy.Status.LastError = ""
+		case reason != cleanupv1.ReasonSuspended && reason != cleanupv1.ReasonRunCanceled:
+			// Suspending and canceling are asked for; they are not errors.
+			policy.Status.LastError = truncateMessage(message, cleanupv1.MaxLastErrorLength)
+		}
+	}
 	cond := metav1.Condition{
 		Type:               condType,
 		Status:             status,
 		Reason:             reason,
 		Message:            message,
-		LastTransitionTime: metav1.Now(),
+		LastTransitionTime: metav1.NewTime(r.Clock.Now()),
 		ObservedGeneration: policy.Generation,
 	}
 	for i, existing := range policy.Status.Conditions {
@@ -271,7 +1790,120 @@ func (r *PodCleanupPolicyReconciler) setCondition(policy *cleanupv1.PodCleanupPo
 
 // SetupWithManager registers the controller with the manager.
 func (r *PodCleanupPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if r.Clock == nil {
+		r.Clock = clock.RealClock{}
+	}
+	r.deleteLimiter = rate.NewLimiter(rate.Inf, 0)
+	if features.Enabled(features.EventDrivenMode) && (r.CachedPods || r.ScopePodWatches) {
+		r.candidates = newCandidateIndex()
+	}
+	if r.CachedPods {
+		if err := mgr.GetFieldIndexer().IndexField(context.Background(), &corev1.Pod{}, podPhaseField, indexPodPhase); err != nil {
+			return err
+		}
+		if r.candidates != nil {
+			podInformer, err := mgr.GetCache().GetInformer(context.Background(), &corev1.Pod{})
+			if err != nil {
+				return err
+			}
+			if _, err := podInformer.AddEventHandler(r.candidates.podHandler()); err != nil {
+				return err
+			}
+		}
+	}
+	if r.ScopePodWatches {
+		r.podWatches = newPodWatches(mgr)
+		if r.candidates != nil {
+			r.podWatches.handler = r.candidates.podHandler()
+		}
+		if err := r.podWatches.setup(); err != nil {
+			return err
+		}
+	}
+	if r.NamespaceReportInterval > 0 {
+		r.namespaceReports = newNamespaceReports(r.Client, r.Clock, r.NamespaceReportInterval)
+		if err := mgr.Add(r.namespaceReports); err != nil {
+			return err
+		}
+	}
+	r.tenantDeletions = newTenantQuotas(r.APIReader, r.Client, r.OperatorNamespace, r.Clock)
+	if err := mgr.Add(r.tenantDeletions); err != nil {
+		return err
+	}
+	r.budgetStore = newUsageStore(r.APIReader, r.Client, r.OperatorNamespace, budgetUsageConfigMap)
+	if err := mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+		return storePeriodically(ctx, r.storeBudgetUsage)
+	})); err != nil {
+		return err
+	}
+	r.digester = notify.NewDigester(r.Clock)
+	if err := mgr.Add(r.digester); err != nil {
+		return err
+	}
+	if err := mgr.Add(manager.RunnableFunc(r.checkScheduleHealthLoop)); err != nil {
+		return err
+	}
+	if err := mgr.Add(manager.RunnableFunc(r.closeAuditSinks)); err != nil {
+		return err
+	}
+
+	informer, err := mgr.GetCache().GetInformer(context.Background(), &cleanupv1.PodCleanupPolicy{})
+	if err != nil {
+		return err
+	}
+	if _, err := informer.AddEventHandler(r.runCancelHandler()); err != nil {
+		return err
+	}
+	if r.candidates != nil {
+		if _, err := informer.AddEventHandler(r.candidates.policyHandler()); err != nil {
+			return err
+		}
+	}
+	configInformer, err := mgr.GetCache().GetInformer(context.Background(), &cleanupv1.OperatorConfig{})
+	if err != nil {
+		return err
+	}
+	if _, err := configInformer.AddEventHandler(r.forcedDryRunCancelHandler()); err != nil {
+		return err
+	}
+
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &discoveryv1.EndpointSlice{}, readyEndpointPodIndex,
+		readyEndpointPods); err != nil {
+		return err
+	}
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &cleanupv1.PodCleanupPolicy{}, defaultsFromIndex,
+		func(obj client.Object) []string {
+			policy := obj.(*cleanupv1.PodCleanupPolicy)
+			if policy.Spec.DefaultsFrom == "" {
+				return nil
+			}
+			return []string{policy.Spec.DefaultsFrom}
+		}); err != nil {
+		return err
+	}
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &cleanupv1.PodCleanupPolicy{}, scheduleRefIndex,
+		func(obj client.Object) []string {
+			policy := obj.(*cleanupv1.PodCleanupPolicy)
+			if policy.Spec.ScheduleRef == "" {
+				return nil
+			}
+			return []string{policy.Spec.ScheduleRef}
+		}); err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&cleanupv1.PodCleanupPolicy{}).
+		For(&cleanupv1.PodCleanupPolicy{}, builder.WithPredicates(policyChanged())).
+		Watches(&cleanupv1.ClusterCleanupDefaults{}, handler.EnqueueRequestsFromMapFunc(r.policiesForDefaults),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&cleanupv1.CleanupSchedule{}, handler.EnqueueRequestsFromMapFunc(r.policiesForSchedule),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&cleanupv1.OperatorConfig{}, handler.EnqueueRequestsFromMapFunc(r.policiesForOperatorConfig),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&corev1.Node{}, handler.EnqueueRequestsFromMapFunc(r.policiesForMaintenanceNode),
+			builder.WithPredicates(nodeLabelsChanged())).
+		Watches(&corev1.Node{}, handler.EnqueueRequestsFromMapFunc(r.policiesForScaleDownNode),
+			builder.WithPredicates(nodeScaleDownStarted())).
+		WatchesMetadata(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.forgetSecret)).
 		Complete(r)
 }
@@ -0,0 +1,107 @@
+package controller
+
+import (
+	"sort"
+
+	"github.com/go-logr/logr"
+
+	cleanupv1 "github.com/aravindavvaru/pod-cleanup-operator/api/v1"
+)
+
+// policyLogger returns logger with the fields and level of the policy's
+// spec.logging applied.
+func policyLogger(logger logr.Logger, policy *cleanupv1.PodCleanupPolicy) logr.Logger {
+	logging := policy.Spec.Logging
+	if logging == nil {
+		return logger
+	}
+	keys := make([]string, 0, len(logging.Fields))
+	for key := range logging.Fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var values []any
+	for _, key := range keys {
+		values = append(values, key, logging.Fields[key])
+	}
+	for _, key := range logging.LabelFields {
+		if value, ok := policy.Labels[key]; ok {
+			values = append(values, key, value)
+		}
+	}
+	if len(values) > 0 {
+		logger = logger.WithValues(values...)
+	}
+	if logging.Level == cleanupv1.LogLevelDebug {
+		logger = withLogSink(logger, func(sink logr.LogSink) logr.LogSink {
+			return levelSink{LogSink: sink, debug: true}
+		})
+	}
+	return logger
+}
+
+// podLogger returns the logger for the lines about the next pod the run evaluates.
+// It drops their informational lines for Quiet policies and for pods sampled out by
+// spec.logging.podSampleRate.
+func (run *cleanupRun) podLogger(logger logr.Logger) logr.Logger {
+	logging := run.policy.Spec.Logging
+	if logging == nil {
+		return logger
+	}
+	run.podsLogged++
+	sampledOut := logging.PodSampleRate > 1 && (run.podsLogged-1)%int(logging.PodSampleRate) != 0
+	if logging.Level != cleanupv1.LogLevelQuiet && !sampledOut {
+		return logger
+	}
+	return withLogSink(logger, func(sink logr.LogSink) logr.LogSink {
+		return levelSink{LogSink: sink, quiet: true}
+	})
+}
+
+// withLogSink returns logger with its sink wrapped by wrap, one call frame deeper so
+// log lines keep their caller.
+func withLogSink(logger logr.Logger, wrap func(logr.LogSink) logr.LogSink) logr.Logger {
+	sink := logger.GetSink()
+	if sink == nil {
+		return logger
+	}
+	if cd, ok := sink.(logr.CallDepthLogSink); ok {
+		sink = cd.WithCallDepth(1)
+	}
+	return logger.WithSink(wrap(sink))
+}
+
+// levelSink adjusts the informational lines of a policy: quiet drops them, and debug
+// logs those of every verbosity as if -v allowed them. Errors always pass.
+type levelSink struct {
+	logr.LogSink
+	quiet bool
+	debug bool
+}
+
+func (s levelSink) Enabled(level int) bool {
+	if s.quiet {
+		return false
+	}
+	if s.debug {
+		level = 0
+	}
+	return s.LogSink.Enabled(level)
+}
+
+func (s levelSink) Info(level int, msg string, keysAndValues ...any) {
+	if s.debug {
+		level = 0
+	}
+	s.LogSink.Info(level, msg, keysAndValues...)
+}
+
+func (s levelSink) WithValues(keysAndValues ...any) logr.LogSink {
+	s.LogSink = s.LogSink.WithValues(keysAndValues...)
+	return s
+}
+
+func (s levelSink) WithName(name string) logr.LogSink {
+	s.LogSink = s.LogSink.WithName(name)
+	return s
+}
@@ -0,0 +1,98 @@
+package controller
+
+import (
+	"maps"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	cleanupv1 "github.com/aravindavvaru/pod-cleanup-operator/api/v1"
+)
+
+// policyChanged passes policy updates that need a reconcile: spec changes, a new
+// run-now request and the start of deletion. It drops the controller's own status,
+// finalizer and annotation updates, which would otherwise retrigger every run.
+func policyChanged() predicate.Predicate {
+	return predicate.Or(
+		predicate.GenerationChangedPredicate{},
+		predicate.Funcs{
+			UpdateFunc: func(e event.UpdateEvent) bool {
+				return runNowRequested(e) || deletionStarted(e)
+			},
+		},
+	)
+}
+
+// nodeLabelsChanged passes node creations and label changes, the events that can
+// put a node under maintenance.
+func nodeLabelsChanged() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			if e.ObjectOld == nil || e.ObjectNew == nil {
+				return false
+			}
+			return !maps.Equal(e.ObjectOld.GetLabels(), e.ObjectNew.GetLabels())
+		},
+		DeleteFunc:  func(event.DeleteEvent) bool { return false },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+	}
+}
+
+// nodeDrainChanged passes node creations and deletions, and the updates that can
+// start or stop a drain: changes of labels, of cordoning and of the drain annotation.
+func nodeDrainChanged() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldNode, ok := e.ObjectOld.(*corev1.Node)
+			if !ok {
+				return false
+			}
+			newNode, ok := e.ObjectNew.(*corev1.Node)
+			if !ok {
+				return false
+			}
+			return oldNode.Spec.Unschedulable != newNode.Spec.Unschedulable ||
+				oldNode.Annotations[cleanupv1.AnnotationDrain] != newNode.Annotations[cleanupv1.AnnotationDrain] ||
+				!maps.Equal(oldNode.Labels, newNode.Labels)
+		},
+		GenericFunc: func(event.GenericEvent) bool { return false },
+	}
+}
+
+// nodeScaleDownStarted passes node creations and the updates that make a node a
+// cluster-autoscaler scale-down candidate.
+func nodeScaleDownStarted() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldNode, ok := e.ObjectOld.(*corev1.Node)
+			if !ok {
+				return false
+			}
+			newNode, ok := e.ObjectNew.(*corev1.Node)
+			if !ok {
+				return false
+			}
+			return !scaleDownCandidate(oldNode) && scaleDownCandidate(newNode)
+		},
+		DeleteFunc:  func(event.DeleteEvent) bool { return false },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+	}
+}
+
+// runNowRequested reports whether the update set the run-now annotation.
+func runNowRequested(e event.UpdateEvent) bool {
+	if e.ObjectOld == nil || e.ObjectNew == nil {
+		return false
+	}
+	return e.ObjectNew.GetAnnotations()[cleanupv1.AnnotationRunNow] == "true" &&
+		e.ObjectOld.GetAnnotations()[cleanupv1.AnnotationRunNow] != "true"
+}
+
+// deletionStarted reports whether the update marked the object for deletion.
+func deletionStarted(e event.UpdateEvent) bool {
+	if e.ObjectOld == nil || e.ObjectNew == nil {
+		return false
+	}
+	return e.ObjectOld.GetDeletionTimestamp() == nil && e.ObjectNew.GetDeletionTimestamp() != nil
+}
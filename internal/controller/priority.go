@@ -0,0 +1,104 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	cleanupv1 "github.com/aravindavvaru/pod-cleanup-operator/api/v1"
+)
+
+// takesPrecedence reports whether policy a decides over policy b for a pod matched by both:
+// the higher priority wins, and ties are broken by name in ascending order.
+func takesPrecedence(a, b *cleanupv1.PodCleanupPolicy) bool {
+	if a.Spec.Priority != b.Spec.Priority {
+		return a.Spec.Priority > b.Spec.Priority
+	}
+	return a.Name < b.Name
+}
+
+// competingPolicies returns the other policies that can keep this policy from acting
+// on a pod: the Delete policies that take precedence over it, and all Protect policies.
+// Suspended, dry-run and preview policies never delete pods, so they do not take
+// precedence.
+func (r *PodCleanupPolicyReconciler) competingPolicies(ctx context.Context, policy *cleanupv1.PodCleanupPolicy) (higher, protectors []cleanupv1.PodCleanupPolicy, err error) {
+	policyList := &cleanupv1.PodCleanupPolicyList{}
+	if err := r.List(ctx, policyList); err != nil {
+		return nil, nil, fmt.Errorf("listing policies: %w", err)
+	}
+
+	for i := range policyList.Items {
+		other := &policyList.Items[i]
+		if other.UID == policy.UID || !other.DeletionTimestamp.IsZero() {
+			continue
+		}
+		switch {
+		case other.Spec.Action == cleanupv1.ActionProtect:
+			protectors = append(protectors, *other)
+		case other.Spec.Suspend || other.Spec.DryRun || other.Spec.Preview:
+		case takesPrecedence(other, policy):
+			higher = append(higher, *other)
+		}
+	}
+	return higher, protectors, nil
+}
+
+// protectingPolicy returns the name of the first Protect policy that runs in the
+// run's cluster and matches the pod, or "" when no such Protect policy matches it.
+func protectingPolicy(run *cleanupRun, ns *corev1.Namespace, pod *corev1.Pod) string {
+	for i := range run.protectors {
+		if targetsCluster(&run.protectors[i], run.clusterName) && policyMatchesPod(&run.protectors[i], ns, pod) {
+			return run.protectors[i].Name
+		}
+	}
+	return ""
+}
+
+// higherPriorityOwner returns the name of the first higher-priority policy that runs
+// in the run's cluster and also matches the pod, or "" when this run's policy
+// decides for the pod.
+func higherPriorityOwner(run *cleanupRun, ns *corev1.Namespace, pod *corev1.Pod) string {
+	for i := range run.higherPriority {
+		if targetsCluster(&run.higherPriority[i], run.clusterName) && policyMatchesPod(&run.higherPriority[i], ns, pod) {
+			return run.higherPriority[i].Name
+		}
+	}
+	return ""
+}
+
+// policyMatchesPod reports whether the pod falls within the policy's scope: its
+// namespace selector, pod selector, and pod phases. Age is deliberately ignored so a
+// higher-priority policy keeps ownership of pods it has not yet aged out.
+func policyMatchesPod(policy *cleanupv1.PodCleanupPolicy, ns *corev1.Namespace, pod *corev1.Pod) bool {
+	if !selectorMatches(policy.Spec.NamespaceSelector, ns.Labels) {
+		return false
+	}
+	if !selectorMatches(policy.Spec.PodSelector, pod.Labels) {
+		return false
+	}
+	if len(policy.Spec.PodStatuses) == 0 {
+		return true
+	}
+	for _, phase := range policy.Spec.PodStatuses {
+		if pod.Status.Phase == phase {
+			return true
+		}
+	}
+	return false
+}
+
+// selectorMatches reports whether the label set matches the selector. A nil
+// selector matches everything; an invalid one matches nothing.
+func selectorMatches(selector *metav1.LabelSelector, set map[string]string) bool {
+	if selector == nil {
+		return true
+	}
+	s, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return false
+	}
+	return s.Matches(labels.Set(set))
+}
@@ -0,0 +1,75 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	cleanupv1 "github.com/aravindavvaru/pod-cleanup-operator/api/v1"
+)
+
+//+kubebuilder:rbac:groups=cleanup.example.com,resources=podretentionpolicies,verbs=get;list;watch
+
+// podRetention is a valid PodRetentionPolicy with its duration parsed.
+type podRetention struct {
+	name      string
+	spec      *cleanupv1.PodRetentionPolicySpec
+	retainFor time.Duration
+}
+
+// retentionPolicies returns the valid PodRetentionPolicies. Invalid ones are logged
+// and ignored.
+func (r *PodCleanupPolicyReconciler) retentionPolicies(ctx context.Context) ([]podRetention, error) {
+	policyList := &cleanupv1.PodRetentionPolicyList{}
+	if err := r.List(ctx, policyList); err != nil {
+		return nil, fmt.Errorf("listing retention policies: %w", err)
+	}
+	retentions := make([]podRetention, 0, len(policyList.Items))
+	for i := range policyList.Items {
+		policy := &policyList.Items[i]
+		if errs := policy.Validate(); len(errs) > 0 {
+			log.FromContext(ctx).Info("Ignoring invalid PodRetentionPolicy", "retentionPolicy", policy.Name, "errors", errs.ToAggregate().Error())
+			continue
+		}
+		// Validated above.
+		retainFor, _ := cleanupv1.ParseDuration(policy.Spec.RetainFor)
+		retentions = append(retentions, podRetention{name: policy.Name, spec: &policy.Spec, retainFor: retainFor})
+	}
+	return retentions, nil
+}
+
+// retainingPolicy returns the PodRetentionPolicy that retains the pod longest at now,
+// and when it stops retaining the pod, or nil if no PodRetentionPolicy retains it.
+func retainingPolicy(run *cleanupRun, ns *corev1.Namespace, pod *corev1.Pod, now time.Time) (*podRetention, time.Time) {
+	var retaining *podRetention
+	var until time.Time
+	for i := range run.retentions {
+		retention := &run.retentions[i]
+		end := pod.CreationTimestamp.Add(retention.retainFor)
+		if !end.After(now) || !end.After(until) || !retentionMatches(retention.spec, ns, pod) {
+			continue
+		}
+		retaining, until = retention, end
+	}
+	return retaining, until
+}
+
+// retentionMatches reports whether the pod falls within the retention policy's
+// namespace selector, pod selector and phases.
+func retentionMatches(spec *cleanupv1.PodRetentionPolicySpec, ns *corev1.Namespace, pod *corev1.Pod) bool {
+	if !selectorMatches(spec.NamespaceSelector, ns.Labels) || !selectorMatches(spec.PodSelector, pod.Labels) {
+		return false
+	}
+	if len(spec.PodStatuses) == 0 {
+		return true
+	}
+	for _, phase := range spec.PodStatuses {
+		if pod.Status.Phase == phase {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,78 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// maxNotifiedPods caps the pods listed in a run's notifications.
+const maxNotifiedPods = 100
+
+//+kubebuilder:rbac:groups="",resources=pods/eviction,verbs=create
+
+// evictPod evicts the pod through the Eviction API, so PodDisruptionBudgets are
+// honored. An eviction a budget blocks fails with TooManyRequests.
+func (run *cleanupRun) evictPod(ctx context.Context, pod *corev1.Pod) error {
+	eviction := &policyv1.Eviction{
+		ObjectMeta: metav1.ObjectMeta{Name: pod.Name, Namespace: pod.Namespace},
+	}
+	if gracePeriod := run.gracePeriodSeconds(pod); gracePeriod != nil {
+		eviction.DeleteOptions = &metav1.DeleteOptions{GracePeriodSeconds: gracePeriod}
+	}
+	return run.podClient.SubResource("eviction").Create(ctx, pod, eviction)
+}
+
+// labelPod adds the labels of a Label rule to the pod. Pods that already carry them
+// are left alone, and dry runs only log the change.
+func (r *PodCleanupPolicyReconciler) labelPod(ctx context.Context, run *cleanupRun, pod *corev1.Pod, labels map[string]string, age time.Duration) {
+	logger := log.FromContext(ctx)
+	if run.dryRun {
+		if !run.preview {
+			logger.Info("DryRun: would label pod", "namespace", pod.Namespace, "pod", pod.Name, "labels", labels)
+		}
+		run.recordPod(pod, age, outcomeWouldLabel)
+		run.labeled++
+		return
+	}
+
+	patch := client.MergeFrom(pod.DeepCopy())
+	changed := false
+	for key, value := range labels {
+		if current, ok := pod.Labels[key]; ok && current == value {
+			continue
+		}
+		if pod.Labels == nil {
+			pod.Labels = map[string]string{}
+		}
+		pod.Labels[key] = value
+		changed = true
+	}
+	if changed {
+		logger.Info("Labeling pod", "namespace", pod.Namespace, "pod", pod.Name, "labels", labels)
+		if err := run.podClient.Patch(ctx, pod, patch); err != nil {
+			logger.Error(err, "Failed to label pod", "namespace", pod.Namespace, "pod", pod.Name)
+			run.recordPod(pod, age, outcomeLabelFailed)
+			if isTransient(err) {
+				run.transientFailures++
+			}
+			return
+		}
+	}
+	run.recordPod(pod, age, outcomeLabeled)
+	run.labeled++
+}
+
+// notifyPod notes a pod matched by a Notify rule for the run's notifications.
+func (run *cleanupRun) notifyPod(pod *corev1.Pod, age time.Duration) {
+	if len(run.notifiedPods) < maxNotifiedPods {
+		run.notifiedPods = append(run.notifiedPods, pod.Namespace+"/"+pod.Name)
+	}
+	run.notified++
+	run.recordPod(pod, age, outcomeNotified)
+}
@@ -0,0 +1,128 @@
+package controller
+
+import (
+	"context"
+	"errors"
+
+	"k8s.io/apimachinery/pkg/types"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	cleanupv1 "github.com/aravindavvaru/pod-cleanup-operator/api/v1"
+)
+
+// runCanceledError is the cause of a run canceled because its policy changed.
+type runCanceledError struct {
+	reason string
+}
+
+func (e *runCanceledError) Error() string {
+	return "run canceled: " + e.reason
+}
+
+// isRunCanceled reports whether err stopped a run because its policy was deleted,
+// suspended or switched to dry run, or dry runs were forced.
+func isRunCanceled(err error) bool {
+	var canceled *runCanceledError
+	return errors.As(err, &canceled)
+}
+
+// activeRun is a run in progress, the context it runs in and the function canceling it.
+type activeRun struct {
+	run    *cleanupRun
+	ctx    context.Context
+	cancel context.CancelCauseFunc
+}
+
+// trackRun registers run as in progress and returns the context to run it in. The
+// context is canceled when the policy is deleted, suspended or switched to dry run
+// while the run is in progress. The returned function must be called when the run
+// finishes.
+func (r *PodCleanupPolicyReconciler) trackRun(ctx context.Context, run *cleanupRun) (context.Context, func()) {
+	runCtx, cancel := context.WithCancelCause(ctx)
+
+	r.activeRunsMu.Lock()
+	defer r.activeRunsMu.Unlock()
+	if r.activeRuns == nil {
+		r.activeRuns = make(map[types.UID]activeRun)
+	}
+	r.activeRuns[run.id] = activeRun{run: run, ctx: runCtx, cancel: cancel}
+
+	return runCtx, func() {
+		r.activeRunsMu.Lock()
+		delete(r.activeRuns, run.id)
+		r.activeRunsMu.Unlock()
+		cancel(nil)
+	}
+}
+
+// cancelRuns cancels the runs in progress of policy that must not continue under its
+// current state.
+func (r *PodCleanupPolicyReconciler) cancelRuns(policy *cleanupv1.PodCleanupPolicy, deleted bool) {
+	r.activeRunsMu.Lock()
+	defer r.activeRunsMu.Unlock()
+	for _, active := range r.activeRuns {
+		if active.run.policy.Name != policy.Name {
+			continue
+		}
+		var reason string
+		switch {
+		case deleted || !policy.DeletionTimestamp.IsZero():
+			reason = "policy was deleted"
+		case policy.Spec.Suspend:
+			reason = "policy was suspended"
+		case policy.Spec.DryRun && !active.run.dryRun:
+			reason = "policy was switched to dry run"
+		default:
+			continue
+		}
+		log.FromContext(active.ctx).Info("Canceling cleanup run", "runID", active.run.id, "reason", reason)
+		active.cancel(&runCanceledError{reason: reason})
+	}
+}
+
+// cancelDeletingRuns cancels every run in progress that deletes pods.
+func (r *PodCleanupPolicyReconciler) cancelDeletingRuns(reason string) {
+	r.activeRunsMu.Lock()
+	defer r.activeRunsMu.Unlock()
+	for _, active := range r.activeRuns {
+		if active.run.dryRun {
+			continue
+		}
+		log.FromContext(active.ctx).Info("Canceling cleanup run", "runID", active.run.id, "reason", reason)
+		active.cancel(&runCanceledError{reason: reason})
+	}
+}
+
+// activeRunCount returns the number of runs of the policy in progress.
+func (r *PodCleanupPolicyReconciler) activeRunCount(policyName string) int {
+	r.activeRunsMu.Lock()
+	defer r.activeRunsMu.Unlock()
+	n := 0
+	for _, active := range r.activeRuns {
+		if active.run.policy.Name == policyName {
+			n++
+		}
+	}
+	return n
+}
+
+// runCancelHandler cancels runs as soon as their policy changes. Reconciles of a
+// policy are serialized, so the change cannot wait for the next reconcile.
+func (r *PodCleanupPolicyReconciler) runCancelHandler() toolscache.ResourceEventHandler {
+	return toolscache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(_, obj interface{}) {
+			if policy, ok := obj.(*cleanupv1.PodCleanupPolicy); ok {
+				r.cancelRuns(policy, false)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			if policy, ok := obj.(*cleanupv1.PodCleanupPolicy); ok {
+				r.cancelRuns(policy, true)
+			}
+		},
+	}
+}
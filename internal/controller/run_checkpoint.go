@@ -0,0 +1,168 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	cleanupv1 "github.com/aravindavvaru/pod-cleanup-operator/api/v1"
+)
+
+// podListPosition locates a page of pods within a namespace.
+type podListPosition struct {
+	// phase is the pod phase being listed, or "" if the run lists all phases at once.
+	phase corev1.PodPhase
+	// continueToken is the continue token of the page, or "" for the first page.
+	continueToken string
+}
+
+// interruptedRun returns the checkpoint of the policy's run that was interrupted by
+// a restart of the operator, if it can be resumed. A checkpoint that cannot be
+// resumed is cleared and its CleanupRun marked Failed: maintenance runs, whose nodes
+// are only known in memory, runs of archiving policies, whose archive uploads are
+// lost, and runs of an older generation or dry-run mode.
+func (r *PodCleanupPolicyReconciler) interruptedRun(ctx context.Context, policy *cleanupv1.PodCleanupPolicy, dryRun bool) (*cleanupv1.RunCheckpoint, error) {
+	checkpoint := policy.Status.CurrentRun
+	if checkpoint == nil {
+		return nil, nil
+	}
+	var record *cleanupv1.CleanupRun
+	if checkpoint.RunName != "" {
+		record = &cleanupv1.CleanupRun{}
+		err := r.Get(ctx, client.ObjectKey{Name: checkpoint.RunName}, record)
+		if err != nil && !errors.IsNotFound(err) {
+			return nil, err
+		}
+		if err != nil || record.Status.Phase != cleanupv1.RunPhaseRunning {
+			// The run finished, but clearing its checkpoint failed.
+			return nil, r.clearCheckpoint(ctx, policy)
+		}
+	}
+
+	archives := policy.Spec.Archive != nil
+	if spec, err := r.resolveSpec(ctx, policy); err == nil {
+		archives = spec.Archive != nil
+	}
+	var reason string
+	switch {
+	case checkpoint.Trigger == cleanupv1.TriggerMaintenance:
+		reason = "maintenance runs cannot be resumed"
+	case checkpoint.Trigger == cleanupv1.TriggerScaleDown:
+		reason = "scale-down runs cannot be resumed"
+	case archives:
+		reason = "runs of archiving policies cannot be resumed"
+	case checkpoint.ObservedGeneration != policy.Generation:
+		reason = "the policy changed"
+	case checkpoint.DryRun != dryRun:
+		reason = "dry-run mode changed"
+	default:
+		log.FromContext(ctx).Info("Resuming interrupted cleanup run", "runID", checkpoint.RunID,
+			"namespace", checkpoint.Namespace, "podsDeleted", checkpoint.PodsDeleted)
+		return checkpoint, nil
+	}
+
+	log.FromContext(ctx).Info("Not resuming interrupted cleanup run", "runID", checkpoint.RunID, "reason", reason)
+	if record != nil {
+		now := metav1.NewTime(r.Clock.Now())
+		if err := updateStatus(ctx, r.Client, record, func() {
+			record.Status.Phase = cleanupv1.RunPhaseFailed
+			record.Status.CompletionTime = &now
+			record.Status.Message = fmt.Sprintf("Run was interrupted before it completed; %s", reason)
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return nil, r.clearCheckpoint(ctx, policy)
+}
+
+// resumeRun continues the interrupted run of checkpoint in run, with the run's ID,
+// start time and CleanupRun.
+func (r *PodCleanupPolicyReconciler) resumeRun(ctx context.Context, run *cleanupRun, checkpoint *cleanupv1.RunCheckpoint) {
+	run.id = checkpoint.RunID
+	run.started = checkpoint.StartTime.Time
+	run.acknowledged = checkpoint.Acknowledged
+	run.resume = checkpoint
+	run.checkpoint = checkpoint.DeepCopy()
+	if checkpoint.RunName != "" {
+		record := &cleanupv1.CleanupRun{}
+		if err := r.Get(ctx, client.ObjectKey{Name: checkpoint.RunName}, record); err != nil {
+			log.FromContext(ctx).Error(err, "Failed to get CleanupRun of resumed run", "cleanupRun", checkpoint.RunName)
+			return
+		}
+		run.record = record
+	}
+}
+
+// startCheckpoint records the first checkpoint of a run. Maintenance and scale-down
+// runs are not checkpointed, as they cannot be resumed.
+func (r *PodCleanupPolicyReconciler) startCheckpoint(ctx context.Context, run *cleanupRun, trigger cleanupv1.RunTrigger) {
+	if nodeSweep(trigger) {
+		return
+	}
+	run.checkpoint = &cleanupv1.RunCheckpoint{
+		RunID:              run.id,
+		Trigger:            trigger,
+		StartTime:          metav1.NewTime(run.started),
+		ObservedGeneration: run.policy.Generation,
+		DryRun:             run.dryRun,
+		Acknowledged:       run.acknowledged,
+	}
+	if run.record != nil {
+		run.checkpoint.RunName = run.record.Name
+	}
+	run.checkpointWritten = r.Clock.Now()
+	r.saveCheckpoint(ctx, run)
+}
+
+// checkpoint records that the run reached position in namespace, after processing
+// namespacesProcessed namespaces and deleting deleted pods. It is written at most
+// once per ProgressInterval; a run interrupted in between resumes from the last
+// checkpoint written.
+func (r *PodCleanupPolicyReconciler) checkpoint(ctx context.Context, run *cleanupRun, namespace string, position podListPosition, namespacesProcessed, deleted int) {
+	if run.checkpoint == nil {
+		return
+	}
+	run.checkpoint.Cluster = run.clusterName
+	run.checkpoint.Namespace = namespace
+	run.checkpoint.Phase = position.phase
+	run.checkpoint.Continue = position.continueToken
+	run.checkpoint.NamespacesProcessed = int32(namespacesProcessed)
+	run.checkpoint.PodsDeleted = int32(deleted)
+	if r.progressDue(&run.checkpointWritten) {
+		r.saveCheckpoint(ctx, run)
+	}
+}
+
+// saveCheckpoint writes the run's checkpoint to the policy status. Failures are
+// logged; the run then resumes from an earlier checkpoint.
+func (r *PodCleanupPolicyReconciler) saveCheckpoint(ctx context.Context, run *cleanupRun) {
+	policy := run.policy
+	if err := updateStatus(ctx, r.Client, policy, func() {
+		policy.Status.CurrentRun = run.checkpoint.DeepCopy()
+	}); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to checkpoint cleanup run")
+	}
+}
+
+// clearCheckpoint removes the checkpoint of a run that will not be resumed.
+func (r *PodCleanupPolicyReconciler) clearCheckpoint(ctx context.Context, policy *cleanupv1.PodCleanupPolicy) error {
+	return updateStatus(ctx, r.Client, policy, func() {
+		policy.Status.CurrentRun = nil
+	})
+}
+
+// resumePosition returns where the run resumes listing pods in namespace, and stops
+// resuming: only the first namespace of a resumed run starts past its beginning.
+func (run *cleanupRun) resumePosition(namespace string) podListPosition {
+	checkpoint := run.resume
+	run.resume = nil
+	if checkpoint == nil || checkpoint.Namespace != namespace {
+		return podListPosition{}
+	}
+	return podListPosition{phase: checkpoint.Phase, continueToken: checkpoint.Continue}
+}
@@ -0,0 +1,76 @@
+package controller
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// maxEventPodsPerNamespace caps the pod names listed per namespace in a
+// RunCompleted event.
+const maxEventPodsPerNamespace = 5
+
+// removedPods tallies the pods a run deleted or evicted (or, in dry runs, would
+// have) per namespace, keeping the first maxEventPodsPerNamespace names of each.
+type removedPods struct {
+	counts map[string]int
+	names  map[string][]string
+	// monthlyCost is the estimated monthly cost of the pods under the OperatorConfig's
+	// costModel.
+	monthlyCost float64
+}
+
+// noteRemovedPod adds the pod to the run's removed pods.
+func (run *cleanupRun) noteRemovedPod(pod *corev1.Pod) {
+	if run.removed.counts == nil {
+		run.removed.counts = make(map[string]int)
+		run.removed.names = make(map[string][]string)
+	}
+	ns := run.qualifiedNamespace(pod.Namespace)
+	run.removed.counts[ns]++
+	if len(run.removed.names[ns]) < maxEventPodsPerNamespace {
+		run.removed.names[ns] = append(run.removed.names[ns], pod.Name)
+	}
+	if monthly := run.cost.Monthly(pod); monthly > 0 {
+		run.removed.monthlyCost += monthly
+		if !run.dryRun {
+			run.countSavings(monthly)
+		}
+	}
+}
+
+// runCompletedMessage describes a run that removed pods for its RunCompleted event:
+// how many, and which, capped at maxEventPodsPerNamespace pods in each of
+// maxReportedNamespaces namespaces. A resumed run only lists the pods removed since
+// it resumed.
+func runCompletedMessage(run *cleanupRun, deleted int) string {
+	verb := "Deleted"
+	if run.dryRun {
+		verb = "Would delete"
+	}
+	namespaces := make([]string, 0, len(run.removed.counts))
+	for ns := range run.removed.counts {
+		namespaces = append(namespaces, ns)
+	}
+	sort.Strings(namespaces)
+
+	parts := make([]string, 0, min(len(namespaces), maxReportedNamespaces))
+	for _, ns := range namespaces[:min(len(namespaces), maxReportedNamespaces)] {
+		names := run.removed.names[ns]
+		part := ns + ": " + strings.Join(names, ", ")
+		if omitted := run.removed.counts[ns] - len(names); omitted > 0 {
+			part += fmt.Sprintf(" and %d more", omitted)
+		}
+		parts = append(parts, part)
+	}
+	if omitted := len(namespaces) - len(parts); omitted > 0 {
+		parts = append(parts, fmt.Sprintf("%d more namespace(s)", omitted))
+	}
+	msg := fmt.Sprintf("%s %d pod(s)", verb, deleted)
+	if len(parts) > 0 {
+		msg += "; " + strings.Join(parts, "; ")
+	}
+	return msg
+}
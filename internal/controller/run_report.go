@@ -0,0 +1,320 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	cleanupv1 "github.com/aravindavvaru/pod-cleanup-operator/api/v1"
+)
+
+const (
+	// defaultRunReportHistoryLimit is the number of report ConfigMaps kept per policy
+	// when the OperatorConfig does not set one.
+	defaultRunReportHistoryLimit = 10
+	// maxReportedPods caps the pod records in a run report, keeping the ConfigMap well
+	// below the object size limit.
+	maxReportedPods = 2000
+	// runReportKey is the ConfigMap data key holding the JSON report.
+	runReportKey = "report.json"
+	// maxTerminationMessage caps the container termination messages in a report.
+	maxTerminationMessage = 256
+	// maxRunReportBytes is the report size above which container details are left
+	// out, keeping the ConfigMap below the object size limit.
+	maxRunReportBytes = 900 << 10
+)
+
+// Pod outcomes recorded in run reports.
+const (
+	outcomeDeleted             = "Deleted"
+	outcomeWouldDelete         = "WouldDelete"
+	outcomeDeleteFailed        = "DeleteFailed"
+	outcomeDeferredByQuota     = "DeferredByQuota"
+	outcomeDeferredByBudget    = "DeferredByBudget"
+	outcomeWarned              = "Warned"
+	outcomeDecisionDenied      = "DecisionDenied"
+	outcomeDisruptionProtected = "DisruptionProtected"
+	outcomeMeshExcluded        = "MeshExcluded"
+	outcomeProtected           = "Protected"
+	outcomeRetained            = "Retained"
+	outcomeKeptNewest          = "KeptNewest"
+	outcomeSkippedByPriority   = "SkippedByPriority"
+	outcomeServingTraffic      = "ServingTraffic"
+	outcomeEvicted             = "Evicted"
+	outcomeWouldEvict          = "WouldEvict"
+	outcomeEvictFailed         = "EvictFailed"
+	outcomeLabeled             = "Labeled"
+	outcomeWouldLabel          = "WouldLabel"
+	outcomeLabelFailed         = "LabelFailed"
+	outcomeNotified            = "Notified"
+	outcomeSidecarQuit         = "SidecarQuit"
+	outcomeWouldQuitSidecar    = "WouldQuitSidecar"
+	outcomeQuitSidecarFailed   = "QuitSidecarFailed"
+	outcomeArchiveFailed       = "ArchiveFailed"
+)
+
+// runReport is the JSON document written for each run.
+type runReport struct {
+	SchemaVersion         string      `json:"schemaVersion"`
+	Policy                string      `json:"policy"`
+	RunID                 string      `json:"runID"`
+	Run                   string      `json:"run,omitempty"`
+	Trigger               string      `json:"trigger"`
+	DryRun                bool        `json:"dryRun"`
+	StartTime             time.Time   `json:"startTime"`
+	CompletionTime        time.Time   `json:"completionTime"`
+	PodsDeleted           int         `json:"podsDeleted"`
+	PodsSkippedByPriority int         `json:"podsSkippedByPriority"`
+	PodsProtected         int         `json:"podsProtected"`
+	PodsRetained          int         `json:"podsRetained,omitempty"`
+	PodsDeferredByQuota   int         `json:"podsDeferredByQuota"`
+	PodsDeferredByBudget  int         `json:"podsDeferredByBudget,omitempty"`
+	PodsLabeled           int         `json:"podsLabeled,omitempty"`
+	PodsNotified          int         `json:"podsNotified,omitempty"`
+	PodsSidecarsQuit      int         `json:"podsSidecarsQuit,omitempty"`
+	Error                 string      `json:"error,omitempty"`
+	Pods                  []podRecord `json:"pods"`
+	PodsOmitted           int         `json:"podsOmitted,omitempty"`
+	// ContainersOmitted is true when container details were left out of Pods to
+	// keep the report within the ConfigMap size limit.
+	ContainersOmitted bool `json:"containersOmitted,omitempty"`
+	// EstimatedMonthlySavings is the estimated monthly cost of the pods the run
+	// removed (or would have), in Currency, if the OperatorConfig has a costModel.
+	EstimatedMonthlySavings *float64 `json:"estimatedMonthlySavings,omitempty"`
+	Currency                string   `json:"currency,omitempty"`
+}
+
+// podRecord is the outcome of a run for a single candidate pod. Removed pods also
+// record their containers, since the pod object is gone after the run.
+type podRecord struct {
+	Namespace  string `json:"namespace"`
+	Name       string `json:"name"`
+	Phase      string `json:"phase"`
+	AgeSeconds int64  `json:"ageSeconds"`
+	Outcome    string `json:"outcome"`
+	// LastTransitionTime is the latest transition of any of the pod's conditions.
+	LastTransitionTime *time.Time        `json:"lastTransitionTime,omitempty"`
+	Containers         []containerRecord `json:"containers,omitempty"`
+}
+
+// containerRecord is the state of one of a removed pod's containers.
+type containerRecord struct {
+	Name         string `json:"name"`
+	Init         bool   `json:"init,omitempty"`
+	RestartCount int32  `json:"restartCount"`
+	// State is Waiting, Running or Terminated.
+	State string `json:"state"`
+	// WaitingReason is why a Waiting container is not running, e.g. CrashLoopBackOff.
+	WaitingReason string `json:"waitingReason,omitempty"`
+	// StartedAt is when a Running container started.
+	StartedAt *time.Time `json:"startedAt,omitempty"`
+	// Terminated describes the termination of a Terminated container, and
+	// LastTerminated the previous termination of a restarted one.
+	Terminated     *terminationRecord `json:"terminated,omitempty"`
+	LastTerminated *terminationRecord `json:"lastTerminated,omitempty"`
+}
+
+// terminationRecord describes a container termination.
+type terminationRecord struct {
+	ExitCode   int32     `json:"exitCode"`
+	Signal     int32     `json:"signal,omitempty"`
+	Reason     string    `json:"reason,omitempty"`
+	Message    string    `json:"message,omitempty"`
+	StartedAt  time.Time `json:"startedAt"`
+	FinishedAt time.Time `json:"finishedAt"`
+}
+
+// recordPod notes the outcome for a candidate pod. It is a no-op unless the run
+// writes a report.
+func (run *cleanupRun) recordPod(pod *corev1.Pod, age time.Duration, outcome string) {
+	if !run.reporting {
+		return
+	}
+	if len(run.podRecords) == maxReportedPods {
+		run.podRecordsOmitted++
+		return
+	}
+	record := podRecord{
+		Namespace:  pod.Namespace,
+		Name:       pod.Name,
+		Phase:      string(pod.Status.Phase),
+		AgeSeconds: int64(age.Seconds()),
+		Outcome:    outcome,
+	}
+	if outcome == outcomeDeleted || outcome == outcomeEvicted {
+		for _, cond := range pod.Status.Conditions {
+			if t := cond.LastTransitionTime.Time; !t.IsZero() && (record.LastTransitionTime == nil || t.After(*record.LastTransitionTime)) {
+				record.LastTransitionTime = &t
+			}
+		}
+		for _, status := range pod.Status.InitContainerStatuses {
+			record.Containers = append(record.Containers, newContainerRecord(status, true))
+		}
+		for _, status := range pod.Status.ContainerStatuses {
+			record.Containers = append(record.Containers, newContainerRecord(status, false))
+		}
+	}
+	run.podRecords = append(run.podRecords, record)
+}
+
+// newContainerRecord records the state of a container from its status.
+func newContainerRecord(status corev1.ContainerStatus, init bool) containerRecord {
+	record := containerRecord{Name: status.Name, Init: init, RestartCount: status.RestartCount}
+	switch state := status.State; {
+	case state.Terminated != nil:
+		record.State = "Terminated"
+		record.Terminated = newTerminationRecord(state.Terminated)
+	case state.Running != nil:
+		record.State = "Running"
+		startedAt := state.Running.StartedAt.Time
+		record.StartedAt = &startedAt
+	default:
+		record.State = "Waiting"
+		if state.Waiting != nil {
+			record.WaitingReason = state.Waiting.Reason
+		}
+	}
+	if last := status.LastTerminationState.Terminated; last != nil {
+		record.LastTerminated = newTerminationRecord(last)
+	}
+	return record
+}
+
+func newTerminationRecord(t *corev1.ContainerStateTerminated) *terminationRecord {
+	message := t.Message
+	if len(message) > maxTerminationMessage {
+		message = message[:maxTerminationMessage] + "..."
+	}
+	return &terminationRecord{
+		ExitCode:   t.ExitCode,
+		Signal:     t.Signal,
+		Reason:     t.Reason,
+		Message:    message,
+		StartedAt:  t.StartedAt.Time,
+		FinishedAt: t.FinishedAt.Time,
+	}
+}
+
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;create;delete
+
+// writeRunReport stores the report of a finished run in a ConfigMap in the operator
+// namespace and prunes the policy's oldest reports. Failures are logged and never
+// fail the run.
+func (r *PodCleanupPolicyReconciler) writeRunReport(ctx context.Context, run *cleanupRun, trigger cleanupv1.RunTrigger, deleted int, runErr error) {
+	if !run.reporting {
+		return
+	}
+	logger := log.FromContext(ctx)
+	if r.OperatorNamespace == "" {
+		logger.Info("Skipping run report; the operator namespace is unknown")
+		return
+	}
+
+	report := runReport{
+		SchemaVersion:         cleanupv1.RecordSchemaVersion,
+		Policy:                run.policy.Name,
+		RunID:                 string(run.id),
+		Trigger:               string(trigger),
+		DryRun:                run.dryRun,
+		StartTime:             run.started,
+		CompletionTime:        r.Clock.Now(),
+		PodsDeleted:           deleted,
+		PodsSkippedByPriority: run.skippedByPriority,
+		PodsProtected:         run.protected,
+		PodsRetained:          run.retained,
+		PodsDeferredByQuota:   run.deferredByQuota,
+		PodsDeferredByBudget:  run.deferredByBudget,
+		PodsLabeled:           run.labeled,
+		PodsNotified:          run.notified,
+		PodsSidecarsQuit:      run.sidecarsQuit,
+		Pods:                  run.podRecords,
+		PodsOmitted:           run.podRecordsOmitted,
+	}
+	if run.record != nil {
+		report.Run = run.record.Name
+	}
+	if run.cost != nil {
+		savings := math.Round(run.removed.monthlyCost*100) / 100
+		report.EstimatedMonthlySavings = &savings
+		report.Currency = run.cost.Currency
+	}
+	if runErr != nil {
+		report.Error = runErr.Error()
+	}
+	if report.Pods == nil {
+		report.Pods = []podRecord{}
+	}
+	data, err := json.Marshal(report)
+	if err == nil && len(data) > maxRunReportBytes {
+		report.Pods = make([]podRecord, len(run.podRecords))
+		for i, record := range run.podRecords {
+			record.Containers = nil
+			report.Pods[i] = record
+		}
+		report.ContainersOmitted = true
+		data, err = json.Marshal(report)
+	}
+	if err != nil {
+		logger.Error(err, "Failed to encode run report")
+		return
+	}
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "cleanup-report-" + run.policy.Name + "-",
+			Namespace:    r.OperatorNamespace,
+			Labels: map[string]string{
+				cleanupv1.LabelPolicy:    run.policy.Name,
+				cleanupv1.LabelRunReport: "true",
+				cleanupv1.LabelRunID:     string(run.id),
+			},
+		},
+		Data: map[string]string{runReportKey: string(data)},
+	}
+	if err := r.Create(ctx, cm); err != nil {
+		logger.Error(err, "Failed to create run report ConfigMap")
+		return
+	}
+	run.reportName = cm.Name
+	r.pruneRunReports(ctx, run)
+}
+
+// pruneRunReports deletes the oldest report ConfigMaps of the policy beyond the
+// configured history limit.
+func (r *PodCleanupPolicyReconciler) pruneRunReports(ctx context.Context, run *cleanupRun) {
+	logger := log.FromContext(ctx)
+
+	limit := defaultRunReportHistoryLimit
+	if hl := run.config.RunReports.HistoryLimit; hl != nil {
+		limit = int(*hl)
+	}
+
+	// Read straight from the API server so the operator does not cache every
+	// ConfigMap in the cluster.
+	cmList := &corev1.ConfigMapList{}
+	if err := r.APIReader.List(ctx, cmList, client.InNamespace(r.OperatorNamespace),
+		client.MatchingLabels{cleanupv1.LabelPolicy: run.policy.Name, cleanupv1.LabelRunReport: "true"}); err != nil {
+		logger.Error(err, "Failed to list run reports for pruning")
+		return
+	}
+	if len(cmList.Items) <= limit {
+		return
+	}
+
+	sort.Slice(cmList.Items, func(i, j int) bool {
+		return cmList.Items[j].CreationTimestamp.Before(&cmList.Items[i].CreationTimestamp)
+	})
+	for i := range cmList.Items[limit:] {
+		old := &cmList.Items[limit+i]
+		if err := r.Delete(ctx, old); client.IgnoreNotFound(err) != nil {
+			logger.Error(err, "Failed to prune run report", "configMap", old.Name)
+		}
+	}
+}
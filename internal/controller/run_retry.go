@@ -0,0 +1,55 @@
+package controller
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/robfig/cron/v3"
+)
+
+const (
+	// maxRunRetries is the number of consecutive retries of a run that hit transient
+	// errors before the policy waits for its next scheduled run.
+	maxRunRetries = 5
+	// baseRetryBackoff is the delay before the first retry; it doubles with every
+	// further attempt up to maxRetryBackoff.
+	baseRetryBackoff = 10 * time.Second
+	maxRetryBackoff  = 5 * time.Minute
+)
+
+// isTransient reports whether err is likely to go away on its own, such as API
+// server throttling or a timeout.
+func isTransient(err error) bool {
+	return errors.IsTooManyRequests(err) ||
+		errors.IsServerTimeout(err) ||
+		errors.IsTimeout(err) ||
+		errors.IsServiceUnavailable(err) ||
+		errors.IsInternalError(err)
+}
+
+// retryBackoff returns the delay before the given retry attempt, starting at 1.
+func retryBackoff(attempt int32) time.Duration {
+	backoff := baseRetryBackoff
+	for i := int32(1); i < attempt; i++ {
+		backoff *= 2
+		if backoff >= maxRetryBackoff {
+			return maxRetryBackoff
+		}
+	}
+	return backoff
+}
+
+// retryDelay returns how long to wait before retrying a run that hit transient
+// errors, or zero when it should not be retried: the retries are exhausted, or the
+// next scheduled run would start before the retry.
+func retryDelay(attempt int32, schedule cron.Schedule, now time.Time) time.Duration {
+	if attempt > maxRunRetries {
+		return 0
+	}
+	delay := retryBackoff(attempt)
+	if schedule != nil && !now.Add(delay).Before(schedule.Next(now)) {
+		return 0
+	}
+	return delay
+}
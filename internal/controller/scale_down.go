@@ -0,0 +1,89 @@
+package controller
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	cleanupv1 "github.com/aravindavvaru/pod-cleanup-operator/api/v1"
+)
+
+const (
+	// taintScaleDownCandidate is set by the cluster-autoscaler on underutilized nodes
+	// it intends to remove.
+	taintScaleDownCandidate = "DeletionCandidateOfClusterAutoscaler"
+	// taintToBeDeleted is set by the cluster-autoscaler on nodes it is draining.
+	taintToBeDeleted = "ToBeDeletedByClusterAutoscaler"
+)
+
+// scaleDownCandidate reports whether the cluster-autoscaler picked the node for
+// scale down.
+func scaleDownCandidate(node *corev1.Node) bool {
+	for _, taint := range node.Spec.Taints {
+		if taint.Key == taintScaleDownCandidate || taint.Key == taintToBeDeleted {
+			return true
+		}
+	}
+	return false
+}
+
+// nodeSweep reports whether runs with the trigger only evaluate the pods on some
+// nodes.
+func nodeSweep(trigger cleanupv1.RunTrigger) bool {
+	return trigger == cleanupv1.TriggerMaintenance || trigger == cleanupv1.TriggerScaleDown
+}
+
+// policiesForScaleDownNode maps a scale-down candidate node to the policies with
+// preferScaleDownNodes, and marks the node as pending a scale-down run of each of
+// them.
+func (r *PodCleanupPolicyReconciler) policiesForScaleDownNode(ctx context.Context, obj client.Object) []reconcile.Request {
+	node, ok := obj.(*corev1.Node)
+	if !ok || !scaleDownCandidate(node) {
+		return nil
+	}
+	policyList := &cleanupv1.PodCleanupPolicyList{}
+	if err := r.List(ctx, policyList); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list policies for scale-down node", "node", node.Name)
+		return nil
+	}
+
+	r.maintenanceMu.Lock()
+	defer r.maintenanceMu.Unlock()
+	var requests []reconcile.Request
+	for i := range policyList.Items {
+		policy := &policyList.Items[i]
+		if !policy.Spec.PreferScaleDownNodes || policy.Spec.Action == cleanupv1.ActionProtect {
+			continue
+		}
+		if r.scaleDownNodes == nil {
+			r.scaleDownNodes = make(map[string]map[string]bool)
+		}
+		if r.scaleDownNodes[policy.Name] == nil {
+			r.scaleDownNodes[policy.Name] = make(map[string]bool)
+		}
+		r.scaleDownNodes[policy.Name][node.Name] = true
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: policy.Name}})
+	}
+	return requests
+}
+
+// hasScaleDownNodes reports whether nodes are pending a scale-down run of the policy.
+func (r *PodCleanupPolicyReconciler) hasScaleDownNodes(policy *cleanupv1.PodCleanupPolicy) bool {
+	r.maintenanceMu.Lock()
+	defer r.maintenanceMu.Unlock()
+	return policy.Spec.PreferScaleDownNodes && len(r.scaleDownNodes[policy.Name]) > 0
+}
+
+// takeScaleDownNodes returns the nodes pending a scale-down run of the policy and
+// clears them.
+func (r *PodCleanupPolicyReconciler) takeScaleDownNodes(policyName string) map[string]bool {
+	r.maintenanceMu.Lock()
+	defer r.maintenanceMu.Unlock()
+	nodes := r.scaleDownNodes[policyName]
+	delete(r.scaleDownNodes, policyName)
+	return nodes
+}
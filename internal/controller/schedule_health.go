@@ -0,0 +1,88 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	cleanupv1 "github.com/aravindavvaru/pod-cleanup-operator/api/v1"
+)
+
+const (
+	// scheduleHealthInterval is how often the schedules of all policies are checked.
+	scheduleHealthInterval = time.Minute
+	// scheduleHealthGrace is how late a run may start before it counts as overdue.
+	scheduleHealthGrace = 5 * time.Minute
+)
+
+// checkScheduleHealthLoop checks the schedules of all policies every
+// scheduleHealthInterval until ctx is done. It runs apart from the reconciles, so
+// it notices when they stall.
+func (r *PodCleanupPolicyReconciler) checkScheduleHealthLoop(ctx context.Context) error {
+	wait.UntilWithContext(ctx, r.checkScheduleHealth, scheduleHealthInterval)
+	return nil
+}
+
+// checkScheduleHealth sets the ScheduleHealthy condition of every scheduled policy:
+// False once its next run is overdue by more than scheduleHealthGrace and no run is
+// in progress, True otherwise. Policies without runs to expect lose the condition.
+func (r *PodCleanupPolicyReconciler) checkScheduleHealth(ctx context.Context) {
+	logger := log.FromContext(ctx)
+	policyList := &cleanupv1.PodCleanupPolicyList{}
+	if err := r.List(ctx, policyList); err != nil {
+		logger.Error(err, "Failed to list policies for schedule health")
+		return
+	}
+	now := r.Clock.Now()
+	for i := range policyList.Items {
+		policy := &policyList.Items[i]
+		current := meta.FindStatusCondition(policy.Status.Conditions, cleanupv1.ConditionScheduleHealthy)
+
+		if !hasSchedule(policy) || policy.Spec.Suspend || policy.Spec.Action == cleanupv1.ActionProtect ||
+			!policy.DeletionTimestamp.IsZero() {
+			policyScheduleHealthy.DeleteLabelValues(policy.Name)
+			if current != nil {
+				if err := updateStatus(ctx, r.Client, policy, func() {
+					meta.RemoveStatusCondition(&policy.Status.Conditions, cleanupv1.ConditionScheduleHealthy)
+				}); err != nil {
+					logger.Error(err, "Failed to update schedule health", "policy", policy.Name)
+				}
+			}
+			continue
+		}
+		next := policy.Status.NextRunTime
+		if next == nil {
+			// Not reconciled yet.
+			continue
+		}
+
+		status, reason, msg := metav1.ConditionTrue, cleanupv1.ReasonOnSchedule, "Runs start on schedule"
+		if overdue := now.Sub(next.Time); overdue > scheduleHealthGrace && r.activeRunCount(policy.Name) == 0 {
+			status, reason = metav1.ConditionFalse, cleanupv1.ReasonRunOverdue
+			msg = fmt.Sprintf("The run scheduled at %s has not started %s later", next.UTC().Format(time.RFC3339), overdue.Round(time.Second))
+		}
+		if status == metav1.ConditionTrue {
+			policyScheduleHealthy.WithLabelValues(policy.Name).Set(1)
+		} else {
+			policyScheduleHealthy.WithLabelValues(policy.Name).Set(0)
+		}
+		if current != nil && current.Status == status && current.Reason == reason {
+			continue
+		}
+		if err := updateStatus(ctx, r.Client, policy, func() {
+			r.setCondition(policy, cleanupv1.ConditionScheduleHealthy, status, reason, msg)
+		}); err != nil {
+			logger.Error(err, "Failed to update schedule health", "policy", policy.Name)
+			continue
+		}
+		if status == metav1.ConditionFalse {
+			r.Recorder.Event(policy, corev1.EventTypeWarning, "RunOverdue", msg)
+		}
+	}
+}
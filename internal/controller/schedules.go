@@ -0,0 +1,192 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	cleanupv1 "github.com/aravindavvaru/pod-cleanup-operator/api/v1"
+)
+
+//+kubebuilder:rbac:groups=cleanup.example.com,resources=cleanupschedules,verbs=get;list;watch
+
+const (
+	// scheduleRefIndex indexes policies by the CleanupSchedule they reference.
+	scheduleRefIndex = ".spec.scheduleRef"
+	// maxBlackoutSkips bounds the scheduled runs skipped in search of one outside
+	// the blackout windows.
+	maxBlackoutSkips = 10000
+)
+
+// cachedSchedule is a policy's parsed cron schedule, with the UID and schedule key
+// it was parsed for.
+type cachedSchedule struct {
+	uid      types.UID
+	key      string
+	schedule cron.Schedule
+}
+
+// scheduleOf returns the policy's parsed cron schedule and the key identifying it,
+// or nil if it has none. The key is the schedule string of inline schedules, and
+// describes the time zone and blackout windows of a referenced CleanupSchedule, so
+// it changes whenever the runs do. The schedule is parsed once per policy UID and
+// key. A missing CleanupSchedule is returned as a NotFound error.
+func (r *PodCleanupPolicyReconciler) scheduleOf(ctx context.Context, policy *cleanupv1.PodCleanupPolicy) (cron.Schedule, string, error) {
+	parse := func() (cron.Schedule, error) { return cleanupv1.ParseSchedule(policy.Spec.Schedule) }
+	key := policy.Spec.Schedule
+	if ref := policy.Spec.ScheduleRef; ref != "" {
+		shared := &cleanupv1.CleanupSchedule{}
+		if err := r.Get(ctx, client.ObjectKey{Name: ref}, shared); err != nil {
+			return nil, "", err
+		}
+		if errs := shared.Validate(); len(errs) > 0 {
+			return nil, "", fmt.Errorf("CleanupSchedule %q is invalid: %w", ref, errs.ToAggregate())
+		}
+		parse = func() (cron.Schedule, error) { return parseCleanupSchedule(&shared.Spec) }
+		key = cleanupScheduleKey(&shared.Spec)
+	}
+	if key == "" {
+		return nil, "", nil
+	}
+
+	r.schedulesMu.Lock()
+	defer r.schedulesMu.Unlock()
+	if cached, ok := r.schedules[policy.Name]; ok && cached.uid == policy.UID && cached.key == key {
+		return cached.schedule, key, nil
+	}
+	schedule, err := parse()
+	if err != nil {
+		return nil, "", err
+	}
+	if r.schedules == nil {
+		r.schedules = make(map[string]cachedSchedule)
+	}
+	r.schedules[policy.Name] = cachedSchedule{uid: policy.UID, key: key, schedule: schedule}
+	return schedule, key, nil
+}
+
+// forgetSchedule drops the parsed schedule of a deleted policy.
+func (r *PodCleanupPolicyReconciler) forgetSchedule(policyName string) {
+	r.schedulesMu.Lock()
+	defer r.schedulesMu.Unlock()
+	delete(r.schedules, policyName)
+}
+
+// hasSchedule reports whether the policy runs on a schedule, inline or referenced.
+func hasSchedule(policy *cleanupv1.PodCleanupPolicy) bool {
+	return policy.Spec.Schedule != "" || policy.Spec.ScheduleRef != ""
+}
+
+// cleanupScheduleKey describes a CleanupSchedule, e.g.
+// "CRON_TZ=Europe/Berlin 0 2 * * * except 0 0 24 12 * for 2d".
+func cleanupScheduleKey(spec *cleanupv1.CleanupScheduleSpec) string {
+	var b strings.Builder
+	b.WriteString(inTimeZone(spec.Schedule, spec.TimeZone))
+	for _, window := range spec.BlackoutWindows {
+		fmt.Fprintf(&b, " except %s for %s", window.Start, window.Duration)
+	}
+	return b.String()
+}
+
+// inTimeZone prefixes a cron expression with its time zone, if set.
+func inTimeZone(expr, timeZone string) string {
+	if timeZone == "" {
+		return expr
+	}
+	return "CRON_TZ=" + timeZone + " " + expr
+}
+
+// parseCleanupSchedule parses a validated CleanupSchedule.
+func parseCleanupSchedule(spec *cleanupv1.CleanupScheduleSpec) (cron.Schedule, error) {
+	schedule, err := cleanupv1.ParseSchedule(inTimeZone(spec.Schedule, spec.TimeZone))
+	if err != nil || len(spec.BlackoutWindows) == 0 {
+		return schedule, err
+	}
+	blackout := &blackoutSchedule{schedule: schedule}
+	for _, window := range spec.BlackoutWindows {
+		start, err := cleanupv1.ParseSchedule(inTimeZone(window.Start, spec.TimeZone))
+		if err != nil {
+			return nil, err
+		}
+		duration, err := cleanupv1.ParseDuration(window.Duration)
+		if err != nil {
+			return nil, err
+		}
+		blackout.windows = append(blackout.windows, blackoutWindow{start: start, duration: duration})
+	}
+	return blackout, nil
+}
+
+// blackoutSchedule is a schedule whose activations within blackout windows are
+// skipped.
+type blackoutSchedule struct {
+	schedule cron.Schedule
+	windows  []blackoutWindow
+}
+
+// blackoutWindow is a window opening at each activation of start, for duration.
+type blackoutWindow struct {
+	start    cron.Schedule
+	duration time.Duration
+}
+
+// Next returns the first activation after t outside every blackout window, or the
+// zero time if there is none within maxBlackoutSkips activations.
+func (s *blackoutSchedule) Next(t time.Time) time.Time {
+	next := s.schedule.Next(t)
+	for i := 0; i < maxBlackoutSkips && !next.IsZero(); i++ {
+		end, blocked := s.blackoutEnd(next)
+		if !blocked {
+			return next
+		}
+		// Activations at the end of the window are outside it.
+		next = s.schedule.Next(end.Add(-time.Nanosecond))
+	}
+	return time.Time{}
+}
+
+// blackoutEnd reports whether t is within a blackout window, and when the latest
+// window containing it closes.
+func (s *blackoutSchedule) blackoutEnd(t time.Time) (time.Time, bool) {
+	var end time.Time
+	for _, window := range s.windows {
+		// The window containing t, if any, is the first one opening after
+		// t-duration.
+		start := window.start.Next(t.Add(-window.duration))
+		if start.IsZero() || start.After(t) {
+			continue
+		}
+		if closes := start.Add(window.duration); closes.After(end) {
+			end = closes
+		}
+	}
+	return end, !end.IsZero()
+}
+
+// blackedOutUntil reports whether now is within a blackout window of the schedule,
+// and when the window closes.
+func blackedOutUntil(schedule cron.Schedule, now time.Time) (time.Time, bool) {
+	if blackout, ok := schedule.(*blackoutSchedule); ok {
+		return blackout.blackoutEnd(now)
+	}
+	return time.Time{}, false
+}
+
+// policiesForSchedule maps a CleanupSchedule to the policies referencing it.
+func (r *PodCleanupPolicyReconciler) policiesForSchedule(ctx context.Context, obj client.Object) []reconcile.Request {
+	policyList := &cleanupv1.PodCleanupPolicyList{}
+	if err := r.List(ctx, policyList, client.MatchingFields{scheduleRefIndex: obj.GetName()}); err != nil {
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(policyList.Items))
+	for _, policy := range policyList.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: policy.Name}})
+	}
+	return requests
+}
@@ -0,0 +1,57 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	cleanupv1 "github.com/aravindavvaru/pod-cleanup-operator/api/v1"
+)
+
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+
+// secretValue returns the credential the secretRef selects. Secrets are read straight
+// from the API server, so the operator does not cache every Secret in the cluster,
+// and kept until the metadata watch sees them change.
+func (r *PodCleanupPolicyReconciler) secretValue(ctx context.Context, ref *cleanupv1.SecretKeyRef) (string, error) {
+	key := types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}
+	if key.Namespace == "" {
+		key.Namespace = r.OperatorNamespace
+	}
+
+	r.secretsMu.Lock()
+	data, ok := r.secrets[key]
+	r.secretsMu.Unlock()
+	if !ok {
+		secret := &corev1.Secret{}
+		if err := r.APIReader.Get(ctx, key, secret); err != nil {
+			return "", fmt.Errorf("reading Secret %s: %w", key, err)
+		}
+		data = secret.Data
+		r.secretsMu.Lock()
+		if r.secrets == nil {
+			r.secrets = make(map[types.NamespacedName]map[string][]byte)
+		}
+		r.secrets[key] = data
+		r.secretsMu.Unlock()
+	}
+
+	value, ok := data[ref.Key]
+	if !ok {
+		return "", fmt.Errorf("key %q not found in Secret %s", ref.Key, key)
+	}
+	return string(value), nil
+}
+
+// forgetSecret drops a changed or deleted Secret from the cache, so the next use
+// rereads it. It maps no Secret to a reconcile.
+func (r *PodCleanupPolicyReconciler) forgetSecret(_ context.Context, obj client.Object) []reconcile.Request {
+	r.secretsMu.Lock()
+	defer r.secretsMu.Unlock()
+	delete(r.secrets, client.ObjectKeyFromObject(obj))
+	return nil
+}
@@ -0,0 +1,31 @@
+package controller
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	cleanupv1 "github.com/aravindavvaru/pod-cleanup-operator/api/v1"
+)
+
+// defaultMeshAnnotations are the annotations Istio and Linkerd add to the pods they
+// inject their proxies into.
+var defaultMeshAnnotations = []string{"sidecar.istio.io/status", "linkerd.io/proxy-version"}
+
+// meshAnnotation returns the annotation marking the pod as injected into a service
+// mesh, or "" if it carries none, it has finished or the OperatorConfig configures
+// no serviceMesh.
+func meshAnnotation(config *cleanupv1.OperatorConfigSpec, pod *corev1.Pod) string {
+	mesh := config.ServiceMesh
+	if mesh == nil || podFinished(pod) {
+		return ""
+	}
+	keys := defaultMeshAnnotations
+	if len(mesh.Annotations) > 0 {
+		keys = mesh.Annotations
+	}
+	for _, key := range keys {
+		if _, ok := pod.Annotations[key]; ok {
+			return key
+		}
+	}
+	return ""
+}
@@ -0,0 +1,208 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	cleanupv1 "github.com/aravindavvaru/pod-cleanup-operator/api/v1"
+)
+
+const (
+	// defaultSidecarFor is how long a sidecar may outlive the main containers of a
+	// pod before the pod counts as stuck.
+	defaultSidecarFor = 5 * time.Minute
+	// sidecarQuitTimeout bounds a quit command.
+	sidecarQuitTimeout = 30 * time.Second
+)
+
+var (
+	// defaultSidecars are the sidecars checked when stuckOnSidecar names none.
+	defaultSidecars = []string{"istio-proxy", "linkerd-proxy"}
+	// defaultQuitCommand stops istio-proxy and its pilot-agent.
+	defaultQuitCommand = []string{"pilot-agent", "request", "POST", "quitquitquit"}
+)
+
+// podStuckOnSidecar reports whether the policy's stuckOnSidecar criterion holds for
+// the pod: it is a Running Job pod whose main containers all terminated at least the
+// criterion's duration ago while one of its sidecars still runs. The explanation
+// describes the outcome.
+func (r *PodCleanupPolicyReconciler) podStuckOnSidecar(run *cleanupRun, pod *corev1.Pod) (bool, string) {
+	criteria := run.policy.Spec.StuckOnSidecar
+	if criteria == nil {
+		return true, ""
+	}
+	if pod.Status.Phase != corev1.PodRunning || !ownedByKind(pod, "Job") {
+		return false, "pod is not a Running Job pod"
+	}
+	minDuration := defaultSidecarFor
+	if criteria.For != "" {
+		// Validated with the policy spec.
+		minDuration, _ = cleanupv1.ParseDuration(criteria.For)
+	}
+
+	sidecars := sidecarNames(criteria)
+	var running []string
+	var finished time.Time
+	mainContainers := 0
+	for _, status := range pod.Status.ContainerStatuses {
+		if slices.Contains(sidecars, status.Name) {
+			if status.State.Running != nil {
+				running = append(running, status.Name)
+			}
+			continue
+		}
+		mainContainers++
+		terminated := status.State.Terminated
+		if terminated == nil {
+			return false, fmt.Sprintf("main container %s has not terminated", status.Name)
+		}
+		if terminated.FinishedAt.After(finished) {
+			finished = terminated.FinishedAt.Time
+		}
+	}
+	if mainContainers == 0 {
+		return false, "pod has no main containers"
+	}
+	if len(running) == 0 {
+		return false, "no sidecar is running"
+	}
+	if since := r.Clock.Since(finished); since < minDuration {
+		return false, fmt.Sprintf("main containers terminated %s ago, less than stuckOnSidecar.for %s",
+			since.Round(time.Second), minDuration)
+	}
+	return true, fmt.Sprintf("sidecar %s still runs after the main containers terminated", strings.Join(running, ", "))
+}
+
+// sidecarNames returns the sidecar containers of the criteria.
+func sidecarNames(criteria *cleanupv1.SidecarCriteria) []string {
+	if len(criteria.Sidecars) > 0 {
+		return criteria.Sidecars
+	}
+	return defaultSidecars
+}
+
+// ownedByKind reports whether the pod has an owner of the given kind.
+func ownedByKind(pod *corev1.Pod, kind string) bool {
+	for _, owner := range pod.OwnerReferences {
+		if owner.Kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// quitsSidecars reports whether the run quits the sidecars of stuck pods instead of
+// removing the pods.
+func (run *cleanupRun) quitsSidecars() bool {
+	criteria := run.policy.Spec.StuckOnSidecar
+	return criteria != nil && criteria.Action == cleanupv1.SidecarActionQuit
+}
+
+// quitSidecars runs the quit command in every running sidecar of a stuck pod, so the
+// pod completes on its own. Like deletions, quits are paced by the rate limits,
+// backpressure and the API budget. Dry runs only log the command.
+func (r *PodCleanupPolicyReconciler) quitSidecars(ctx context.Context, run *cleanupRun, pod *corev1.Pod, age time.Duration) error {
+	logger := log.FromContext(ctx)
+	criteria := run.policy.Spec.StuckOnSidecar
+	command := criteria.QuitCommand
+	if len(command) == 0 {
+		command = defaultQuitCommand
+	}
+	sidecars := sidecarNames(criteria)
+	if run.dryRun {
+		if !run.preview {
+			logger.Info("DryRun: would quit sidecars", "namespace", pod.Namespace, "pod", pod.Name, "command", command)
+		}
+		run.recordPod(pod, age, outcomeWouldQuitSidecar)
+		run.sidecarsQuit++
+		return nil
+	}
+
+	if err := run.limiter.Wait(ctx); err != nil {
+		return err
+	}
+	if err := r.deleteLimiter.Wait(ctx); err != nil {
+		return err
+	}
+	if err := r.Backpressure.Wait(ctx); err != nil {
+		return err
+	}
+	for _, status := range pod.Status.ContainerStatuses {
+		if !slices.Contains(sidecars, status.Name) || status.State.Running == nil {
+			continue
+		}
+		logger.Info("Quitting sidecar", "namespace", pod.Namespace, "pod", pod.Name, "container", status.Name)
+		err := r.withAPIBudget(ctx, func() error {
+			return r.execInPod(ctx, run, pod, status.Name, command)
+		})
+		if err != nil && ctx.Err() != nil {
+			return context.Cause(ctx)
+		}
+		if err != nil {
+			logger.Error(err, "Failed to quit sidecar", "namespace", pod.Namespace, "pod", pod.Name, "container", status.Name)
+			run.recordPod(pod, age, outcomeQuitSidecarFailed)
+			return nil
+		}
+	}
+	run.recordPod(pod, age, outcomeSidecarQuit)
+	run.sidecarsQuit++
+	return nil
+}
+
+// execInPod runs command in a container of the pod through the exec subresource,
+// impersonating the policy's ServiceAccount, so a policy can only run what the
+// account may run itself. Only pods of the operator's own cluster can be reached.
+func (r *PodCleanupPolicyReconciler) execInPod(ctx context.Context, run *cleanupRun, pod *corev1.Pod, container string, command []string) error {
+	if run.clusterName != "" {
+		return fmt.Errorf("running commands in pods of ClusterTarget %s is not supported", run.clusterName)
+	}
+	if r.RestConfig == nil {
+		return fmt.Errorf("no REST config to run commands with")
+	}
+	sa := run.policy.Spec.ServiceAccountName
+	if sa == "" {
+		return fmt.Errorf("quitting sidecars requires serviceAccountName")
+	}
+	cfg := rest.CopyConfig(r.RestConfig)
+	cfg.Impersonate = rest.ImpersonationConfig{
+		UserName: serviceAccountUsername(run.policy.Spec.ServiceAccountNamespace, sa),
+	}
+	clientset, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return err
+	}
+	req := clientset.CoreV1().RESTClient().Post().
+		Resource("pods").Namespace(pod.Namespace).Name(pod.Name).SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: container,
+			Command:   command,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+	executor, err := remotecommand.NewSPDYExecutor(cfg, "POST", req.URL())
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, sidecarQuitTimeout)
+	defer cancel()
+	var output bytes.Buffer
+	if err := executor.StreamWithContext(ctx, remotecommand.StreamOptions{Stdout: &output, Stderr: &output}); err != nil {
+		if msg := strings.TrimSpace(output.String()); msg != "" {
+			return fmt.Errorf("%w: %s", err, msg)
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	cleanupv1 "github.com/aravindavvaru/pod-cleanup-operator/api/v1"
+)
+
+// defaultProgressInterval is the default minimum time between progress writes of a run.
+const defaultProgressInterval = 5 * time.Second
+
+// fieldOwner is the field manager the operator applies status with.
+const fieldOwner = client.FieldOwner("pod-cleanup-operator")
+
+// operatorConditions are the condition types the operator manages. Conditions of
+// other types belong to other tools and are left to them.
+var operatorConditions = []string{
+	cleanupv1.ConditionReady, cleanupv1.ConditionProgressing, cleanupv1.ConditionSuspended, cleanupv1.ConditionDegraded,
+	cleanupv1.ConditionScheduleValid, cleanupv1.ConditionScheduleHealthy, cleanupv1.ConditionBudgetExceeded,
+	cleanupv1.ConditionDryRunForced, cleanupv1.ConditionNoMatches, cleanupv1.ConditionExhausted,
+}
+
+// updateStatus applies mutate to obj and writes its status with Server-Side Apply,
+// skipping the write if mutate changed nothing. The operator owns the status fields
+// it sets and its own conditions; fields it leaves out, such as conditions of other
+// tools, are kept. The apply carries obj's resourceVersion, so on a conflict
+// updateStatus re-reads obj and applies mutate again: mutate must compute the new
+// status from the object it is given (e.g. add to counters rather than set them from
+// a stale copy) and is never lost to a concurrent update. The status message of a
+// PodCleanupPolicy is rewritten from the mutated status, so it stays in sync.
+func updateStatus(ctx context.Context, c client.Client, obj client.Object, mutate func()) error {
+	key := client.ObjectKeyFromObject(obj)
+	first := true
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if !first {
+			if err := c.Get(ctx, key, obj); err != nil {
+				return err
+			}
+		}
+		first = false
+		base := obj.DeepCopyObject()
+		mutate()
+		if policy, ok := obj.(*cleanupv1.PodCleanupPolicy); ok {
+			policy.Status.Message = policyStatusMessage(policy)
+		}
+		if equality.Semantic.DeepEqual(base, obj) {
+			return nil
+		}
+		return applyStatus(ctx, c, obj)
+	})
+}
+
+// policyStatusMessage summarizes the policy's status in one line: why it is not
+// ready, or the outcome of its last run, followed by when it runs next.
+func policyStatusMessage(policy *cleanupv1.PodCleanupPolicy) string {
+	status := &policy.Status
+	ready := meta.FindStatusCondition(status.Conditions, cleanupv1.ConditionReady)
+	var msg string
+	switch {
+	case ready != nil && ready.Reason == cleanupv1.ReasonCleanupFailed && status.LastRunTime != nil:
+		msg = fmt.Sprintf("Last run at %s failed: %s", formatTime(status.LastRunTime), ready.Message)
+	case ready != nil && (ready.Status != metav1.ConditionTrue || policy.Spec.Action == cleanupv1.ActionProtect):
+		// Protect policies never run, and policies that are not ready explain why.
+		return ready.Message
+	case status.LastRunTime == nil:
+		msg = "No runs yet"
+	default:
+		verb := "deleted"
+		if status.LastRunDryRun {
+			verb = "would have deleted"
+		}
+		msg = fmt.Sprintf("Last run at %s %s %d pod(s) across %d namespace(s)",
+			formatTime(status.LastRunTime), verb, status.LastRunPodsDeleted, status.LastRunNamespacesCleaned)
+	}
+	switch {
+	case status.NextRetryTime != nil:
+		msg += "; retry at " + formatTime(status.NextRetryTime)
+	case status.NextRunTime != nil && (policy.Spec.Schedule != "" || policy.Spec.ScheduleRef != ""):
+		msg += "; next run at " + formatTime(status.NextRunTime)
+	}
+	return msg
+}
+
+// formatTime formats t as an RFC 3339 UTC timestamp.
+func formatTime(t *metav1.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// applyStatus applies the status of obj, without the conditions of other tools, and
+// updates the resourceVersion of obj.
+func applyStatus(ctx context.Context, c client.Client, obj client.Object) error {
+	gvk, err := apiutil.GVKForObject(obj, c.Scheme())
+	if err != nil {
+		return err
+	}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return err
+	}
+	status, _ := content["status"].(map[string]any)
+	if status == nil {
+		status = map[string]any{}
+	}
+	if conditions, ok := status["conditions"].([]any); ok {
+		status["conditions"] = slices.DeleteFunc(conditions, func(cond any) bool {
+			condType, _ := cond.(map[string]any)["type"].(string)
+			return !slices.Contains(operatorConditions, condType)
+		})
+	}
+
+	applied := &unstructured.Unstructured{Object: map[string]any{"status": status}}
+	applied.SetGroupVersionKind(gvk)
+	applied.SetNamespace(obj.GetNamespace())
+	applied.SetName(obj.GetName())
+	applied.SetResourceVersion(obj.GetResourceVersion())
+	if err := c.Status().Patch(ctx, applied, client.Apply, fieldOwner, client.ForceOwnership); err != nil {
+		return err
+	}
+	obj.SetResourceVersion(applied.GetResourceVersion())
+	return nil
+}
+
+// progressDue reports whether a run's progress, last written at *last, may be
+// written again, and if so sets *last to now. Progress reported in between is
+// coalesced into the next write, so busy runs write at most once per
+// ProgressInterval.
+func (r *PodCleanupPolicyReconciler) progressDue(last *time.Time) bool {
+	interval := r.ProgressInterval
+	if interval <= 0 {
+		interval = defaultProgressInterval
+	}
+	now := r.Clock.Now()
+	if !last.IsZero() && now.Sub(*last) < interval {
+		return false
+	}
+	*last = now
+	return true
+}
@@ -0,0 +1,105 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/aravindavvaru/pod-cleanup-operator/internal/quota"
+)
+
+const (
+	// tenantQuotaConfigMap is the ConfigMap in the operator namespace the tenant quota
+	// usage is stored in.
+	tenantQuotaConfigMap = "pod-cleanup-tenant-quota"
+	// tenantQuotaPeriod is the resolution the usage is stored at.
+	tenantQuotaPeriod = 10 * time.Minute
+)
+
+// tenantQuotas counts deletions per tenant for the OperatorConfig tenant quota. The
+// counts are kept in memory and stored in a ConfigMap in the operator namespace,
+// from which they are read before the first deletion is reserved, so a restart does
+// not reset the quota. Stored deletions are counted in periods of
+// tenantQuotaPeriod and restored at the end of their period, so a quota only gets
+// stricter across a restart. Deletions reserved after the last store are lost when
+// the operator crashes.
+type tenantQuotas struct {
+	store   *usageStore
+	tracker *quota.Tracker
+
+	mu     sync.Mutex
+	loaded bool
+	dirty  bool
+}
+
+// newTenantQuotas returns the tenant quota usage stored in namespace, read with
+// reader and written with writer. Usage is only kept in memory if namespace is "".
+func newTenantQuotas(reader client.Reader, writer client.Client, namespace string, clock clock.PassiveClock) *tenantQuotas {
+	return &tenantQuotas{
+		store:   newUsageStore(reader, writer, namespace, tenantQuotaConfigMap),
+		tracker: quota.NewTracker(24*time.Hour, clock),
+	}
+}
+
+// reserve reserves a deletion for tenant if it does not exceed limit, and reports
+// whether it did.
+func (q *tenantQuotas) reserve(ctx context.Context, tenant string, limit int) (bool, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if err := q.load(ctx); err != nil {
+		return false, fmt.Errorf("reading tenant quota usage: %w", err)
+	}
+	if !q.tracker.Reserve(tenant, limit) {
+		return false, nil
+	}
+	q.dirty = true
+	return true, nil
+}
+
+// release returns a reserved deletion that did not happen.
+func (q *tenantQuotas) release(tenant string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.tracker.Release(tenant)
+	q.dirty = true
+}
+
+// load restores the stored usage once. q.mu must be held.
+func (q *tenantQuotas) load(ctx context.Context) error {
+	if q.loaded || q.store == nil {
+		return nil
+	}
+	usage := map[string]map[int64]int{}
+	if err := q.store.read(ctx, &usage); err != nil {
+		return err
+	}
+	q.tracker.Restore(usage)
+	q.loaded = true
+	return nil
+}
+
+// Start stores changed usage periodically until ctx is done, then stores what is
+// left. It implements the controller-runtime Runnable interface.
+func (q *tenantQuotas) Start(ctx context.Context) error {
+	return storePeriodically(ctx, q.flush)
+}
+
+// flush stores the usage if it changed. Usage that could not be stored is stored
+// with the next flush.
+func (q *tenantQuotas) flush(ctx context.Context) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if !q.dirty || !q.loaded {
+		return
+	}
+	if err := q.store.write(ctx, q.tracker.Usage(tenantQuotaPeriod)); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to store tenant quota usage", "configMap", tenantQuotaConfigMap)
+		return
+	}
+	q.dirty = false
+}
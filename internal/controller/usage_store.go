@@ -0,0 +1,131 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;create;update
+
+const (
+	// usageStoreKey is the ConfigMap data key holding the stored usage as JSON.
+	usageStoreKey = "usage.json"
+	// usageStoreInterval is how often changed usage is stored.
+	usageStoreInterval = 30 * time.Second
+)
+
+// usageStore keeps deletion counts that must survive a restart, such as the tenant
+// quota and ClusterCleanupBudget usage, as JSON in a ConfigMap in the operator
+// namespace. The ConfigMap is read straight from the API server, so the operator does
+// not cache every ConfigMap in the cluster. Callers serialize the calls.
+type usageStore struct {
+	reader    client.Reader
+	writer    client.Client
+	namespace string
+	name      string
+	// stored is the ConfigMap last read or written, or nil if there is none.
+	stored *corev1.ConfigMap
+}
+
+// newUsageStore returns the store of the named ConfigMap in namespace, or nil if
+// namespace is "", in which case usage is only kept in memory.
+func newUsageStore(reader client.Reader, writer client.Client, namespace, name string) *usageStore {
+	if namespace == "" {
+		return nil
+	}
+	return &usageStore{reader: reader, writer: writer, namespace: namespace, name: name}
+}
+
+// read decodes the stored usage into v, leaving v alone if none is stored.
+func (s *usageStore) read(ctx context.Context, v any) error {
+	cm := &corev1.ConfigMap{}
+	err := s.reader.Get(ctx, client.ObjectKey{Namespace: s.namespace, Name: s.name}, cm)
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	s.stored = cm
+	if data := cm.Data[usageStoreKey]; data != "" {
+		if err := json.Unmarshal([]byte(data), v); err != nil {
+			// Starting over beats never deleting again.
+			log.FromContext(ctx).Error(err, "Ignoring unreadable usage", "configMap", s.name)
+		}
+	}
+	return nil
+}
+
+// write stores v, creating the ConfigMap if needed. Only the leader writes usage, so
+// a conflicting write is overwritten by the next one.
+func (s *usageStore) write(ctx context.Context, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if s.stored == nil {
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: s.namespace,
+				Name:      s.name,
+				Labels:    map[string]string{"app.kubernetes.io/managed-by": "pod-cleanup-operator"},
+			},
+			Data: map[string]string{usageStoreKey: string(data)},
+		}
+		if err := s.writer.Create(ctx, cm); err != nil {
+			if errors.IsAlreadyExists(err) {
+				s.refresh(ctx)
+			}
+			return err
+		}
+		s.stored = cm
+		return nil
+	}
+	cm := s.stored.DeepCopy()
+	cm.Data = map[string]string{usageStoreKey: string(data)}
+	if err := s.writer.Update(ctx, cm); err != nil {
+		if errors.IsConflict(err) || errors.IsNotFound(err) {
+			s.refresh(ctx)
+		}
+		return err
+	}
+	s.stored = cm
+	return nil
+}
+
+// refresh reads the latest version of the ConfigMap for the next write.
+func (s *usageStore) refresh(ctx context.Context) {
+	latest := &corev1.ConfigMap{}
+	err := s.reader.Get(ctx, client.ObjectKey{Namespace: s.namespace, Name: s.name}, latest)
+	switch {
+	case errors.IsNotFound(err):
+		s.stored = nil
+	case err == nil:
+		s.stored = latest
+	}
+}
+
+// storePeriodically calls store every usageStoreInterval until ctx is done, and once
+// more afterwards, so usage is stored when the operator shuts down.
+func storePeriodically(ctx context.Context, store func(context.Context)) error {
+	ticker := time.NewTicker(usageStoreInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			store(ctx)
+		case <-ctx.Done():
+			storeCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			store(storeCtx)
+			return nil
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	cleanupv1 "github.com/aravindavvaru/pod-cleanup-operator/api/v1"
+)
+
+//+kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;watch
+
+// claimNames returns the PersistentVolumeClaims the pod references, including those
+// of its generic ephemeral volumes.
+func claimNames(pod *corev1.Pod) []string {
+	var names []string
+	for _, volume := range pod.Spec.Volumes {
+		switch {
+		case volume.PersistentVolumeClaim != nil:
+			names = append(names, volume.PersistentVolumeClaim.ClaimName)
+		case volume.Ephemeral != nil:
+			names = append(names, pod.Name+"-"+volume.Name)
+		}
+	}
+	return names
+}
+
+// podStuckOnVolumeClaim reports whether the policy's stuckOnVolumeClaim criterion
+// holds for the pod: one of its claims is Pending, Lost or missing, and the pod is
+// older than the criterion's duration. The explanation describes the outcome.
+func (r *PodCleanupPolicyReconciler) podStuckOnVolumeClaim(ctx context.Context, run *cleanupRun, pod *corev1.Pod) (bool, string) {
+	criteria := run.policy.Spec.StuckOnVolumeClaim
+	if criteria == nil {
+		return true, ""
+	}
+	if criteria.For != "" {
+		// Validated with the policy spec.
+		minDuration, _ := cleanupv1.ParseDuration(criteria.For)
+		if age := r.Clock.Since(pod.CreationTimestamp.Time); age < minDuration {
+			return false, fmt.Sprintf("pod is younger than stuckOnVolumeClaim.for %s", criteria.For)
+		}
+	}
+
+	for _, name := range claimNames(pod) {
+		pvc := &corev1.PersistentVolumeClaim{}
+		err := run.cluster.Get(ctx, client.ObjectKey{Namespace: pod.Namespace, Name: name}, pvc)
+		if errors.IsNotFound(err) {
+			return true, fmt.Sprintf("claim %s does not exist", name)
+		}
+		if err != nil {
+			return false, fmt.Sprintf("claim %s could not be read: %v", name, err)
+		}
+		switch pvc.Status.Phase {
+		case corev1.ClaimPending, corev1.ClaimLost:
+			return true, fmt.Sprintf("claim %s is %s", name, pvc.Status.Phase)
+		}
+	}
+	return false, "pod has no Pending, Lost or missing volume claims"
+}
@@ -0,0 +1,95 @@
+// Package cost estimates what pods cost from their resource requests.
+package cost
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	cleanupv1 "github.com/aravindavvaru/pod-cleanup-operator/api/v1"
+)
+
+// HoursPerMonth is the average number of hours in a month.
+const HoursPerMonth = 730
+
+// gib is the number of bytes in a GiB, the unit of byte-sized resources.
+const gib = 1 << 30
+
+// Model is a compiled cleanupv1.CostModel. A nil Model prices every pod at zero.
+type Model struct {
+	prices map[corev1.ResourceName]float64
+	// Currency labels the estimates.
+	Currency string
+}
+
+// New compiles spec. It returns a nil Model for a nil spec.
+func New(spec *cleanupv1.CostModel) (*Model, error) {
+	if spec == nil {
+		return nil, nil
+	}
+	m := &Model{prices: make(map[corev1.ResourceName]float64, len(spec.Prices)), Currency: spec.Currency}
+	for name, price := range spec.Prices {
+		p, err := strconv.ParseFloat(price, 64)
+		if err != nil || p < 0 {
+			return nil, fmt.Errorf("invalid price %q for %s", price, name)
+		}
+		m.prices[name] = p
+	}
+	return m, nil
+}
+
+// Monthly returns the estimated cost of running the pod for a month. Pods that
+// finished, Succeeded or Failed, no longer hold their requests and cost nothing.
+func (m *Model) Monthly(pod *corev1.Pod) float64 {
+	if m == nil || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+		return 0
+	}
+	var hourly float64
+	for name, quantity := range Requests(pod) {
+		price, ok := m.prices[name]
+		if !ok {
+			continue
+		}
+		amount := quantity.AsApproximateFloat64()
+		if byteSized(name) {
+			amount /= gib
+		}
+		hourly += amount * price
+	}
+	return hourly * HoursPerMonth
+}
+
+// byteSized reports whether the resource is measured in bytes.
+func byteSized(name corev1.ResourceName) bool {
+	return name == corev1.ResourceMemory || name == corev1.ResourceEphemeralStorage ||
+		strings.HasPrefix(string(name), corev1.ResourceHugePagesPrefix)
+}
+
+// Requests returns the resources the scheduler reserves for the pod: the larger of
+// the sum of its app containers' requests and of each init container's requests,
+// plus its overhead.
+func Requests(pod *corev1.Pod) corev1.ResourceList {
+	requests := corev1.ResourceList{}
+	for _, container := range pod.Spec.Containers {
+		for name, quantity := range container.Resources.Requests {
+			sum := requests[name]
+			sum.Add(quantity)
+			requests[name] = sum
+		}
+	}
+	for _, container := range pod.Spec.InitContainers {
+		for name, quantity := range container.Resources.Requests {
+			if current, ok := requests[name]; !ok || quantity.Cmp(current) > 0 {
+				requests[name] = quantity.DeepCopy()
+			}
+		}
+	}
+	for name, quantity := range pod.Spec.Overhead {
+		sum := requests[name]
+		sum.Add(quantity)
+		requests[name] = sum
+	}
+	return requests
+}
@@ -0,0 +1,64 @@
+// Package features defines the feature gates of the pod-cleanup-operator.
+//
+// Risky subsystems ship behind a gate that is disabled by default and can be
+// toggled per cluster with --feature-gates, e.g.
+// --feature-gates=EventDrivenMode=true,Archive=false.
+package features
+
+import (
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/component-base/featuregate"
+)
+
+const (
+	// EventDrivenMode tracks the candidates of every policy from pod watch
+	// events, so runs process just those instead of listing every pod of the
+	// selected namespaces.
+	EventDrivenMode featuregate.Feature = "EventDrivenMode"
+
+	// Eviction allows policies to remove pods through the Eviction API
+	// (respecting PodDisruptionBudgets) instead of plain deletes.
+	Eviction featuregate.Feature = "Eviction"
+
+	// Archive stores the manifests of deleted pods in an archive backend
+	// before they are removed.
+	Archive featuregate.Feature = "Archive"
+
+	// GenericResourceCleanup extends cleanup beyond pods to other resources,
+	// such as objects orphaned by a deleted owner.
+	GenericResourceCleanup featuregate.Feature = "GenericResourceCleanup"
+
+	// NamespaceTTL lets policies expire ephemeral namespaces and delete them.
+	NamespaceTTL featuregate.Feature = "NamespaceTTL"
+
+	// MultiCluster lets policies clean up workload clusters reached through the
+	// kubeconfigs of ClusterTargets.
+	MultiCluster featuregate.Feature = "MultiCluster"
+
+	// NodeDrain runs the NodeDrainCleanup controller, which evicts or deletes the
+	// pods of drained nodes.
+	NodeDrain featuregate.Feature = "NodeDrain"
+)
+
+// defaultFeatureGates lists every known feature and its default state.
+var defaultFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
+	EventDrivenMode:        {Default: false, PreRelease: featuregate.Alpha},
+	Eviction:               {Default: false, PreRelease: featuregate.Alpha},
+	Archive:                {Default: false, PreRelease: featuregate.Alpha},
+	GenericResourceCleanup: {Default: false, PreRelease: featuregate.Alpha},
+	NamespaceTTL:           {Default: false, PreRelease: featuregate.Alpha},
+	MultiCluster:           {Default: false, PreRelease: featuregate.Alpha},
+	NodeDrain:              {Default: false, PreRelease: featuregate.Alpha},
+}
+
+// Gate is the operator-wide feature gate, populated from --feature-gates.
+var Gate = featuregate.NewFeatureGate()
+
+func init() {
+	utilruntime.Must(Gate.Add(defaultFeatureGates))
+}
+
+// Enabled reports whether the given feature is enabled.
+func Enabled(f featuregate.Feature) bool {
+	return Gate.Enabled(f)
+}
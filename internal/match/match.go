@@ -0,0 +1,211 @@
+// Package match evaluates the boolean criteria of a policy's spec.match against pods.
+package match
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	cleanupv1 "github.com/aravindavvaru/pod-cleanup-operator/api/v1"
+)
+
+// Matcher is a compiled spec.match. A nil Matcher matches every pod.
+type Matcher struct {
+	allOf []criterion
+	anyOf []*Rule
+}
+
+// Rule is a compiled anyOf group.
+type Rule struct {
+	// Name is the group's name, or "anyOf[i]" for unnamed groups.
+	Name   string
+	Action cleanupv1.RuleAction
+	Labels map[string]string
+
+	criteria []criterion
+}
+
+// criterion is a compiled cleanupv1.Criterion with its duration parsed.
+type criterion struct {
+	cleanupv1.Criterion
+	olderThan time.Duration
+}
+
+// Compile validates and compiles criteria. It returns a nil Matcher for nil criteria.
+func Compile(criteria *cleanupv1.MatchCriteria) (*Matcher, error) {
+	if criteria == nil {
+		return nil, nil
+	}
+	m := &Matcher{}
+	var err error
+	if m.allOf, err = compileAll(criteria.AllOf); err != nil {
+		return nil, fmt.Errorf("allOf: %w", err)
+	}
+	for i, g := range criteria.AnyOf {
+		compiled, err := compileAll(g.AllOf)
+		if err != nil {
+			return nil, fmt.Errorf("anyOf[%d]: %w", i, err)
+		}
+		rule := &Rule{Name: g.Name, Action: g.Action, Labels: g.Labels, criteria: compiled}
+		if rule.Name == "" {
+			rule.Name = fmt.Sprintf("anyOf[%d]", i)
+		}
+		if rule.Action == "" {
+			rule.Action = cleanupv1.RuleActionDelete
+		}
+		if rule.Action == cleanupv1.RuleActionLabel && len(rule.Labels) == 0 {
+			return nil, fmt.Errorf("anyOf[%d]: labels is required when action is Label", i)
+		}
+		m.anyOf = append(m.anyOf, rule)
+	}
+	return m, nil
+}
+
+func compileAll(criteria []cleanupv1.Criterion) ([]criterion, error) {
+	compiled := make([]criterion, 0, len(criteria))
+	for i, c := range criteria {
+		if n := fieldsSet(c); n != 1 {
+			return nil, fmt.Errorf("criterion %d: exactly one field must be set, found %d", i, n)
+		}
+		cc := criterion{Criterion: c}
+		if c.OlderThan != "" {
+			d, err := cleanupv1.ParseDuration(c.OlderThan)
+			if err != nil {
+				return nil, fmt.Errorf("criterion %d: invalid olderThan: %w", i, err)
+			}
+			cc.olderThan = d
+		}
+		if c.Condition != nil && c.Condition.For != "" {
+			if _, err := cleanupv1.ParseDuration(c.Condition.For); err != nil {
+				return nil, fmt.Errorf("criterion %d: invalid condition.for: %w", i, err)
+			}
+		}
+		compiled = append(compiled, cc)
+	}
+	return compiled, nil
+}
+
+func fieldsSet(c cleanupv1.Criterion) int {
+	n := 0
+	for _, set := range []bool{
+		len(c.Phases) > 0, c.OlderThan != "", len(c.WaitingReasons) > 0,
+		len(c.TerminatedReasons) > 0, c.Condition != nil,
+	} {
+		if set {
+			n++
+		}
+	}
+	return n
+}
+
+// Match reports whether the pod, of the given age, satisfies the criteria. rule is
+// the first anyOf group that holds, or nil if there are none. The explanation
+// describes the criterion that failed or the rule that held.
+func (m *Matcher) Match(pod *corev1.Pod, age time.Duration, now time.Time) (matched bool, rule *Rule, explanation string) {
+	if m == nil {
+		return true, nil, ""
+	}
+	for _, c := range m.allOf {
+		if ok, why := c.match(pod, age, now); !ok {
+			return false, nil, "allOf: " + why
+		}
+	}
+	if len(m.anyOf) == 0 {
+		return true, nil, ""
+	}
+	failures := make([]string, 0, len(m.anyOf))
+	for _, rule := range m.anyOf {
+		ok, why := rule.match(pod, age, now)
+		if ok {
+			return true, rule, fmt.Sprintf("matches %s", rule.Name)
+		}
+		failures = append(failures, fmt.Sprintf("%s: %s", rule.Name, why))
+	}
+	return false, nil, "no anyOf group matches (" + strings.Join(failures, "; ") + ")"
+}
+
+func (rule *Rule) match(pod *corev1.Pod, age time.Duration, now time.Time) (bool, string) {
+	for _, c := range rule.criteria {
+		if ok, why := c.match(pod, age, now); !ok {
+			return false, why
+		}
+	}
+	return true, ""
+}
+
+func (c criterion) match(pod *corev1.Pod, age time.Duration, now time.Time) (bool, string) {
+	switch {
+	case len(c.Phases) > 0:
+		if !slices.Contains(c.Phases, pod.Status.Phase) {
+			return false, fmt.Sprintf("phase %s is not one of %v", pod.Status.Phase, c.Phases)
+		}
+	case c.OlderThan != "":
+		if age < c.olderThan {
+			return false, fmt.Sprintf("age %s is below %s", age, c.OlderThan)
+		}
+	case len(c.WaitingReasons) > 0:
+		if !anyContainer(pod, func(s corev1.ContainerStatus) bool {
+			return s.State.Waiting != nil && slices.Contains(c.WaitingReasons, s.State.Waiting.Reason)
+		}) {
+			return false, fmt.Sprintf("no container is waiting with reason %v", c.WaitingReasons)
+		}
+	case len(c.TerminatedReasons) > 0:
+		if !anyContainer(pod, func(s corev1.ContainerStatus) bool {
+			return terminatedWith(s, c.TerminatedReasons)
+		}) {
+			return false, fmt.Sprintf("no container terminated with reason %v", c.TerminatedReasons)
+		}
+	case c.Condition != nil:
+		return Condition(pod, *c.Condition, now)
+	}
+	return true, ""
+}
+
+// anyContainer reports whether f holds for the status of an init or app container.
+func anyContainer(pod *corev1.Pod, f func(corev1.ContainerStatus) bool) bool {
+	return slices.ContainsFunc(pod.Status.InitContainerStatuses, f) || slices.ContainsFunc(pod.Status.ContainerStatuses, f)
+}
+
+// terminatedWith reports whether the container is, or last was, terminated with one
+// of reasons. A restarting container only keeps the reason in its last state.
+func terminatedWith(s corev1.ContainerStatus, reasons []string) bool {
+	if t := s.State.Terminated; t != nil && slices.Contains(reasons, t.Reason) {
+		return true
+	}
+	t := s.LastTerminationState.Terminated
+	return t != nil && slices.Contains(reasons, t.Reason)
+}
+
+// Condition reports whether the pod has the condition described by match at now,
+// with an explanation when it does not.
+func Condition(pod *corev1.Pod, match cleanupv1.PodConditionMatch, now time.Time) (bool, string) {
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type != match.Type {
+			continue
+		}
+		if cond.Status != match.Status || (match.Reason != "" && cond.Reason != match.Reason) {
+			return false, fmt.Sprintf("condition %s is %s (reason %q), not %s", cond.Type, cond.Status, cond.Reason, DescribeCondition(match))
+		}
+		if match.For != "" {
+			// Validated with the policy spec.
+			minDuration, _ := cleanupv1.ParseDuration(match.For)
+			if held := now.Sub(cond.LastTransitionTime.Time).Round(time.Second); held < minDuration {
+				return false, fmt.Sprintf("condition %s has been %s for %s, less than %s", cond.Type, cond.Status, held, match.For)
+			}
+		}
+		return true, ""
+	}
+	return false, fmt.Sprintf("pod has no %s condition", match.Type)
+}
+
+// DescribeCondition formats a condition match, e.g. "PodScheduled=False (reason Unschedulable)".
+func DescribeCondition(match cleanupv1.PodConditionMatch) string {
+	s := fmt.Sprintf("%s=%s", match.Type, match.Status)
+	if match.Reason != "" {
+		s += fmt.Sprintf(" (reason %s)", match.Reason)
+	}
+	return s
+}
@@ -0,0 +1,112 @@
+// Package matcher asks external matchers whether pods are cleanup candidates.
+package matcher
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	corev1 "k8s.io/api/core/v1"
+
+	matcherv1 "github.com/aravindavvaru/pod-cleanup-operator/api/matcher/v1"
+)
+
+// DefaultTimeout bounds a call when no timeout is configured.
+const DefaultTimeout = 5 * time.Second
+
+// Request describes a pod that meets all other criteria of a policy.
+type Request struct {
+	Policy string
+	RunID  string
+	// Cluster is the ClusterTarget the pod runs in, or empty for the operator's own
+	// cluster.
+	Cluster string
+	// Rule is the match.anyOf group the pod matched, if any.
+	Rule   string
+	DryRun bool
+	Pod    *corev1.Pod
+	Age    time.Duration
+}
+
+// Decision is the answer of a matcher.
+type Decision struct {
+	Match bool
+	// Reason explains the decision, if the matcher gives one.
+	Reason string
+}
+
+// Matcher decides whether pods are cleanup candidates.
+type Matcher interface {
+	Match(ctx context.Context, req Request) (Decision, error)
+}
+
+// GRPC is a Matcher calling the cleanup.matcher.v1.ExternalMatcher service of a gRPC
+// endpoint.
+type GRPC struct {
+	Timeout time.Duration
+
+	conn   *grpc.ClientConn
+	client matcherv1.ExternalMatcherClient
+}
+
+// Dial returns a GRPC matcher for the service at endpoint, connecting with TLS
+// unless tlsConfig is nil. The connection is established lazily.
+func Dial(endpoint string, tlsConfig *tls.Config, timeout time.Duration) (*GRPC, error) {
+	creds := insecure.NewCredentials()
+	if tlsConfig != nil {
+		creds = credentials.NewTLS(tlsConfig)
+	}
+	conn, err := grpc.Dial(endpoint, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, fmt.Errorf("connecting to external matcher %s: %w", endpoint, err)
+	}
+	return &GRPC{Timeout: timeout, conn: conn, client: matcherv1.NewExternalMatcherClient(conn)}, nil
+}
+
+// Match asks the service whether the pod is a candidate.
+func (g *GRPC) Match(ctx context.Context, req Request) (Decision, error) {
+	ctx, cancel := context.WithTimeout(ctx, g.Timeout)
+	defer cancel()
+	resp, err := g.client.Match(ctx, request(req))
+	if err != nil {
+		return Decision{}, err
+	}
+	return Decision{Match: resp.Match, Reason: resp.Reason}, nil
+}
+
+// Close closes the connection to the service.
+func (g *GRPC) Close() error {
+	return g.conn.Close()
+}
+
+// request converts req to its protobuf message.
+func request(req Request) *matcherv1.MatchRequest {
+	pod := req.Pod
+	owners := make([]*matcherv1.OwnerReference, 0, len(pod.OwnerReferences))
+	for _, owner := range pod.OwnerReferences {
+		owners = append(owners, &matcherv1.OwnerReference{ApiVersion: owner.APIVersion, Kind: owner.Kind, Name: owner.Name})
+	}
+	return &matcherv1.MatchRequest{
+		Policy:  req.Policy,
+		RunId:   req.RunID,
+		Cluster: req.Cluster,
+		Rule:    req.Rule,
+		DryRun:  req.DryRun,
+		Pod: &matcherv1.Pod{
+			Namespace:      pod.Namespace,
+			Name:           pod.Name,
+			Uid:            string(pod.UID),
+			Labels:         pod.Labels,
+			Annotations:    pod.Annotations,
+			Owners:         owners,
+			Phase:          string(pod.Status.Phase),
+			Node:           pod.Spec.NodeName,
+			ServiceAccount: pod.Spec.ServiceAccountName,
+			AgeSeconds:     int64(req.Age / time.Second),
+		},
+	}
+}
@@ -0,0 +1,187 @@
+package notify
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// card is a chat message with a run summary or digest, rendered as a Microsoft Teams
+// Adaptive Card, a Google Chat card or a Datadog event.
+type card struct {
+	id       string
+	title    string
+	subtitle string
+	facts    []fact
+	// text is a paragraph shown below the facts.
+	text string
+	// failed marks a failed run, or a digest of failed runs.
+	failed bool
+	// tags are the Datadog tags of the summary or digest, as key:value.
+	tags []string
+}
+
+// fact is a labeled value of a card.
+type fact struct {
+	label, value string
+}
+
+// summaryCard returns the card of a run summary.
+func summaryCard(s Summary) card {
+	c := card{
+		id:       "run-" + s.RunID,
+		title:    "Pod cleanup: " + s.Policy,
+		subtitle: "Run " + s.RunID,
+		failed:   s.Error != "",
+		tags:     []string{"policy:" + s.Policy},
+	}
+	if s.Cluster != "" {
+		c.subtitle += " in cluster " + s.Cluster
+		c.tags = append(c.tags, "cluster:"+s.Cluster)
+	}
+	deleted := "Pods deleted"
+	if s.DryRun {
+		c.title = "Pod cleanup (dry run): " + s.Policy
+		deleted = "Pods that would be deleted"
+		c.tags = append(c.tags, "dry_run:true")
+	}
+	if s.Error != "" {
+		c.title = "Pod cleanup failed: " + s.Policy
+	}
+	c.facts = append(c.facts, fact{deleted, fmt.Sprint(s.PodsDeleted)})
+	if s.PodsLabeled > 0 {
+		c.facts = append(c.facts, fact{"Pods labeled", fmt.Sprint(s.PodsLabeled)})
+	}
+	if s.PodsNotified > 0 {
+		c.facts = append(c.facts, fact{"Pods notified", fmt.Sprint(s.PodsNotified)})
+	}
+	if s.Error != "" {
+		c.facts = append(c.facts, fact{"Error", s.Error})
+	}
+	if len(s.NotifiedPods) > 0 {
+		c.text = "Notified pods: " + strings.Join(s.NotifiedPods, ", ")
+	}
+	return c
+}
+
+// digestCard returns the card of a digest, with a fact per policy.
+func digestCard(d Digest) card {
+	c := card{
+		id:    fmt.Sprintf("digest-%d", d.End.Unix()),
+		title: "Pod cleanup digest",
+		subtitle: fmt.Sprintf("%d run(s) from %s to %s", d.Runs,
+			d.Start.UTC().Format(time.RFC3339), d.End.UTC().Format(time.RFC3339)),
+	}
+	for _, policy := range d.Policies {
+		parts := []string{fmt.Sprintf("%d run(s)", policy.Runs), fmt.Sprintf("%d pod(s) deleted", policy.PodsDeleted)}
+		if policy.DryRunRuns > 0 {
+			parts = append(parts, fmt.Sprintf("%d dry run(s)", policy.DryRunRuns))
+		}
+		if policy.PodsLabeled > 0 {
+			parts = append(parts, fmt.Sprintf("%d labeled", policy.PodsLabeled))
+		}
+		if len(policy.NotifiedPods) > 0 {
+			parts = append(parts, fmt.Sprintf("%d notified", len(policy.NotifiedPods)))
+		}
+		failures := 0
+		for _, e := range policy.Errors {
+			failures += e.Count
+		}
+		if failures > 0 {
+			parts = append(parts, fmt.Sprintf("%d failed", failures))
+			c.failed = true
+		}
+		c.facts = append(c.facts, fact{policy.Policy, strings.Join(parts, ", ")})
+		c.tags = append(c.tags, "policy:"+policy.Policy)
+	}
+	return c
+}
+
+// teams returns the card as a message for a Microsoft Teams incoming webhook or
+// workflow, holding an Adaptive Card.
+func (c card) teams() map[string]any {
+	facts := make([]map[string]any, 0, len(c.facts))
+	for _, f := range c.facts {
+		facts = append(facts, map[string]any{"title": f.label, "value": f.value})
+	}
+	body := []map[string]any{
+		{"type": "TextBlock", "text": c.title, "weight": "Bolder", "size": "Medium", "wrap": true},
+		{"type": "TextBlock", "text": c.subtitle, "isSubtle": true, "spacing": "None", "wrap": true},
+		{"type": "FactSet", "facts": facts},
+	}
+	if c.text != "" {
+		body = append(body, map[string]any{"type": "TextBlock", "text": c.text, "wrap": true})
+	}
+	return map[string]any{
+		"type": "message",
+		"attachments": []map[string]any{{
+			"contentType": "application/vnd.microsoft.card.adaptive",
+			"content": map[string]any{
+				"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+				"type":    "AdaptiveCard",
+				"version": "1.4",
+				"body":    body,
+			},
+		}},
+	}
+}
+
+// googleChat returns the card as a message for a Google Chat incoming webhook. The
+// text is the notification shown where cards are not.
+func (c card) googleChat() map[string]any {
+	widgets := make([]map[string]any, 0, len(c.facts)+1)
+	for _, f := range c.facts {
+		widgets = append(widgets, map[string]any{"decoratedText": map[string]any{"topLabel": f.label, "text": f.value, "wrapText": true}})
+	}
+	if c.text != "" {
+		widgets = append(widgets, map[string]any{"textParagraph": map[string]any{"text": c.text}})
+	}
+	return map[string]any{
+		"text": c.title,
+		"cardsV2": []map[string]any{{
+			"cardId": c.id,
+			"card": map[string]any{
+				"header":   map[string]any{"title": c.title, "subtitle": c.subtitle},
+				"sections": []map[string]any{{"widgets": widgets}},
+			},
+		}},
+	}
+}
+
+// datadog returns the card as an event for the Datadog Events API, tagged with tags
+// and the card's tags. The card's tags replace those of tags with the same key.
+func (c card) datadog(tags []string) map[string]any {
+	keys := make(map[string]bool, len(c.tags))
+	for _, tag := range c.tags {
+		key, _, _ := strings.Cut(tag, ":")
+		keys[key] = true
+	}
+	eventTags := make([]string, 0, len(tags)+len(c.tags))
+	for _, tag := range tags {
+		if key, _, _ := strings.Cut(tag, ":"); !keys[key] {
+			eventTags = append(eventTags, tag)
+		}
+	}
+	eventTags = append(eventTags, c.tags...)
+
+	var text strings.Builder
+	text.WriteString("%%%\n" + c.subtitle + "\n\n")
+	for _, f := range c.facts {
+		fmt.Fprintf(&text, "- **%s**: %s\n", f.label, f.value)
+	}
+	if c.text != "" {
+		text.WriteString("\n" + c.text + "\n")
+	}
+	text.WriteString("%%%")
+
+	alertType := "success"
+	if c.failed {
+		alertType = "error"
+	}
+	return map[string]any{
+		"title":      c.title,
+		"text":       text.String(),
+		"tags":       eventTags,
+		"alert_type": alertType,
+	}
+}
@@ -0,0 +1,95 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"text/template"
+	"time"
+)
+
+// ChangeRecord is the data of a change record filed for a run. It is posted as JSON,
+// or rendered by the change record template.
+type ChangeRecord struct {
+	// SchemaVersion is cleanupv1.RecordSchemaVersion.
+	SchemaVersion string `json:"schemaVersion"`
+	// RunID is the unique ID of the run; Run names its CleanupRun, and Report the
+	// ConfigMap holding its run report in ReportNamespace, if they were written.
+	RunID           string    `json:"runID"`
+	Run             string    `json:"run,omitempty"`
+	Report          string    `json:"report,omitempty"`
+	ReportNamespace string    `json:"reportNamespace,omitempty"`
+	Policy          string    `json:"policy"`
+	Trigger         string    `json:"trigger"`
+	StartTime       time.Time `json:"startTime"`
+	CompletionTime  time.Time `json:"completionTime"`
+	PodsDeleted     int       `json:"podsDeleted"`
+	// Namespaces lists the namespaces pods were deleted in.
+	Namespaces []string `json:"namespaces"`
+	Error      string   `json:"error,omitempty"`
+}
+
+// ChangeRecorder files change records in an ITSM system through its REST API.
+type ChangeRecorder struct {
+	URL string
+	// Template, if set, renders the request body; otherwise the record is posted
+	// as JSON.
+	Template *template.Template
+	// ContentType of the request body, application/json if empty.
+	ContentType string
+	// Headers are added to every request.
+	Headers map[string]string
+	// Token, if set, is sent as a bearer token in the Authorization header.
+	Token  string
+	Client *http.Client
+}
+
+// NewChangeRecorder returns a ChangeRecorder posting to the given URL.
+func NewChangeRecorder(url string, tmpl *template.Template) *ChangeRecorder {
+	return &ChangeRecorder{URL: url, Template: tmpl, Client: &http.Client{Timeout: defaultTimeout}}
+}
+
+// File posts the change record.
+func (c *ChangeRecorder) File(ctx context.Context, record ChangeRecord) error {
+	var body []byte
+	if c.Template != nil {
+		var buf bytes.Buffer
+		if err := c.Template.Execute(&buf, record); err != nil {
+			return fmt.Errorf("rendering change record: %w", err)
+		}
+		body = buf.Bytes()
+	} else {
+		var err error
+		if body, err = json.Marshal(record); err != nil {
+			return fmt.Errorf("encoding change record: %w", err)
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	contentType := c.ContentType
+	if contentType == "" {
+		contentType = "application/json"
+	}
+	req.Header.Set("Content-Type", contentType)
+	for name, value := range c.Headers {
+		req.Header.Set(name, value)
+	}
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected response status %s", resp.Status)
+	}
+	return nil
+}
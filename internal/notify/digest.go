@@ -0,0 +1,189 @@
+package notify
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	cleanupv1 "github.com/aravindavvaru/pod-cleanup-operator/api/v1"
+)
+
+const (
+	// digestPollInterval is how often pending digests are checked for being due.
+	digestPollInterval = 10 * time.Second
+	// maxDigestPods caps the pods and run IDs listed per policy in a digest.
+	maxDigestPods = 100
+)
+
+// Digest aggregates the run summaries sent to one endpoint over an interval.
+type Digest struct {
+	SchemaVersion string         `json:"schemaVersion"`
+	Start         time.Time      `json:"start"`
+	End           time.Time      `json:"end"`
+	Runs          int            `json:"runs"`
+	Policies      []PolicyDigest `json:"policies"`
+}
+
+// PolicyDigest aggregates the runs of one policy within a digest. Repeated errors and
+// pods reported by several runs are listed once.
+type PolicyDigest struct {
+	Policy       string   `json:"policy"`
+	Runs         int      `json:"runs"`
+	DryRunRuns   int      `json:"dryRunRuns,omitempty"`
+	PodsDeleted  int      `json:"podsDeleted"`
+	PodsLabeled  int      `json:"podsLabeled,omitempty"`
+	NotifiedPods []string `json:"notifiedPods,omitempty"`
+	// RunIDs lists the IDs of the runs, capped at 100.
+	RunIDs []string      `json:"runIDs,omitempty"`
+	Errors []DigestError `json:"errors,omitempty"`
+}
+
+// DigestError is an error reported by one or more runs of a policy.
+type DigestError struct {
+	Error string `json:"error"`
+	Count int    `json:"count"`
+}
+
+// Digester collects the run summaries of endpoints in digest mode and posts each
+// endpoint a digest once per interval. Pending digests are kept in memory and
+// posted when the Digester stops.
+type Digester struct {
+	mu      sync.Mutex
+	clock   clock.PassiveClock
+	pending map[digestKey]*pendingDigest
+}
+
+type digestKey struct {
+	name, url string
+}
+
+type pendingDigest struct {
+	// webhook is the endpoint's webhook as of its latest summary, so the digest is
+	// posted with the current credentials.
+	webhook  *Webhook
+	interval time.Duration
+	start    time.Time
+	runs     int
+	policies map[string]*PolicyDigest
+	notified map[string]map[string]bool
+}
+
+// NewDigester returns a Digester timing digests with clock.
+func NewDigester(clock clock.PassiveClock) *Digester {
+	return &Digester{clock: clock, pending: make(map[digestKey]*pendingDigest)}
+}
+
+// Add adds a run summary to the next digest of the named endpoint, which is posted
+// to webhook interval after its first summary.
+func (d *Digester) Add(name string, webhook *Webhook, interval time.Duration, s Summary) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	key := digestKey{name: name, url: webhook.URL}
+	p, ok := d.pending[key]
+	if !ok {
+		p = &pendingDigest{
+			start:    d.clock.Now(),
+			policies: make(map[string]*PolicyDigest),
+			notified: make(map[string]map[string]bool),
+		}
+		d.pending[key] = p
+	}
+	p.webhook = webhook
+	p.interval = interval
+	p.runs++
+
+	policy, ok := p.policies[s.Policy]
+	if !ok {
+		policy = &PolicyDigest{Policy: s.Policy}
+		p.policies[s.Policy] = policy
+		p.notified[s.Policy] = make(map[string]bool)
+	}
+	policy.Runs++
+	if len(policy.RunIDs) < maxDigestPods {
+		policy.RunIDs = append(policy.RunIDs, s.RunID)
+	}
+	if s.DryRun {
+		policy.DryRunRuns++
+	}
+	policy.PodsDeleted += s.PodsDeleted
+	policy.PodsLabeled += s.PodsLabeled
+	for _, pod := range s.NotifiedPods {
+		if !p.notified[s.Policy][pod] && len(policy.NotifiedPods) < maxDigestPods {
+			p.notified[s.Policy][pod] = true
+			policy.NotifiedPods = append(policy.NotifiedPods, pod)
+		}
+	}
+	if s.Error != "" {
+		for i := range policy.Errors {
+			if policy.Errors[i].Error == s.Error {
+				policy.Errors[i].Count++
+				return
+			}
+		}
+		policy.Errors = append(policy.Errors, DigestError{Error: s.Error, Count: 1})
+	}
+}
+
+// Start posts due digests until ctx is done, then posts the pending ones. It
+// implements the controller-runtime Runnable interface.
+func (d *Digester) Start(ctx context.Context) error {
+	ticker := time.NewTicker(digestPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			d.flush(ctx, false)
+		case <-ctx.Done():
+			flushCtx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+			defer cancel()
+			d.flush(flushCtx, true)
+			return nil
+		}
+	}
+}
+
+// flush posts the digests whose interval has elapsed, or all of them with all set.
+// Digests of quiet intervals are dropped.
+func (d *Digester) flush(ctx context.Context, all bool) {
+	now := d.clock.Now()
+	type dueDigest struct {
+		webhook *Webhook
+		digest  Digest
+	}
+	due := make(map[digestKey]dueDigest)
+	d.mu.Lock()
+	for key, p := range d.pending {
+		if !all && now.Before(p.start.Add(p.interval)) {
+			continue
+		}
+		delete(d.pending, key)
+		if digest, ok := p.digest(now); ok {
+			due[key] = dueDigest{webhook: p.webhook, digest: digest}
+		}
+	}
+	d.mu.Unlock()
+
+	for key, d := range due {
+		if err := d.webhook.NotifyDigest(ctx, d.digest); err != nil {
+			log.FromContext(ctx).Error(err, "Failed to send notification digest", "endpoint", key.name)
+		}
+	}
+}
+
+// digest returns the digest ending at end, and false if nothing happened in it.
+func (p *pendingDigest) digest(end time.Time) (Digest, bool) {
+	digest := Digest{SchemaVersion: cleanupv1.RecordSchemaVersion, Start: p.start, End: end, Runs: p.runs}
+	quiet := true
+	for _, policy := range p.policies {
+		if policy.PodsDeleted > 0 || policy.PodsLabeled > 0 || len(policy.NotifiedPods) > 0 || len(policy.Errors) > 0 {
+			quiet = false
+		}
+		digest.Policies = append(digest.Policies, *policy)
+	}
+	sort.Slice(digest.Policies, func(i, j int) bool { return digest.Policies[i].Policy < digest.Policies[j].Policy })
+	return digest, !quiet
+}
@@ -0,0 +1,147 @@
+// Package notify delivers cleanup run summaries to external endpoints.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	cleanupv1 "github.com/aravindavvaru/pod-cleanup-operator/api/v1"
+)
+
+// defaultTimeout bounds how long a single notification may take.
+const defaultTimeout = 10 * time.Second
+
+// Headers of signed posts.
+const (
+	TimestampHeader = "X-Cleanup-Timestamp"
+	SignatureHeader = "X-Cleanup-Signature"
+)
+
+// Summary describes the outcome of a single cleanup run.
+type Summary struct {
+	// SchemaVersion is cleanupv1.RecordSchemaVersion.
+	SchemaVersion string `json:"schemaVersion"`
+	// RunID is the unique ID of the run, as in its CleanupRun and run report.
+	RunID       string    `json:"runID"`
+	Policy      string    `json:"policy"`
+	Time        time.Time `json:"time"`
+	DryRun      bool      `json:"dryRun"`
+	PodsDeleted int       `json:"podsDeleted"`
+	// PodsLabeled and PodsNotified count the pods matched by Label and Notify rules.
+	PodsLabeled  int `json:"podsLabeled,omitempty"`
+	PodsNotified int `json:"podsNotified,omitempty"`
+	// NotifiedPods lists the pods matched by Notify rules as namespace/name, capped
+	// at 100; PodsNotified has the full count.
+	NotifiedPods []string `json:"notifiedPods,omitempty"`
+	Error        string   `json:"error,omitempty"`
+	// Cluster is the ClusterTarget the run cleaned up, or empty for the operator's
+	// own cluster.
+	Cluster string `json:"cluster,omitempty"`
+}
+
+// Notifier sends run summaries to a destination.
+type Notifier interface {
+	Notify(ctx context.Context, s Summary) error
+}
+
+// Webhook posts run summaries as JSON to an HTTP endpoint.
+type Webhook struct {
+	URL string
+	// Format, if Teams or GoogleChat, posts cards for that chat instead of JSON
+	// summaries and digests, or if Datadog, Datadog events.
+	Format cleanupv1.NotificationFormat
+	// Tags are added to Datadog events.
+	Tags []string
+	// Token, if set, is sent as a bearer token in the Authorization header, or as
+	// the API key of a Datadog endpoint.
+	Token string
+	// SigningKey, if set, signs every post; see Sign.
+	SigningKey []byte
+	Client     *http.Client
+}
+
+// NewWebhook returns a Webhook notifier for the given URL.
+func NewWebhook(url string) *Webhook {
+	return &Webhook{URL: url, Client: &http.Client{Timeout: defaultTimeout}}
+}
+
+// Notify posts the summary to the webhook URL.
+func (w *Webhook) Notify(ctx context.Context, s Summary) error {
+	body, err := w.encode(s, func() card { return summaryCard(s) })
+	if err != nil {
+		return fmt.Errorf("encoding summary: %w", err)
+	}
+	return w.post(ctx, body)
+}
+
+// NotifyDigest posts the digest to the webhook URL.
+func (w *Webhook) NotifyDigest(ctx context.Context, d Digest) error {
+	body, err := w.encode(d, func() card { return digestCard(d) })
+	if err != nil {
+		return fmt.Errorf("encoding digest: %w", err)
+	}
+	return w.post(ctx, body)
+}
+
+// encode returns the body posting v in the webhook's format, as JSON or as the card
+// or event built by toCard.
+func (w *Webhook) encode(v any, toCard func() card) ([]byte, error) {
+	switch w.Format {
+	case cleanupv1.NotificationFormatTeams:
+		return json.Marshal(toCard().teams())
+	case cleanupv1.NotificationFormatGoogleChat:
+		return json.Marshal(toCard().googleChat())
+	case cleanupv1.NotificationFormatDatadog:
+		return json.Marshal(toCard().datadog(w.Tags))
+	}
+	return json.Marshal(v)
+}
+
+func (w *Webhook) post(ctx context.Context, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	switch {
+	case w.Token == "":
+	case w.Format == cleanupv1.NotificationFormatDatadog:
+		req.Header.Set("DD-API-KEY", w.Token)
+	default:
+		req.Header.Set("Authorization", "Bearer "+w.Token)
+	}
+	if len(w.SigningKey) > 0 {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		req.Header.Set(TimestampHeader, timestamp)
+		req.Header.Set(SignatureHeader, Sign(w.SigningKey, timestamp, body))
+	}
+
+	resp, err := w.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected response status %s", resp.Status)
+	}
+	return nil
+}
+
+// Sign returns the signature of a post made at timestamp: "sha256=" followed by the
+// hex HMAC-SHA256 of the timestamp, a ".", and the body. Receivers recompute it to
+// verify a post, and reject posts whose timestamp is too old to prevent replays.
+func Sign(key []byte, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
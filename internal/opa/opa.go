@@ -0,0 +1,117 @@
+// Package opa asks an Open Policy Agent, through its Data API, whether the operator
+// may remove a pod.
+package opa
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// DefaultTimeout bounds a query when no timeout is configured.
+const DefaultTimeout = 5 * time.Second
+
+// ErrUndefined is returned when the queried decision is undefined, e.g. because the
+// Rego package is not loaded.
+var ErrUndefined = errors.New("decision is undefined")
+
+// Input is the input document of a query.
+type Input struct {
+	// Policy is the PodCleanupPolicy removing the pod.
+	Policy string `json:"policy"`
+	// Cluster is the ClusterTarget the pod runs in, or empty for the operator's own
+	// cluster.
+	Cluster string `json:"cluster,omitempty"`
+	// Action is Delete, Evict or QuitSidecar.
+	Action string `json:"action"`
+	// Rule is the match.anyOf group the pod matched, if any.
+	Rule       string      `json:"rule,omitempty"`
+	AgeSeconds int64       `json:"ageSeconds"`
+	Namespace  Namespace   `json:"namespace"`
+	Pod        *corev1.Pod `json:"pod"`
+}
+
+// Namespace describes the namespace of the pod.
+type Namespace struct {
+	Name   string            `json:"name"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// Decision is the answer to a query.
+type Decision struct {
+	Allow bool
+	// Reason explains a denial, if the Rego policy gives one.
+	Reason string
+}
+
+// Client queries a decision of an OPA server.
+type Client struct {
+	// URL is the full URL of the decision, under /v1/data.
+	URL string
+	// Token, if set, is sent as a bearer token in the Authorization header.
+	Token string
+	HTTP  *http.Client
+}
+
+// New returns a client for the decision at path on the OPA server at baseURL.
+func New(baseURL, path string, timeout time.Duration) *Client {
+	return &Client{
+		URL:  strings.TrimSuffix(baseURL, "/") + "/v1/data/" + strings.Trim(path, "/"),
+		HTTP: &http.Client{Timeout: timeout},
+	}
+}
+
+// Decide queries the decision for input. The decision is either a boolean or an
+// object with a boolean allow and an optional string reason.
+func (c *Client) Decide(ctx context.Context, input Input) (Decision, error) {
+	body, err := json.Marshal(map[string]any{"input": input})
+	if err != nil {
+		return Decision{}, fmt.Errorf("encoding input: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.URL, bytes.NewReader(body))
+	if err != nil {
+		return Decision{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return Decision{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return Decision{}, fmt.Errorf("unexpected response status %s", resp.Status)
+	}
+	var response struct {
+		Result json.RawMessage `json:"result"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return Decision{}, fmt.Errorf("decoding response: %w", err)
+	}
+	if len(response.Result) == 0 {
+		return Decision{}, ErrUndefined
+	}
+
+	var allow bool
+	if err := json.Unmarshal(response.Result, &allow); err == nil {
+		return Decision{Allow: allow}, nil
+	}
+	var result struct {
+		Allow  *bool  `json:"allow"`
+		Reason string `json:"reason"`
+	}
+	if err := json.Unmarshal(response.Result, &result); err != nil || result.Allow == nil {
+		return Decision{}, fmt.Errorf("decision %s is neither a boolean nor an object with a boolean allow", response.Result)
+	}
+	return Decision{Allow: *result.Allow, Reason: result.Reason}, nil
+}
@@ -0,0 +1,136 @@
+// Package printer renders the candidates of policy previews for the command-line
+// tools, as a table, JSON or YAML.
+package printer
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/aravindavvaru/pod-cleanup-operator/internal/controller"
+	"github.com/aravindavvaru/pod-cleanup-operator/internal/report"
+)
+
+// Output formats.
+const (
+	FormatTable = "table"
+	FormatJSON  = "json"
+	FormatYAML  = "yaml"
+)
+
+// sortKeys compare candidates by the column named by the key.
+var sortKeys = map[string]func(a, b controller.Candidate) int{
+	"namespace": func(a, b controller.Candidate) int { return strings.Compare(a.Namespace, b.Namespace) },
+	"pod":       func(a, b controller.Candidate) int { return strings.Compare(a.Name, b.Name) },
+	"phase":     func(a, b controller.Candidate) int { return strings.Compare(string(a.Phase), string(b.Phase)) },
+	"rule":      func(a, b controller.Candidate) int { return strings.Compare(a.Rule, b.Rule) },
+	// Oldest first.
+	"age": func(a, b controller.Candidate) int { return int(b.Age - a.Age) },
+}
+
+// Preview is the JSON and YAML document of a preview.
+type Preview struct {
+	Policy     string                    `json:"policy"`
+	Count      int                       `json:"count"`
+	Candidates []report.CandidateSummary `json:"candidates"`
+}
+
+// Options selects how a preview is printed.
+type Options struct {
+	// Format is table, json or yaml.
+	Format string
+	// SortBy is the column candidates are sorted by: namespace, pod, phase, age or
+	// rule. Ties are broken by namespace and pod.
+	SortBy string
+	// Total is the number of candidates of the preview when only some of them are
+	// printed, e.g. because a status capped them. Zero means all are printed.
+	Total int
+}
+
+// Bind registers the -o and -sort-by flags of the options on fs.
+func (o *Options) Bind(fs *flag.FlagSet) {
+	fs.StringVar(&o.Format, "o", FormatTable, "Output format: table, json or yaml.")
+	fs.StringVar(&o.SortBy, "sort-by", "namespace", "Sort candidates by namespace, pod, phase, age (oldest first) or rule.")
+}
+
+// Validate checks the options, so a preview is not run for nothing.
+func (o *Options) Validate() error {
+	switch o.Format {
+	case FormatTable, FormatJSON, FormatYAML:
+	default:
+		return fmt.Errorf("unknown output format %q; use table, json or yaml", o.Format)
+	}
+	if _, ok := sortKeys[o.SortBy]; !ok {
+		return fmt.Errorf("unknown sort key %q; use namespace, pod, phase, age or rule", o.SortBy)
+	}
+	return nil
+}
+
+// Print sorts the candidates of the policy's preview and writes them to w.
+func (o *Options) Print(w io.Writer, policy string, candidates []controller.Candidate) error {
+	if err := o.Validate(); err != nil {
+		return err
+	}
+	compare := sortKeys[o.SortBy]
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if c := compare(a, b); c != 0 {
+			return c < 0
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+
+	switch o.Format {
+	case FormatJSON, FormatYAML:
+		preview := Preview{Policy: policy, Count: o.total(candidates), Candidates: report.SummarizeCandidates(candidates)}
+		out, err := json.MarshalIndent(preview, "", "  ")
+		if err != nil {
+			return err
+		}
+		if o.Format == FormatYAML {
+			if out, err = yaml.JSONToYAML(out); err != nil {
+				return err
+			}
+		} else {
+			out = append(out, '\n')
+		}
+		_, err = w.Write(out)
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "NAMESPACE\tPOD\tPHASE\tAGE\tRULE")
+	for _, c := range candidates {
+		rule := c.Rule
+		if rule == "" {
+			rule = "-"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", c.Namespace, c.Name, c.Phase, c.Age, rule)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if total := o.total(candidates); total > len(candidates) {
+		_, err := fmt.Fprintf(w, "\n%d pod(s) would be deleted by policy %s; %d shown\n", total, policy, len(candidates))
+		return err
+	}
+	_, err := fmt.Fprintf(w, "\n%d pod(s) would be deleted by policy %s\n", len(candidates), policy)
+	return err
+}
+
+// total returns the number of candidates of the preview.
+func (o *Options) total(candidates []controller.Candidate) int {
+	if o.Total > len(candidates) {
+		return o.Total
+	}
+	return len(candidates)
+}
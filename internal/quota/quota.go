@@ -0,0 +1,150 @@
+// Package quota tracks pod deletions per tenant over a rolling time window.
+package quota
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"k8s.io/utils/clock"
+)
+
+// Tracker counts deletions per tenant within a rolling window. Counts are kept in
+// memory; Usage and Restore carry them over a restart.
+type Tracker struct {
+	mu        sync.Mutex
+	clock     clock.PassiveClock
+	window    time.Duration
+	deletions map[string][]time.Time
+}
+
+// NewTracker returns a Tracker counting deletions within the given window, as
+// measured by clock.
+func NewTracker(window time.Duration, clock clock.PassiveClock) *Tracker {
+	return &Tracker{clock: clock, window: window, deletions: make(map[string][]time.Time)}
+}
+
+// Allow reports whether tenant may delete another pod without exceeding limit.
+func (t *Tracker) Allow(tenant string, limit int) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.prune(tenant, t.clock.Now())) < limit
+}
+
+// Reserve records a deletion for tenant if it does not exceed limit, and reports
+// whether it did. A deletion reserved but not carried out is returned with Release.
+func (t *Tracker) Reserve(tenant string, limit int) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := t.clock.Now()
+	times := t.prune(tenant, now)
+	if len(times) >= limit {
+		return false
+	}
+	t.deletions[tenant] = append(times, now)
+	return true
+}
+
+// Record notes a deletion for tenant.
+func (t *Tracker) Record(tenant string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := t.clock.Now()
+	t.deletions[tenant] = append(t.prune(tenant, now), now)
+}
+
+// Count returns the deletions of tenant within the window.
+func (t *Tracker) Count(tenant string) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.prune(tenant, t.clock.Now()))
+}
+
+// Release undoes the latest deletion recorded for tenant, e.g. one that was reserved
+// but did not happen.
+func (t *Tracker) Release(tenant string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	times := t.prune(tenant, t.clock.Now())
+	if len(times) == 0 {
+		return
+	}
+	if len(times) == 1 {
+		delete(t.deletions, tenant)
+		return
+	}
+	t.deletions[tenant] = times[:len(times)-1]
+}
+
+// Tenants returns the tenants with deletions within the window, in no particular order.
+func (t *Tracker) Tenants() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := t.clock.Now()
+	tenants := make([]string, 0, len(t.deletions))
+	for tenant := range t.deletions {
+		if len(t.prune(tenant, now)) > 0 {
+			tenants = append(tenants, tenant)
+		}
+	}
+	return tenants
+}
+
+// Usage returns the deletions of every tenant within the window, counted per period
+// and keyed by the Unix time their period ends.
+func (t *Tracker) Usage(period time.Duration) map[string]map[int64]int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := t.clock.Now()
+	usage := make(map[string]map[int64]int, len(t.deletions))
+	for tenant := range t.deletions {
+		times := t.prune(tenant, now)
+		if len(times) == 0 {
+			continue
+		}
+		counts := make(map[int64]int)
+		for _, deleted := range times {
+			counts[deleted.Truncate(period).Add(period).Unix()]++
+		}
+		usage[tenant] = counts
+	}
+	return usage
+}
+
+// Restore adds deletions counted per period, as returned by Usage. Each is taken to
+// happen when its period ends, so restored deletions leave the window no earlier
+// than the actual ones did.
+func (t *Tracker) Restore(usage map[string]map[int64]int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := t.clock.Now()
+	for tenant, counts := range usage {
+		times := t.deletions[tenant]
+		for end, count := range counts {
+			for i := 0; i < count; i++ {
+				times = append(times, time.Unix(end, 0))
+			}
+		}
+		slices.SortFunc(times, func(a, b time.Time) int { return a.Compare(b) })
+		t.deletions[tenant] = times
+		t.prune(tenant, now)
+	}
+}
+
+// prune drops deletions of tenant that fell out of the window and returns the rest.
+// The caller must hold t.mu.
+func (t *Tracker) prune(tenant string, now time.Time) []time.Time {
+	cutoff := now.Add(-t.window)
+	times := t.deletions[tenant]
+	i := 0
+	for i < len(times) && !times[i].After(cutoff) {
+		i++
+	}
+	times = times[i:]
+	if len(times) == 0 {
+		delete(t.deletions, tenant)
+		return nil
+	}
+	t.deletions[tenant] = times
+	return times
+}
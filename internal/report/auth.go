@@ -0,0 +1,142 @@
+package report
+
+import (
+	"crypto/sha256"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+//+kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenreviews,verbs=create
+//+kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+
+const (
+	// reviewTTL is how long the outcome of the reviews of a token and path is reused.
+	reviewTTL = 10 * time.Second
+	// maxCachedReviews bounds the cached review outcomes.
+	maxCachedReviews = 1024
+)
+
+// reviewCache remembers the outcome of the reviews of recent requests, keyed by a
+// hash of the token and the path, so dashboards polling the API do not cost a
+// TokenReview and a SubjectAccessReview each time.
+type reviewCache struct {
+	mu      sync.Mutex
+	entries map[[sha256.Size]byte]cachedReview
+}
+
+type cachedReview struct {
+	status  int
+	expires time.Time
+}
+
+func reviewKey(token, path string) [sha256.Size]byte {
+	return sha256.Sum256([]byte(token + "\x00" + path))
+}
+
+// get returns the cached status of the key, or 0 if there is none.
+func (c *reviewCache) get(key [sha256.Size]byte, now time.Time) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok || now.After(entry.expires) {
+		return 0
+	}
+	return entry.status
+}
+
+func (c *reviewCache) put(key [sha256.Size]byte, status int, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.entries == nil {
+		c.entries = make(map[[sha256.Size]byte]cachedReview)
+	}
+	if len(c.entries) >= maxCachedReviews {
+		for k, entry := range c.entries {
+			if now.After(entry.expires) {
+				delete(c.entries, k)
+			}
+		}
+		if len(c.entries) >= maxCachedReviews {
+			c.entries = make(map[[sha256.Size]byte]cachedReview)
+		}
+	}
+	c.entries[key] = cachedReview{status: status, expires: now.Add(reviewTTL)}
+}
+
+// WithAuth wraps handler so that every request must carry a bearer token that the
+// API server accepts (TokenReview) and whose user may "get" the request path as a
+// non-resource URL (SubjectAccessReview), e.g. through a ClusterRole rule with
+// nonResourceURLs: ["/report/*"]. The outcome for a token and path is reused for
+// reviewTTL.
+func WithAuth(c client.Client, handler http.Handler) http.Handler {
+	cache := &reviewCache{}
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		token, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+		if !ok || token == "" {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		key := reviewKey(token, req.URL.Path)
+		now := time.Now()
+		status := cache.get(key, now)
+		if status == 0 {
+			status = review(req, c, token)
+			if status != http.StatusInternalServerError {
+				cache.put(key, status, now)
+			}
+		}
+		if status != http.StatusOK {
+			http.Error(w, http.StatusText(status), status)
+			return
+		}
+		handler.ServeHTTP(w, req)
+	})
+}
+
+// review checks the token and the user's access to the request path with the API
+// server, returning the HTTP status the request gets.
+func review(req *http.Request, c client.Client, token string) int {
+	ctx := req.Context()
+	logger := log.FromContext(ctx).WithName("report")
+
+	tokenReview := &authenticationv1.TokenReview{Spec: authenticationv1.TokenReviewSpec{Token: token}}
+	if err := c.Create(ctx, tokenReview); err != nil {
+		logger.Error(err, "TokenReview failed")
+		return http.StatusInternalServerError
+	}
+	if !tokenReview.Status.Authenticated {
+		return http.StatusUnauthorized
+	}
+
+	user := tokenReview.Status.User
+	extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+	for k, v := range user.Extra {
+		extra[k] = authorizationv1.ExtraValue(v)
+	}
+	access := &authorizationv1.SubjectAccessReview{Spec: authorizationv1.SubjectAccessReviewSpec{
+		User:   user.Username,
+		UID:    user.UID,
+		Groups: user.Groups,
+		Extra:  extra,
+		NonResourceAttributes: &authorizationv1.NonResourceAttributes{
+			Path: req.URL.Path,
+			Verb: "get",
+		},
+	}}
+	if err := c.Create(ctx, access); err != nil {
+		logger.Error(err, "SubjectAccessReview failed")
+		return http.StatusInternalServerError
+	}
+	if !access.Status.Allowed {
+		return http.StatusForbidden
+	}
+	return http.StatusOK
+}
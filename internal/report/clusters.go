@@ -0,0 +1,113 @@
+package report
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+
+	cleanupv1 "github.com/aravindavvaru/pod-cleanup-operator/api/v1"
+)
+
+// ClusterSummary aggregates the last runs of the policies that clean up a workload
+// cluster.
+type ClusterSummary struct {
+	Name string `json:"name"`
+	// Registered is false if no ClusterTarget of the name exists but policies
+	// reference it.
+	Registered bool `json:"registered"`
+	// PodsDeleted and PodsDeferred sum the policies' last runs in the cluster;
+	// PodsDeferred is the candidate backlog left for later runs.
+	PodsDeleted  int64 `json:"podsDeleted"`
+	PodsDeferred int64 `json:"podsDeferred"`
+	// Failures is the number of policies whose last run could not clean up the cluster.
+	Failures    int                    `json:"failures"`
+	LastRunTime *time.Time             `json:"lastRunTime,omitempty"`
+	Policies    []ClusterPolicySummary `json:"policies"`
+}
+
+// ClusterPolicySummary describes the last run of a policy in a workload cluster.
+type ClusterPolicySummary struct {
+	Name                string     `json:"name"`
+	LastRunTime         *time.Time `json:"lastRunTime,omitempty"`
+	PodsDeleted         int32      `json:"podsDeleted"`
+	PodsDeferred        int32      `json:"podsDeferred"`
+	NamespacesProcessed int32      `json:"namespacesProcessed"`
+	Error               string     `json:"error,omitempty"`
+}
+
+func (h *Handler) clusters(ctx context.Context) ([]ClusterSummary, error) {
+	targetList := &cleanupv1.ClusterTargetList{}
+	if err := h.Client.List(ctx, targetList); err != nil {
+		return nil, err
+	}
+	policyList := &cleanupv1.PodCleanupPolicyList{}
+	if err := h.Client.List(ctx, policyList); err != nil {
+		return nil, err
+	}
+
+	byName := make(map[string]*ClusterSummary, len(targetList.Items))
+	for _, target := range targetList.Items {
+		byName[target.Name] = &ClusterSummary{Name: target.Name, Registered: true, Policies: []ClusterPolicySummary{}}
+	}
+	for i := range policyList.Items {
+		policy := &policyList.Items[i]
+		for _, name := range policy.Spec.ClusterRefs {
+			summary, ok := byName[name]
+			if !ok {
+				summary = &ClusterSummary{Name: name, Policies: []ClusterPolicySummary{}}
+				byName[name] = summary
+			}
+			summary.add(policy)
+		}
+	}
+
+	summaries := make([]ClusterSummary, 0, len(byName))
+	for _, summary := range byName {
+		sort.Slice(summary.Policies, func(i, j int) bool { return summary.Policies[i].Name < summary.Policies[j].Name })
+		summaries = append(summaries, *summary)
+	}
+	sort.Slice(summaries, func(i, j int) bool { return summaries[i].Name < summaries[j].Name })
+	return summaries, nil
+}
+
+func (h *Handler) cluster(ctx context.Context, name string) (ClusterSummary, error) {
+	summaries, err := h.clusters(ctx)
+	if err != nil {
+		return ClusterSummary{}, err
+	}
+	for _, summary := range summaries {
+		if summary.Name == name {
+			return summary, nil
+		}
+	}
+	return ClusterSummary{}, errors.NewNotFound(cleanupv1.GroupVersion.WithResource("clustertargets").GroupResource(), name)
+}
+
+// add folds the policy's last run in the cluster into the summary. A policy that has
+// not run in the cluster yet is listed without counts.
+func (summary *ClusterSummary) add(policy *cleanupv1.PodCleanupPolicy) {
+	entry := ClusterPolicySummary{Name: policy.Name}
+	for _, result := range policy.Status.Clusters {
+		if result.Name != summary.Name {
+			continue
+		}
+		if policy.Status.LastRunTime != nil {
+			entry.LastRunTime = &policy.Status.LastRunTime.Time
+		}
+		entry.PodsDeleted = result.PodsDeleted
+		entry.PodsDeferred = result.PodsDeferred
+		entry.NamespacesProcessed = result.NamespacesProcessed
+		entry.Error = result.Error
+	}
+	summary.PodsDeleted += int64(entry.PodsDeleted)
+	summary.PodsDeferred += int64(entry.PodsDeferred)
+	if entry.Error != "" {
+		summary.Failures++
+	}
+	if entry.LastRunTime != nil && (summary.LastRunTime == nil || entry.LastRunTime.After(*summary.LastRunTime)) {
+		summary.LastRunTime = entry.LastRunTime
+	}
+	summary.Policies = append(summary.Policies, entry)
+}
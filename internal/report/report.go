@@ -0,0 +1,275 @@
+// Package report serves read-only JSON summaries of policies, their runs, their
+// current candidates and the workload clusters they clean up over HTTP.
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	cleanupv1 "github.com/aravindavvaru/pod-cleanup-operator/api/v1"
+	"github.com/aravindavvaru/pod-cleanup-operator/internal/controller"
+)
+
+// PathPrefix is the path under which the report API is served.
+const PathPrefix = "/report/"
+
+// candidatesTTL is how long the candidates of a policy are served from the last
+// preview before another one lists its pods.
+const candidatesTTL = 30 * time.Second
+
+// Previewer computes the pods a run of a policy would delete.
+type Previewer interface {
+	Preview(ctx context.Context, policy *cleanupv1.PodCleanupPolicy) ([]controller.Candidate, error)
+}
+
+// Handler serves the report API:
+//
+//	GET /report/policies                    Summaries of all policies
+//	GET /report/policies/<name>             Summary of one policy
+//	GET /report/policies/<name>/runs        Recorded runs of the policy, newest first
+//	GET /report/policies/<name>/candidates  Pods a run of the policy would delete now
+//	GET /report/clusters                    Last runs of policies per workload cluster
+//	GET /report/clusters/<name>             Last runs of policies in one workload cluster
+//
+// Client and Previewer must be set before the handler serves requests. Previews run
+// one at a time, and their candidates are reused for candidatesTTL.
+type Handler struct {
+	Client    client.Reader
+	Previewer Previewer
+
+	previewMu sync.Mutex
+	previews  map[string]cachedCandidates
+}
+
+type cachedCandidates struct {
+	generation int64
+	summaries  []CandidateSummary
+	expires    time.Time
+}
+
+// PolicySummary describes a policy and the outcome of its last run.
+type PolicySummary struct {
+	Name               string     `json:"name"`
+	Action             string     `json:"action"`
+	Schedule           string     `json:"schedule,omitempty"`
+	ScheduleRef        string     `json:"scheduleRef,omitempty"`
+	DryRun             bool       `json:"dryRun"`
+	Ready              string     `json:"ready"`
+	Message            string     `json:"message,omitempty"`
+	LastRunTime        *time.Time `json:"lastRunTime,omitempty"`
+	LastScheduleTime   *time.Time `json:"lastScheduleTime,omitempty"`
+	LastRunTrigger     string     `json:"lastRunTrigger,omitempty"`
+	LastRunPodsDeleted int32      `json:"lastRunPodsDeleted"`
+	PodsDeleted        int64      `json:"podsDeleted"`
+}
+
+// RunSummary describes a recorded run of a policy.
+type RunSummary struct {
+	Name                string     `json:"name"`
+	Trigger             string     `json:"trigger"`
+	DryRun              bool       `json:"dryRun"`
+	Phase               string     `json:"phase"`
+	StartTime           *time.Time `json:"startTime,omitempty"`
+	CompletionTime      *time.Time `json:"completionTime,omitempty"`
+	NamespacesTotal     int32      `json:"namespacesTotal"`
+	NamespacesProcessed int32      `json:"namespacesProcessed"`
+	PodsDeleted         int32      `json:"podsDeleted"`
+	Message             string     `json:"message,omitempty"`
+}
+
+// CandidateSummary describes a pod a run of a policy would delete.
+type CandidateSummary struct {
+	Namespace  string `json:"namespace"`
+	Name       string `json:"name"`
+	Phase      string `json:"phase"`
+	AgeSeconds int64  `json:"ageSeconds"`
+	Rule       string `json:"rule,omitempty"`
+}
+
+// ServeHTTP routes report API requests.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(req.URL.Path, PathPrefix), "/"), "/")
+	ctx := req.Context()
+	if parts[0] == "clusters" && len(parts) <= 2 {
+		if len(parts) == 1 {
+			h.serveJSON(w, req, func() (any, error) { return h.clusters(ctx) })
+		} else {
+			h.serveJSON(w, req, func() (any, error) { return h.cluster(ctx, parts[1]) })
+		}
+		return
+	}
+	if parts[0] != "policies" || len(parts) > 3 {
+		http.NotFound(w, req)
+		return
+	}
+	switch {
+	case len(parts) == 1:
+		h.serveJSON(w, req, func() (any, error) { return h.policies(ctx) })
+	case len(parts) == 2:
+		h.serveJSON(w, req, func() (any, error) { return h.policy(ctx, parts[1]) })
+	case parts[2] == "runs":
+		h.serveJSON(w, req, func() (any, error) { return h.runs(ctx, parts[1]) })
+	case parts[2] == "candidates":
+		h.serveJSON(w, req, func() (any, error) { return h.candidates(ctx, parts[1]) })
+	default:
+		http.NotFound(w, req)
+	}
+}
+
+// serveJSON writes the result of fn as JSON, mapping NotFound errors to 404.
+func (h *Handler) serveJSON(w http.ResponseWriter, req *http.Request, fn func() (any, error)) {
+	result, err := fn()
+	if errors.IsNotFound(err) {
+		http.NotFound(w, req)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(result)
+}
+
+func (h *Handler) policies(ctx context.Context) ([]PolicySummary, error) {
+	policyList := &cleanupv1.PodCleanupPolicyList{}
+	if err := h.Client.List(ctx, policyList); err != nil {
+		return nil, err
+	}
+	summaries := make([]PolicySummary, 0, len(policyList.Items))
+	for i := range policyList.Items {
+		summaries = append(summaries, summarizePolicy(&policyList.Items[i]))
+	}
+	sort.Slice(summaries, func(i, j int) bool { return summaries[i].Name < summaries[j].Name })
+	return summaries, nil
+}
+
+func (h *Handler) policy(ctx context.Context, name string) (PolicySummary, error) {
+	policy := &cleanupv1.PodCleanupPolicy{}
+	if err := h.Client.Get(ctx, client.ObjectKey{Name: name}, policy); err != nil {
+		return PolicySummary{}, err
+	}
+	return summarizePolicy(policy), nil
+}
+
+func (h *Handler) runs(ctx context.Context, name string) ([]RunSummary, error) {
+	if _, err := h.policy(ctx, name); err != nil {
+		return nil, err
+	}
+	runList := &cleanupv1.CleanupRunList{}
+	if err := h.Client.List(ctx, runList, client.MatchingLabels{cleanupv1.LabelPolicy: name}); err != nil {
+		return nil, err
+	}
+	sort.Slice(runList.Items, func(i, j int) bool {
+		return runList.Items[j].CreationTimestamp.Before(&runList.Items[i].CreationTimestamp)
+	})
+
+	summaries := make([]RunSummary, 0, len(runList.Items))
+	for _, run := range runList.Items {
+		summary := RunSummary{
+			Name:                run.Name,
+			Trigger:             string(run.Spec.Trigger),
+			DryRun:              run.Spec.DryRun,
+			Phase:               string(run.Status.Phase),
+			NamespacesTotal:     run.Status.NamespacesTotal,
+			NamespacesProcessed: run.Status.NamespacesProcessed,
+			PodsDeleted:         run.Status.PodsDeleted,
+			Message:             run.Status.Message,
+		}
+		if run.Status.StartTime != nil {
+			summary.StartTime = &run.Status.StartTime.Time
+		}
+		if run.Status.CompletionTime != nil {
+			summary.CompletionTime = &run.Status.CompletionTime.Time
+		}
+		summaries = append(summaries, summary)
+	}
+	return summaries, nil
+}
+
+func (h *Handler) candidates(ctx context.Context, name string) ([]CandidateSummary, error) {
+	policy := &cleanupv1.PodCleanupPolicy{}
+	if err := h.Client.Get(ctx, client.ObjectKey{Name: name}, policy); err != nil {
+		return nil, err
+	}
+
+	h.previewMu.Lock()
+	defer h.previewMu.Unlock()
+	now := time.Now()
+	if cached, ok := h.previews[name]; ok && cached.generation == policy.Generation && now.Before(cached.expires) {
+		return cached.summaries, nil
+	}
+	candidates, err := h.Previewer.Preview(ctx, policy)
+	if err != nil {
+		return nil, err
+	}
+	summaries := SummarizeCandidates(candidates)
+	if h.previews == nil {
+		h.previews = make(map[string]cachedCandidates)
+	}
+	for key, cached := range h.previews {
+		if now.After(cached.expires) {
+			delete(h.previews, key)
+		}
+	}
+	h.previews[name] = cachedCandidates{generation: policy.Generation, summaries: summaries, expires: now.Add(candidatesTTL)}
+	return summaries, nil
+}
+
+// SummarizeCandidates flattens the candidates of a preview into CandidateSummaries.
+func SummarizeCandidates(candidates []controller.Candidate) []CandidateSummary {
+	summaries := make([]CandidateSummary, 0, len(candidates))
+	for _, c := range candidates {
+		summaries = append(summaries, CandidateSummary{
+			Namespace:  c.Namespace,
+			Name:       c.Name,
+			Phase:      string(c.Phase),
+			AgeSeconds: int64(c.Age.Seconds()),
+			Rule:       c.Rule,
+		})
+	}
+	return summaries
+}
+
+// summarizePolicy flattens a policy and its Ready condition into a PolicySummary.
+func summarizePolicy(policy *cleanupv1.PodCleanupPolicy) PolicySummary {
+	summary := PolicySummary{
+		Name:               policy.Name,
+		Action:             string(policy.Spec.Action),
+		Schedule:           policy.Spec.Schedule,
+		ScheduleRef:        policy.Spec.ScheduleRef,
+		DryRun:             policy.Spec.DryRun,
+		Ready:              "Unknown",
+		LastRunTrigger:     string(policy.Status.LastRunTrigger),
+		LastRunPodsDeleted: policy.Status.LastRunPodsDeleted,
+		PodsDeleted:        policy.Status.PodsDeleted,
+	}
+	if summary.Action == "" {
+		summary.Action = string(cleanupv1.ActionDelete)
+	}
+	if policy.Status.LastRunTime != nil {
+		summary.LastRunTime = &policy.Status.LastRunTime.Time
+	}
+	if policy.Status.LastScheduleTime != nil {
+		summary.LastScheduleTime = &policy.Status.LastScheduleTime.Time
+	}
+	if ready := meta.FindStatusCondition(policy.Status.Conditions, cleanupv1.ConditionReady); ready != nil {
+		summary.Ready = string(ready.Status)
+		summary.Message = ready.Message
+	}
+	return summary
+}